@@ -0,0 +1,111 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Command optimizely-diff reports the flags, rules, variations, variables, audiences and traffic allocations which
+// were added, removed or changed between two datafiles.
+//
+// Usage:
+//
+//	optimizely-diff [-format text|json] old-datafile.json new-datafile.json
+//
+// Like diff, the exit code is 0 when the datafiles have no differences, 1 when they do and 2 when a datafile can not
+// be read or parsed.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// Exit codes of the command
+const (
+	exitSame        = 0
+	exitDifferences = 1
+	exitFailure     = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("optimizely-diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format, text or json")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	fail := func(err error) int {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	if *format != "text" && *format != "json" {
+		return fail(fmt.Errorf("unsupported format %q", *format))
+	}
+	if flags.NArg() != 2 {
+		return fail(errors.New("usage: optimizely-diff [-format text|json] old-datafile.json new-datafile.json"))
+	}
+
+	// the SDK logs about the datafiles are noise here, parse errors are reported by the command
+	logging.SetLogger(logging.NewFilteredLevelLogConsumer(logging.LogLevelError, io.Discard))
+	oldConfig, err := loadConfig(flags.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+	newConfig, err := loadConfig(flags.Arg(1))
+	if err != nil {
+		return fail(err)
+	}
+
+	diff := configdiff.Compare(oldConfig, newConfig)
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return fail(err)
+		}
+	} else {
+		for _, change := range diff.Changes {
+			fmt.Fprintln(stdout, change)
+		}
+	}
+
+	if diff.IsEmpty() {
+		return exitSame
+	}
+	return exitDifferences
+}
+
+func loadConfig(path string) (*datafileprojectconfig.DatafileProjectConfig, error) {
+	datafile, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	projectConfig, err := datafileprojectconfig.NewDatafileProjectConfig(datafile, logging.GetLogger("", "DatafileProjectConfig"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid datafile: %w", path, err)
+	}
+	return projectConfig, nil
+}
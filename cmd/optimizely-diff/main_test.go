@@ -0,0 +1,114 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+	datafile *datafileEntities.Datafile
+	dir      string
+}
+
+func (s *DiffTestSuite) SetupTest() {
+	b := builder.New().Revision("1").Attribute("age")
+	b.Audience("adults", builder.Match("age", "ge", 18))
+	checkout := b.Flag("checkout").Variable("limit", entities.Integer, 10)
+	checkout.Experiment("checkout_test").
+		Audiences("adults").
+		Variation("blue", true, nil).
+		Variation("green", true, builder.Vars{"limit": 20})
+	checkout.EveryoneElse().Enabled(true)
+
+	var err error
+	s.datafile, err = b.Datafile()
+	s.Require().NoError(err)
+	s.dir = s.T().TempDir()
+}
+
+func (s *DiffTestSuite) write(name string) string {
+	jsonDatafile, err := json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, jsonDatafile, 0600))
+	return path
+}
+
+func (s *DiffTestSuite) TestNoDifferences() {
+	oldPath := s.write("old.json")
+	s.datafile.Revision = "2"
+	newPath := s.write("new.json")
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitSame, run([]string{oldPath, newPath}, &stdout, &stderr))
+	s.Empty(stdout.String())
+	s.Empty(stderr.String())
+}
+
+func (s *DiffTestSuite) TestDifferences() {
+	oldPath := s.write("old.json")
+	s.datafile.Revision = "2"
+	s.datafile.FeatureFlags[0].Variables[0].DefaultValue = "15"
+	s.datafile.Experiments[0].Status = string(entities.ExperimentStatusPaused)
+	newPath := s.write("new.json")
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitDifferences, run([]string{oldPath, newPath}, &stdout, &stderr))
+	s.Equal(`changed variable checkout/limit defaultValue: "10" -> "15"
+changed rule checkout/checkout_test status: "Running" -> "Paused"
+`, stdout.String())
+
+	stdout.Reset()
+	s.Equal(exitDifferences, run([]string{"-format", "json", oldPath, newPath}, &stdout, &stderr))
+	var diff configdiff.Diff
+	s.Require().NoError(json.Unmarshal(stdout.Bytes(), &diff))
+	s.Equal("1", diff.OldRevision)
+	s.Equal("2", diff.NewRevision)
+	s.Len(diff.Changes, 2)
+	s.Equal([]string{"checkout"}, diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestFailures() {
+	path := s.write("datafile.json")
+	invalid := filepath.Join(s.dir, "invalid.json")
+	s.Require().NoError(os.WriteFile(invalid, []byte("{"), 0600))
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitFailure, run([]string{path}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{path, filepath.Join(s.dir, "missing.json")}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{path, invalid}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{"-format", "yaml", path, path}, &stdout, &stderr))
+	s.Empty(stdout.String())
+	s.Contains(stderr.String(), "invalid.json: invalid datafile")
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
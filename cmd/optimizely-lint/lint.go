@@ -0,0 +1,333 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/mappers"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator/matchers"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// maxTrafficAllocation is the end of the bucketing range, traffic allocations are in basis points
+const maxTrafficAllocation = 10000
+
+// Severity tells whether a finding fails the lint
+type Severity string
+
+const (
+	// SeverityError findings break decisions and fail the lint
+	SeverityError Severity = "error"
+	// SeverityWarning findings are likely mistakes, they only fail the lint in strict mode
+	SeverityWarning Severity = "warning"
+)
+
+// Names of the checks, they are reported with each finding
+const (
+	CheckMissingAudience   = "missing-audience"
+	CheckUnknownMatcher    = "unknown-matcher"
+	CheckTrafficAllocation = "traffic-allocation"
+	CheckVariableDefault   = "variable-default"
+	CheckRollout           = "rollout"
+	CheckSharedExperiment  = "shared-experiment"
+)
+
+// Finding is a problem found in a datafile
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s] %s", f.Severity, f.Check, f.Message)
+}
+
+// Lint parses the datafile and returns the findings of every check, the error is only set if the datafile is not valid
+func Lint(jsonDatafile []byte) ([]Finding, error) {
+	datafile, err := datafileprojectconfig.Parse(jsonDatafile)
+	if err != nil {
+		return nil, err
+	}
+	projectConfig, err := datafileprojectconfig.NewDatafileProjectConfig(jsonDatafile, logging.GetLogger("", "Linter"))
+	if err != nil {
+		return nil, err
+	}
+
+	l := linter{datafile: datafile, projectConfig: projectConfig, findings: []Finding{}}
+	l.checkAudiences()
+	l.checkMatchers()
+	l.checkTrafficAllocations()
+	l.checkVariableDefaults()
+	l.checkRollouts()
+	l.checkSharedExperiments()
+	return l.findings, nil
+}
+
+type linter struct {
+	datafile      *datafileEntities.Datafile
+	projectConfig *datafileprojectconfig.DatafileProjectConfig
+	findings      []Finding
+}
+
+func (l *linter) report(severity Severity, check, format string, arguments ...interface{}) {
+	l.findings = append(l.findings, Finding{Severity: severity, Check: check, Message: fmt.Sprintf(format, arguments...)})
+}
+
+// rule is an experiment, rollout rule or holdout, they are all bucketed the same way
+type rule struct {
+	kind       string
+	experiment entities.Experiment
+}
+
+func (r rule) String() string {
+	return fmt.Sprintf("%s %q", r.kind, r.experiment.Key)
+}
+
+func (l *linter) rules() []rule {
+	rules := []rule{}
+	experiments := l.projectConfig.GetExperimentList()
+	sort.Slice(experiments, func(i, j int) bool { return experiments[i].Key < experiments[j].Key })
+	for _, experiment := range experiments {
+		rules = append(rules, rule{kind: "experiment", experiment: experiment})
+	}
+	rollouts := l.projectConfig.GetRolloutList()
+	sort.Slice(rollouts, func(i, j int) bool { return rollouts[i].ID < rollouts[j].ID })
+	for _, rollout := range rollouts {
+		for _, experiment := range rollout.Experiments {
+			rules = append(rules, rule{kind: "rollout rule", experiment: experiment})
+		}
+	}
+	for _, holdout := range l.projectConfig.GetHoldoutList() {
+		rules = append(rules, rule{kind: "holdout", experiment: holdout.ToExperiment()})
+	}
+	return rules
+}
+
+func (l *linter) checkAudiences() {
+	audienceMap := l.projectConfig.GetAudienceMap()
+	for _, r := range l.rules() {
+		// the tree is built from the audience IDs when the rule has no audience conditions, otherwise they are ignored
+		referenced := map[string]bool{}
+		walkTree(r.experiment.AudienceConditionTree, func(item interface{}) {
+			if audienceID, ok := item.(string); ok {
+				referenced[audienceID] = true
+			}
+		})
+		for _, audienceID := range sortedKeys(referenced) {
+			if _, ok := audienceMap[audienceID]; !ok {
+				l.report(SeverityError, CheckMissingAudience, "%s references audience %q, which is not in the datafile", r, audienceID)
+			}
+		}
+	}
+}
+
+func (l *linter) checkMatchers() {
+	audiences := l.projectConfig.GetAudienceList()
+	sort.Slice(audiences, func(i, j int) bool { return audiences[i].ID < audiences[j].ID })
+	for _, audience := range audiences {
+		walkTree(audience.ConditionTree, func(item interface{}) {
+			condition, ok := item.(entities.Condition)
+			if !ok || condition.Match == "" {
+				// conditions without a match type use the exact matcher
+				return
+			}
+			if _, ok := matchers.Get(condition.Match); !ok {
+				l.report(SeverityError, CheckUnknownMatcher, "audience %q uses the unknown matcher %q for %q", audience.ID, condition.Match, condition.Name)
+			}
+		})
+	}
+}
+
+func (l *linter) checkTrafficAllocations() {
+	for _, r := range l.rules() {
+		variationIDs := map[string]bool{}
+		for id := range r.experiment.Variations {
+			variationIDs[id] = true
+		}
+		l.checkTrafficAllocation(r.String(), "variation", r.experiment.TrafficAllocation, variationIDs)
+	}
+
+	groupMap, _ := mappers.MapGroups(l.datafile.Groups)
+	for _, group := range l.datafile.Groups {
+		experimentIDs := map[string]bool{}
+		for _, experiment := range group.Experiments {
+			experimentIDs[experiment.ID] = true
+		}
+		l.checkTrafficAllocation(fmt.Sprintf("group %q", group.ID), "experiment", groupMap[group.ID].TrafficAllocation, experimentIDs)
+	}
+}
+
+// checkTrafficAllocation reports ranges the bucketer can never reach and traffic which is allocated to nothing
+func (l *linter) checkTrafficAllocation(owner, entityKind string, ranges []entities.Range, entityIDs map[string]bool) {
+	if len(ranges) == 0 {
+		l.report(SeverityWarning, CheckTrafficAllocation, "%s has no traffic allocated", owner)
+		return
+	}
+
+	previousEnd := 0
+	for _, trafficRange := range ranges {
+		switch {
+		case trafficRange.EndOfRange < previousEnd:
+			l.report(SeverityError, CheckTrafficAllocation, "%s allocates range [%d, %d) to %s %q, which overlaps the previous ranges",
+				owner, previousEnd, trafficRange.EndOfRange, entityKind, trafficRange.EntityID)
+			continue
+		case trafficRange.EndOfRange > maxTrafficAllocation:
+			l.report(SeverityError, CheckTrafficAllocation, "%s allocates traffic up to %d, past the end of the bucketing range %d",
+				owner, trafficRange.EndOfRange, maxTrafficAllocation)
+		case trafficRange.EntityID == "" && trafficRange.EndOfRange > previousEnd:
+			l.report(SeverityWarning, CheckTrafficAllocation, "%s leaves range [%d, %d) unallocated", owner, previousEnd, trafficRange.EndOfRange)
+		case trafficRange.EntityID != "" && !entityIDs[trafficRange.EntityID]:
+			l.report(SeverityError, CheckTrafficAllocation, "%s allocates range [%d, %d) to %s %q, which is not in the datafile",
+				owner, previousEnd, trafficRange.EndOfRange, entityKind, trafficRange.EntityID)
+		}
+		previousEnd = trafficRange.EndOfRange
+	}
+
+	if previousEnd < maxTrafficAllocation {
+		l.report(SeverityWarning, CheckTrafficAllocation, "%s leaves range [%d, %d) unallocated", owner, previousEnd, maxTrafficAllocation)
+	}
+}
+
+func (l *linter) checkVariableDefaults() {
+	for _, feature := range l.features() {
+		variables := make([]entities.Variable, 0, len(feature.VariableMap))
+		for _, variable := range feature.VariableMap {
+			variables = append(variables, variable)
+		}
+		sort.Slice(variables, func(i, j int) bool { return variables[i].Key < variables[j].Key })
+		for _, variable := range variables {
+			if err := parseVariableValue(variable.Type, variable.DefaultValue); err != nil {
+				l.report(SeverityError, CheckVariableDefault, "flag %q variable %q: default value %q is not a valid %s: %s",
+					feature.Key, variable.Key, variable.DefaultValue, variable.Type, err)
+			}
+		}
+	}
+}
+
+// parseVariableValue parses the value the way the client does for the given variable type
+func parseVariableValue(variableType entities.VariableType, value string) (err error) {
+	switch variableType {
+	case entities.String:
+	case entities.Integer:
+		_, err = strconv.Atoi(value)
+	case entities.Double:
+		_, err = strconv.ParseFloat(value, 64)
+	case entities.Boolean:
+		_, err = strconv.ParseBool(value)
+	case entities.JSON:
+		err = json.Unmarshal([]byte(value), &map[string]interface{}{})
+	default:
+		err = fmt.Errorf("unknown variable type %q", variableType)
+	}
+	return err
+}
+
+func (l *linter) checkRollouts() {
+	rolloutIDs := map[string]bool{}
+	for _, rollout := range l.datafile.Rollouts {
+		rolloutIDs[rollout.ID] = true
+	}
+
+	for _, flag := range l.sortedFlags() {
+		if flag.RolloutID == "" {
+			l.report(SeverityWarning, CheckRollout, "flag %q has no rollout", flag.Key)
+			continue
+		}
+		if !rolloutIDs[flag.RolloutID] {
+			l.report(SeverityError, CheckRollout, "flag %q references rollout %q, which is not in the datafile", flag.Key, flag.RolloutID)
+			continue
+		}
+
+		feature, _ := l.projectConfig.GetFeatureByKey(flag.Key)
+		rollout := feature.Rollout
+		if len(rollout.Experiments) == 0 {
+			l.report(SeverityWarning, CheckRollout, "flag %q has an empty rollout %q", flag.Key, rollout.ID)
+			continue
+		}
+		running := false
+		for _, experiment := range rollout.Experiments {
+			running = running || experiment.IsRunning()
+		}
+		if !running {
+			l.report(SeverityWarning, CheckRollout, "flag %q has a disabled rollout %q, none of its rules are running", flag.Key, rollout.ID)
+		}
+	}
+}
+
+func (l *linter) checkSharedExperiments() {
+	flagKeys := map[string][]string{}
+	for _, flag := range l.sortedFlags() {
+		for _, experimentID := range flag.ExperimentIDs {
+			flagKeys[experimentID] = append(flagKeys[experimentID], flag.Key)
+		}
+	}
+
+	experimentIDs := make([]string, 0, len(flagKeys))
+	for experimentID := range flagKeys {
+		experimentIDs = append(experimentIDs, experimentID)
+	}
+	sort.Strings(experimentIDs)
+	for _, experimentID := range experimentIDs {
+		if keys := flagKeys[experimentID]; len(keys) > 1 {
+			l.report(SeverityError, CheckSharedExperiment, "experiment %q is shared by flags %s", experimentID, strings.Join(keys, ", "))
+		}
+	}
+}
+
+func (l *linter) features() []entities.Feature {
+	features := l.projectConfig.GetFeatureList()
+	sort.Slice(features, func(i, j int) bool { return features[i].Key < features[j].Key })
+	return features
+}
+
+func (l *linter) sortedFlags() []datafileEntities.FeatureFlag {
+	flags := append([]datafileEntities.FeatureFlag{}, l.datafile.FeatureFlags...)
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+	return flags
+}
+
+// walkTree calls visit for the item of every leaf of the tree
+func walkTree(node *entities.TreeNode, visit func(item interface{})) {
+	if node == nil {
+		return
+	}
+	if node.Operator == "" {
+		visit(node.Item)
+		return
+	}
+	for _, child := range node.Nodes {
+		walkTree(child, visit)
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
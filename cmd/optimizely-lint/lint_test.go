@@ -0,0 +1,192 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+type LintTestSuite struct {
+	suite.Suite
+	datafile *datafileEntities.Datafile
+}
+
+func (s *LintTestSuite) SetupTest() {
+	b := builder.New().Attribute("age")
+	b.Audience("adults", builder.Match("age", "ge", 18))
+	checkout := b.Flag("checkout").
+		Variable("limit", entities.Integer, 10).
+		Variable("settings", entities.JSON, `{"theme":"dark"}`)
+	checkout.Experiment("checkout_test").
+		Audiences("adults").
+		Variation("blue", true, nil).
+		Variation("green", true, nil)
+	checkout.EveryoneElse().Enabled(true)
+
+	var err error
+	s.datafile, err = b.Datafile()
+	s.Require().NoError(err)
+}
+
+func (s *LintTestSuite) lint() []Finding {
+	jsonDatafile, err := json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	findings, err := Lint(jsonDatafile)
+	s.Require().NoError(err)
+	return findings
+}
+
+func (s *LintTestSuite) TestCleanDatafile() {
+	s.Empty(s.lint())
+}
+
+func (s *LintTestSuite) TestInvalidDatafile() {
+	_, err := Lint([]byte(`{"version": "1"}`))
+	s.Error(err)
+}
+
+func (s *LintTestSuite) TestMissingAudience() {
+	s.datafile.Experiments[0].AudienceConditions = []interface{}{"or", s.datafile.TypedAudiences[0].ID, "missing"}
+	s.Equal([]Finding{{
+		Severity: SeverityError,
+		Check:    CheckMissingAudience,
+		Message:  `experiment "checkout_test" references audience "missing", which is not in the datafile`,
+	}}, s.lint())
+}
+
+func (s *LintTestSuite) TestUnknownMatcher() {
+	s.datafile.TypedAudiences[0].Conditions = []interface{}{"and", map[string]interface{}{
+		"type": "custom_attribute", "name": "age", "match": "between", "value": 18,
+	}}
+	findings := s.lint()
+	s.Len(findings, 1)
+	s.Equal(CheckUnknownMatcher, findings[0].Check)
+	s.Contains(findings[0].Message, `unknown matcher "between" for "age"`)
+}
+
+func (s *LintTestSuite) TestTrafficAllocation() {
+	experiment := &s.datafile.Experiments[0]
+	blue, green := experiment.Variations[0].ID, experiment.Variations[1].ID
+	experiment.TrafficAllocation = []datafileEntities.TrafficAllocation{
+		{EntityID: blue, EndOfRange: 5000},
+		{EntityID: "", EndOfRange: 6000},
+		{EntityID: green, EndOfRange: 4000},
+		{EntityID: "unknown", EndOfRange: 9000},
+	}
+	s.Equal([]Finding{
+		{Severity: SeverityWarning, Check: CheckTrafficAllocation, Message: `experiment "checkout_test" leaves range [5000, 6000) unallocated`},
+		{Severity: SeverityError, Check: CheckTrafficAllocation, Message: `experiment "checkout_test" allocates range [6000, 4000) to variation "` + green + `", which overlaps the previous ranges`},
+		{Severity: SeverityError, Check: CheckTrafficAllocation, Message: `experiment "checkout_test" allocates range [6000, 9000) to variation "unknown", which is not in the datafile`},
+		{Severity: SeverityWarning, Check: CheckTrafficAllocation, Message: `experiment "checkout_test" leaves range [9000, 10000) unallocated`},
+	}, s.lint())
+
+	experiment.TrafficAllocation = []datafileEntities.TrafficAllocation{{EntityID: blue, EndOfRange: 12000}}
+	s.Equal([]Finding{
+		{Severity: SeverityError, Check: CheckTrafficAllocation, Message: `experiment "checkout_test" allocates traffic up to 12000, past the end of the bucketing range 10000`},
+	}, s.lint())
+}
+
+func (s *LintTestSuite) TestVariableDefaults() {
+	variables := s.datafile.FeatureFlags[0].Variables
+	variables[0].DefaultValue = "ten"
+	variables[1].DefaultValue = `{"theme":`
+	findings := s.lint()
+	s.Len(findings, 2)
+	s.Equal(CheckVariableDefault, findings[0].Check)
+	s.Contains(findings[0].Message, `flag "checkout" variable "limit": default value "ten" is not a valid integer`)
+	s.Contains(findings[1].Message, `flag "checkout" variable "settings": default value "{\"theme\":" is not a valid json`)
+}
+
+func (s *LintTestSuite) TestRollouts() {
+	rollout := &s.datafile.Rollouts[0]
+	rollout.Experiments[0].Status = string(entities.ExperimentStatusPaused)
+	s.Equal([]Finding{{
+		Severity: SeverityWarning,
+		Check:    CheckRollout,
+		Message:  `flag "checkout" has a disabled rollout "` + rollout.ID + `", none of its rules are running`,
+	}}, s.lint())
+
+	rollout.Experiments = []datafileEntities.Experiment{}
+	s.Equal(`flag "checkout" has an empty rollout "`+rollout.ID+`"`, s.lint()[0].Message)
+
+	s.datafile.FeatureFlags[0].RolloutID = "missing"
+	s.Equal(`flag "checkout" references rollout "missing", which is not in the datafile`, s.lint()[0].Message)
+}
+
+func (s *LintTestSuite) TestSharedExperiments() {
+	flag := s.datafile.FeatureFlags[0]
+	flag.ID, flag.Key, flag.Variables = "20000", "checkout_copy", nil
+	s.datafile.FeatureFlags = append(s.datafile.FeatureFlags, flag)
+	s.Equal([]Finding{{
+		Severity: SeverityError,
+		Check:    CheckSharedExperiment,
+		Message:  `experiment "` + flag.ExperimentIDs[0] + `" is shared by flags checkout, checkout_copy`,
+	}}, s.lint())
+}
+
+func (s *LintTestSuite) TestRun() {
+	dir := s.T().TempDir()
+	clean := filepath.Join(dir, "clean.json")
+	jsonDatafile, err := json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	s.Require().NoError(os.WriteFile(clean, jsonDatafile, 0600))
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitOK, run([]string{clean}, nil, &stdout, &stderr))
+	s.Empty(stdout.String())
+
+	// warnings only fail the lint in strict mode
+	s.datafile.Rollouts[0].Experiments[0].Status = string(entities.ExperimentStatusPaused)
+	jsonDatafile, err = json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	s.Equal(exitOK, run(nil, bytes.NewReader(jsonDatafile), &stdout, &stderr))
+	s.True(strings.HasPrefix(stdout.String(), "<stdin>: warning [rollout] "))
+	s.Equal(exitFindings, run([]string{"-strict"}, bytes.NewReader(jsonDatafile), &stdout, &stderr))
+
+	s.datafile.FeatureFlags[0].Variables[0].DefaultValue = "ten"
+	jsonDatafile, err = json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	stdout.Reset()
+	s.Equal(exitFindings, run([]string{"-format", "json", "-", clean}, bytes.NewReader(jsonDatafile), &stdout, &stderr))
+	var reports []fileReport
+	s.Require().NoError(json.Unmarshal(stdout.Bytes(), &reports))
+	s.Len(reports, 2)
+	s.Equal("<stdin>", reports[0].File)
+	s.Len(reports[0].Findings, 2)
+	s.Equal(clean, reports[1].File)
+	s.Empty(reports[1].Findings)
+
+	stderr.Reset()
+	s.Equal(exitFailure, run([]string{filepath.Join(dir, "missing.json"), clean}, nil, &stdout, &stderr))
+	s.Contains(stderr.String(), "missing.json")
+	s.Equal(exitFailure, run([]string{"-format", "yaml", clean}, nil, &stdout, &stderr))
+}
+
+func TestLintTestSuite(t *testing.T) {
+	suite.Run(t, new(LintTestSuite))
+}
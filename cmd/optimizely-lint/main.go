@@ -0,0 +1,140 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Command optimizely-lint checks datafiles for configuration mistakes, e.g. as part of a config-as-code review.
+//
+// Usage:
+//
+//	optimizely-lint [-format text|json] [-strict] [datafile ...]
+//
+// The datafile is read from stdin when no file is given. The exit code is 0 when no finding fails the lint,
+// 1 when an error finding (or, with -strict, any finding) is reported and 2 when a datafile can not be read or parsed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// Exit codes of the command
+const (
+	exitOK       = 0
+	exitFindings = 1
+	exitFailure  = 2
+)
+
+// fileReport holds the findings of a datafile
+type fileReport struct {
+	File     string    `json:"file"`
+	Findings []Finding `json:"findings"`
+	Error    string    `json:"error,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("optimizely-lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format, text or json")
+	strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "unsupported format %q\n", *format)
+		return exitFailure
+	}
+	// the linter reports the problems itself, the SDK logs about them are noise here
+	logging.SetLogger(logging.NewFilteredLevelLogConsumer(logging.LogLevelError, io.Discard))
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	exitCode := exitOK
+	reports := make([]fileReport, 0, len(files))
+	for _, file := range files {
+		report := lintFile(file, stdin)
+		reports = append(reports, report)
+		switch {
+		case report.Error != "":
+			exitCode = exitFailure
+		case exitCode == exitOK && fails(report.Findings, *strict):
+			exitCode = exitFindings
+		}
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		return exitCode
+	}
+
+	for _, report := range reports {
+		if report.Error != "" {
+			fmt.Fprintf(stderr, "%s: %s\n", report.File, report.Error)
+		}
+		for _, finding := range report.Findings {
+			fmt.Fprintf(stdout, "%s: %s\n", report.File, finding)
+		}
+	}
+	return exitCode
+}
+
+func lintFile(file string, stdin io.Reader) fileReport {
+	report := fileReport{File: file, Findings: []Finding{}}
+	var jsonDatafile []byte
+	var err error
+	if file == "-" {
+		report.File = "<stdin>"
+		jsonDatafile, err = io.ReadAll(stdin)
+	} else {
+		jsonDatafile, err = os.ReadFile(file)
+	}
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	findings, err := Lint(jsonDatafile)
+	if err != nil {
+		report.Error = fmt.Sprintf("invalid datafile: %s", err)
+		return report
+	}
+	report.Findings = findings
+	return report
+}
+
+func fails(findings []Finding, strict bool) bool {
+	for _, finding := range findings {
+		if strict || finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
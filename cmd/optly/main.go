@@ -0,0 +1,273 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Command optly answers "what would this user get?" offline: it decides the flags of a datafile for a user and prints
+// the decisions with their variables and reasons. No events are sent. The exit code is 1 when a flag could not be
+// decided, e.g. because it is not in the datafile, and 2 when the arguments or the datafile are invalid.
+//
+// Usage:
+//
+//	optly -datafile datafile.json -user user1 [-attributes '{"country":"US"}'] [-segments a,b] [-flags f1,f2] [-format table|json]
+//
+// The datafile is either a path, a file:// URL or an http(s):// URL, e.g. of a datafile served locally. All flags are
+// decided when -flags is not given.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// Exit codes of the command
+const (
+	exitOK       = 0
+	exitDecision = 1
+	exitFailure  = 2
+)
+
+// fetchTimeout bounds the time spent downloading a datafile
+const fetchTimeout = 10 * time.Second
+
+// decideOptions make the decisions explainable without tracking them
+var decideOptions = []decide.OptimizelyDecideOptions{decide.IncludeReasons, decide.DisableDecisionEvent}
+
+// flagDecision is a decision as printed by the command
+type flagDecision struct {
+	FlagKey           string                    `json:"flagKey"`
+	Enabled           bool                      `json:"enabled"`
+	VariationKey      string                    `json:"variationKey"`
+	RuleKey           string                    `json:"ruleKey"`
+	Variables         map[string]interface{}    `json:"variables"`
+	Reasons           []string                  `json:"reasons"`
+	StructuredReasons []decide.StructuredReason `json:"structuredReasons,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("optly", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	datafileLocation := flags.String("datafile", "", "path or URL of the datafile")
+	userID := flags.String("user", "", "ID of the user")
+	attributesJSON := flags.String("attributes", "", "attributes of the user as a JSON object")
+	segments := flags.String("segments", "", "comma-separated qualified segments of the user")
+	flagKeys := flags.String("flags", "", "comma-separated keys of the flags to decide, all flags by default")
+	format := flags.String("format", "table", "output format, table or json")
+	if err := flags.Parse(args); err != nil {
+		return exitFailure
+	}
+
+	fail := func(err error) int {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	switch {
+	case *datafileLocation == "":
+		return fail(errors.New("-datafile is required"))
+	case *userID == "":
+		return fail(errors.New("-user is required"))
+	case *format != "table" && *format != "json":
+		return fail(fmt.Errorf("unsupported format %q", *format))
+	}
+
+	var attributes map[string]interface{}
+	if *attributesJSON != "" {
+		if err := json.Unmarshal([]byte(*attributesJSON), &attributes); err != nil {
+			return fail(fmt.Errorf("invalid attributes: %w", err))
+		}
+	}
+
+	logging.SetLogger(logging.NewFilteredLevelLogConsumer(logging.LogLevelError, stderr))
+	datafile, err := loadDatafile(*datafileLocation)
+	if err != nil {
+		return fail(err)
+	}
+	configManager, err := config.NewStaticProjectConfigManagerFromPayload(datafile, logging.GetLogger("", "StaticProjectConfigManager"))
+	if err != nil {
+		return fail(fmt.Errorf("invalid datafile: %w", err))
+	}
+	optimizelyClient, err := (&client.OptimizelyFactory{}).Client(client.WithConfigManager(configManager), client.WithOdpDisabled(true))
+	if err != nil {
+		return fail(err)
+	}
+	defer optimizelyClient.Close()
+
+	userContext := optimizelyClient.CreateUserContext(*userID, attributes)
+	if *segments != "" {
+		userContext.SetQualifiedSegments(splitList(*segments))
+	}
+
+	var decisions []client.OptimizelyDecision
+	if keys := splitList(*flagKeys); len(keys) > 0 {
+		for _, key := range keys {
+			decisions = append(decisions, userContext.Decide(key, decideOptions))
+		}
+	} else {
+		for _, decision := range userContext.DecideAll(decideOptions) {
+			decisions = append(decisions, decision)
+		}
+		sort.Slice(decisions, func(i, j int) bool { return decisions[i].FlagKey < decisions[j].FlagKey })
+	}
+
+	exitCode := exitOK
+	printed := make([]flagDecision, 0, len(decisions))
+	for _, decision := range decisions {
+		printed = append(printed, toFlagDecision(decision))
+		if hasError(decision) {
+			exitCode = exitDecision
+		}
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(printed); err != nil {
+			return fail(err)
+		}
+		return exitCode
+	}
+	printTable(stdout, printed)
+	return exitCode
+}
+
+// loadDatafile reads the datafile from a path, a file:// URL or an http(s):// URL
+func loadDatafile(location string) ([]byte, error) {
+	parsed, err := url.Parse(location)
+	if err != nil || parsed.Scheme == "" {
+		return os.ReadFile(location)
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return os.ReadFile(parsed.Path)
+	case "http", "https":
+		httpClient := http.Client{Timeout: fetchTimeout}
+		response, err := httpClient.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unable to fetch datafile from %s: %s", location, response.Status)
+		}
+		return io.ReadAll(response.Body)
+	default:
+		// e.g. a Windows path with a drive letter
+		return os.ReadFile(location)
+	}
+}
+
+// hasError returns true if the decision could not be made properly, e.g. because the flag is not in the datafile
+func hasError(decision client.OptimizelyDecision) bool {
+	for _, reason := range decision.StructuredReasons {
+		if reason.Code == reasons.Error {
+			return true
+		}
+	}
+	return false
+}
+
+func toFlagDecision(decision client.OptimizelyDecision) flagDecision {
+	variables := map[string]interface{}{}
+	if decision.Variables != nil {
+		variables = decision.Variables.ToMap()
+	}
+	return flagDecision{
+		FlagKey:           decision.FlagKey,
+		Enabled:           decision.Enabled,
+		VariationKey:      decision.VariationKey,
+		RuleKey:           decision.RuleKey,
+		Variables:         variables,
+		Reasons:           decision.Reasons,
+		StructuredReasons: decision.StructuredReasons,
+	}
+}
+
+func printTable(out io.Writer, decisions []flagDecision) {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FLAG\tENABLED\tVARIATION\tRULE\tVARIABLES")
+	for _, decision := range decisions {
+		fmt.Fprintf(table, "%s\t%t\t%s\t%s\t%s\n", decision.FlagKey, decision.Enabled, orDash(decision.VariationKey), orDash(decision.RuleKey),
+			formatVariables(decision.Variables))
+	}
+	_ = table.Flush()
+
+	for _, decision := range decisions {
+		if len(decision.Reasons) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", decision.FlagKey)
+		for _, reason := range decision.Reasons {
+			fmt.Fprintf(out, "  - %s\n", reason)
+		}
+	}
+}
+
+func formatVariables(variables map[string]interface{}) string {
+	if len(variables) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := json.Marshal(variables[key])
+		if err != nil {
+			value = []byte(fmt.Sprint(variables[key]))
+		}
+		pairs = append(pairs, key+"="+string(value))
+	}
+	return strings.Join(pairs, " ")
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,128 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+type OptlyTestSuite struct {
+	suite.Suite
+	datafile     []byte
+	datafilePath string
+}
+
+func (s *OptlyTestSuite) SetupTest() {
+	b := builder.New().Attribute("country")
+	b.Audience("us", builder.Exact("country", "US"))
+	b.Audience("beta", builder.Qualified("beta"))
+	checkout := b.Flag("checkout").Variable("limit", entities.Integer, 10)
+	checkout.Rule("us_only").Audiences("us").Enabled(true).Variables(builder.Vars{"limit": 20})
+	checkout.Rule("beta_only").Audiences("beta").Enabled(true).Variables(builder.Vars{"limit": 30})
+	b.Flag("search").EveryoneElse().Enabled(true)
+
+	var err error
+	s.datafile, err = b.JSON()
+	s.Require().NoError(err)
+	s.datafilePath = filepath.Join(s.T().TempDir(), "datafile.json")
+	s.Require().NoError(os.WriteFile(s.datafilePath, s.datafile, 0600))
+}
+
+func (s *OptlyTestSuite) decide(args ...string) ([]flagDecision, int) {
+	var stdout, stderr bytes.Buffer
+	exitCode := run(append(args, "-format", "json"), &stdout, &stderr)
+	var decisions []flagDecision
+	if exitCode != exitFailure {
+		s.Require().NoError(json.Unmarshal(stdout.Bytes(), &decisions), stderr.String())
+	}
+	return decisions, exitCode
+}
+
+func (s *OptlyTestSuite) TestDecideAll() {
+	decisions, exitCode := s.decide("-datafile", s.datafilePath, "-user", "user1", "-attributes", `{"country":"US"}`)
+	s.Equal(exitOK, exitCode)
+	s.Len(decisions, 2)
+
+	checkout := decisions[0]
+	s.Equal("checkout", checkout.FlagKey)
+	s.True(checkout.Enabled)
+	s.Equal("us_only", checkout.RuleKey)
+	s.Equal(map[string]interface{}{"limit": float64(20)}, checkout.Variables)
+	s.NotEmpty(checkout.Reasons)
+	s.NotEmpty(checkout.StructuredReasons)
+
+	s.Equal("search", decisions[1].FlagKey)
+	s.True(decisions[1].Enabled)
+}
+
+func (s *OptlyTestSuite) TestDecideWithSegmentsFromURL() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(s.datafile)
+	}))
+	defer server.Close()
+
+	decisions, exitCode := s.decide("-datafile", server.URL, "-user", "user1", "-segments", "alpha, beta", "-flags", "checkout")
+	s.Equal(exitOK, exitCode)
+	s.Len(decisions, 1)
+	s.Equal("beta_only", decisions[0].RuleKey)
+	s.Equal(map[string]interface{}{"limit": float64(30)}, decisions[0].Variables)
+
+	decisions, exitCode = s.decide("-datafile", "file://"+s.datafilePath, "-user", "user1", "-flags", "checkout")
+	s.Equal(exitOK, exitCode)
+	s.False(decisions[0].Enabled)
+}
+
+func (s *OptlyTestSuite) TestTable() {
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitOK, run([]string{"-datafile", s.datafilePath, "-user", "user1", "-flags", "search"}, &stdout, &stderr))
+	s.Contains(stdout.String(), "FLAG    ENABLED  VARIATION")
+	s.Contains(stdout.String(), "search  true")
+	s.Contains(stdout.String(), "\nsearch:\n  - ")
+}
+
+func (s *OptlyTestSuite) TestErrors() {
+	decisions, exitCode := s.decide("-datafile", s.datafilePath, "-user", "user1", "-flags", "missing")
+	s.Equal(exitDecision, exitCode)
+	s.Equal("missing", decisions[0].FlagKey)
+
+	var stdout, stderr bytes.Buffer
+	s.Equal(exitFailure, run([]string{"-user", "user1"}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{"-datafile", s.datafilePath}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{"-datafile", s.datafilePath, "-user", "user1", "-attributes", "[1]"}, &stdout, &stderr))
+	s.Equal(exitFailure, run([]string{"-datafile", filepath.Join(s.T().TempDir(), "missing.json"), "-user", "user1"}, &stdout, &stderr))
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	stderr.Reset()
+	s.Equal(exitFailure, run([]string{"-datafile", server.URL, "-user", "user1"}, &stdout, &stderr))
+	s.Contains(stderr.String(), "404 Not Found")
+}
+
+func TestOptlyTestSuite(t *testing.T) {
+	suite.Run(t, new(OptlyTestSuite))
+}
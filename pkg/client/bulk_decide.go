@@ -0,0 +1,224 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+)
+
+// DefaultBulkEventBatchSize is the number of impressions a DecideForUsers worker collects before queueing them
+const DefaultBulkEventBatchSize = 100
+
+// UserInput describes one of the users passed to DecideForUsers
+type UserInput struct {
+	UserID            string
+	Attributes        map[string]interface{}
+	QualifiedSegments []string
+}
+
+// UserDecisions holds the decisions made by DecideForUsers for the user at Index of the input
+type UserDecisions struct {
+	Index     int
+	UserID    string
+	Decisions map[string]OptimizelyDecision
+	Err       error
+}
+
+// BulkDecideOptions configures a DecideForUsers call
+type BulkDecideOptions struct {
+	DecideOptions []decide.OptimizelyDecideOptions
+	// Workers is the number of users decided in parallel, defaults to GOMAXPROCS
+	Workers int
+	// EventBatchSize is the number of impressions each worker collects before queueing them, defaults to DefaultBulkEventBatchSize
+	EventBatchSize int
+}
+
+// decideFlag holds everything about a flag which does not depend on the user being decided
+type decideFlag struct {
+	feature       entities.Feature
+	variableTypes map[string]entities.VariableType
+}
+
+func newDecideFlag(projectConfig config.ProjectConfig, key string) (*decideFlag, error) {
+	feature, err := projectConfig.GetFeatureByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	variableTypes := make(map[string]entities.VariableType, len(feature.VariableMap))
+	for variableKey, variable := range feature.VariableMap {
+		variableTypes[variableKey] = variable.Type
+	}
+	return &decideFlag{feature: feature, variableTypes: variableTypes}, nil
+}
+
+// DecideForUsers decides the flags for every user against a single project config revision.
+// See DecideForUsersWithContext.
+func (o *OptimizelyClient) DecideForUsers(users []UserInput, keys []string, opts BulkDecideOptions) (<-chan UserDecisions, error) {
+	return o.DecideForUsersWithContext(o.defaultContext(), users, keys, opts)
+}
+
+// DecideForUsersWithContext decides the flags for every user on a pool of workers and streams the results,
+// which arrive in no particular order. All flags are decided when keys is empty. The config revision is
+// pinned for the whole call, and impressions are queued in batches of opts.EventBatchSize.
+// The returned channel is closed once every user is decided or ctx is done, and it must be drained.
+func (o *OptimizelyClient) DecideForUsersWithContext(ctx context.Context, users []UserInput, keys []string, opts BulkDecideOptions) (<-chan UserDecisions, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	projectConfig, err := o.getProjectConfig()
+	if err != nil {
+		o.logger.Error("Optimizely instance is not valid, failing DecideForUsers call.", err)
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		for _, flag := range projectConfig.GetFeatureList() {
+			keys = append(keys, flag.Key)
+		}
+	}
+	// flags which are not in the datafile are left nil and get an error decision
+	flags := make([]*decideFlag, len(keys))
+	for i, key := range keys {
+		flags[i], _ = newDecideFlag(projectConfig, key)
+	}
+
+	allOptions := o.getAllOptions(convertDecideOptions(opts.DecideOptions))
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(users) {
+		workers = len(users)
+	}
+	eventBatchSize := opts.EventBatchSize
+	if eventBatchSize <= 0 {
+		eventBatchSize = DefaultBulkEventBatchSize
+	}
+
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameDecideForUsers)
+	indexes := make(chan int)
+	results := make(chan UserDecisions, workers)
+
+	go func() {
+		defer close(indexes)
+		for i := range users {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			var userEvents []event.UserEvent
+			defer func() { o.processEvents(userEvents) }()
+			collect := func(userEvent event.UserEvent) {
+				userEvents = append(userEvents, userEvent)
+			}
+
+			for i := range indexes {
+				result := o.decideForUser(ctx, projectConfig, keys, flags, users[i], &allOptions, collect)
+				result.Index = i
+				if len(userEvents) >= eventBatchSize {
+					o.processEvents(userEvents)
+					userEvents = nil
+				}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		span.End()
+		close(results)
+	}()
+
+	return results, nil
+}
+
+func (o *OptimizelyClient) decideForUser(ctx context.Context, projectConfig config.ProjectConfig, keys []string, flags []*decideFlag,
+	user UserInput, allOptions *decide.Options, processEvent func(userEvent event.UserEvent)) (result UserDecisions) {
+	result = UserDecisions{UserID: user.UserID, Decisions: map[string]OptimizelyDecision{}}
+	defer func() {
+		if r := recover(); r != nil {
+			switch t := r.(type) {
+			case error:
+				result.Err = t
+			case string:
+				result.Err = errors.New(t)
+			default:
+				result.Err = errors.New("unexpected error")
+			}
+			errorMessage := fmt.Sprintf("DecideForUsers call for user %q, optimizely SDK is panicking with the error:", user.UserID)
+			o.logger.Error(errorMessage, result.Err)
+			o.logger.Debug(string(debug.Stack()))
+		}
+	}()
+
+	userContext := newOptimizelyUserContext(o, user.UserID, user.Attributes, nil, user.QualifiedSegments)
+	userProfileTracker := decision.NewUserProfileTracker()
+	defer o.saveUserProfile(ctx, userProfileTracker, user.UserID)
+	for i, key := range keys {
+		var optimizelyDecision OptimizelyDecision
+		if flags[i] == nil {
+			optimizelyDecision = NewErrorDecision(key, userContext, decide.GetDecideError(decide.FlagKeyInvalid, key))
+			optimizelyDecision.Revision = projectConfig.GetRevision()
+		} else {
+			optimizelyDecision = o.decideFlag(ctx, projectConfig, flags[i], userContext, allOptions, userProfileTracker, processEvent)
+		}
+		if !allOptions.EnabledFlagsOnly || optimizelyDecision.Enabled {
+			result.Decisions[key] = optimizelyDecision
+		}
+	}
+	return result
+}
+
+// processEvents queues the events in one call when the event processor supports it
+func (o *OptimizelyClient) processEvents(userEvents []event.UserEvent) {
+	if len(userEvents) == 0 {
+		return
+	}
+	if bulkProcessor, ok := o.EventProcessor.(event.BulkProcessor); ok {
+		bulkProcessor.ProcessEvents(userEvents)
+		return
+	}
+	for _, userEvent := range userEvents {
+		o.EventProcessor.ProcessEvent(userEvent)
+	}
+}
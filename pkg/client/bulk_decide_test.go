@@ -0,0 +1,170 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+)
+
+type MockBulkProcessor struct {
+	event.Processor
+	mutex   sync.Mutex
+	Events  []event.UserEvent
+	Batches []int
+}
+
+func (m *MockBulkProcessor) ProcessEvents(events []event.UserEvent) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Events = append(m.Events, events...)
+	m.Batches = append(m.Batches, len(events))
+	return len(events)
+}
+
+type BulkDecideTestSuite struct {
+	suite.Suite
+	*OptimizelyClient
+	eventProcessor *MockBulkProcessor
+	users          []UserInput
+}
+
+func (s *BulkDecideTestSuite) SetupTest() {
+	doOnce.Do(func() {
+		absPath, _ := filepath.Abs("../../test-data/decide-test-datafile.json")
+		datafile, _ = os.ReadFile(absPath)
+	})
+	s.eventProcessor = new(MockBulkProcessor)
+	factory := OptimizelyFactory{Datafile: datafile}
+	s.OptimizelyClient, _ = factory.Client(WithEventProcessor(s.eventProcessor))
+	s.users = []UserInput{{UserID: "user1"}, {UserID: "user2"}, {UserID: "user3"}, {UserID: "user4"}, {UserID: "user5"}}
+}
+
+func (s *BulkDecideTestSuite) collect(results <-chan UserDecisions) []UserDecisions {
+	collected := []UserDecisions{}
+	for result := range results {
+		collected = append(collected, result)
+	}
+	sort.Slice(collected, func(i, j int) bool { return collected[i].Index < collected[j].Index })
+	return collected
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsers() {
+	results, err := s.DecideForUsers(s.users, []string{"feature_2", "invalid_flag"}, BulkDecideOptions{Workers: 2})
+	s.NoError(err)
+
+	collected := s.collect(results)
+	s.Len(collected, len(s.users))
+	for i, result := range collected {
+		s.Equal(i, result.Index)
+		s.Equal(s.users[i].UserID, result.UserID)
+		s.NoError(result.Err)
+		s.Len(result.Decisions, 2)
+
+		decision := result.Decisions["feature_2"]
+		s.Equal("variation_with_traffic", decision.VariationKey)
+		s.True(decision.Enabled)
+		s.Equal(s.users[i].UserID, decision.UserContext.GetUserID())
+		value, err := GetVariable[int](decision, "i_42")
+		s.NoError(err)
+		s.Equal(42, value)
+
+		s.Equal([]string{decide.GetDecideMessage(decide.FlagKeyInvalid, "invalid_flag")}, result.Decisions["invalid_flag"].Reasons)
+	}
+	s.Len(s.eventProcessor.Events, len(s.users))
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersMatchesDecide() {
+	results, err := s.DecideForUsers(s.users[:1], nil, BulkDecideOptions{})
+	s.NoError(err)
+	collected := s.collect(results)
+	s.Len(collected, 1)
+
+	user := s.CreateUserContext(s.users[0].UserID, nil)
+	expected := user.DecideAll([]decide.OptimizelyDecideOptions{decide.DisableDecisionEvent})
+	s.Len(collected[0].Decisions, len(expected))
+	for key, decision := range expected {
+		s.Equal(decision.VariationKey, collected[0].Decisions[key].VariationKey)
+		s.Equal(decision.Enabled, collected[0].Decisions[key].Enabled)
+		s.Equal(decision.Variables.ToMap(), collected[0].Decisions[key].Variables.ToMap())
+	}
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersBatchesImpressions() {
+	results, err := s.DecideForUsers(s.users, []string{"feature_2"}, BulkDecideOptions{Workers: 1, EventBatchSize: 2})
+	s.NoError(err)
+	s.collect(results)
+
+	s.Equal([]int{2, 2, 1}, s.eventProcessor.Batches)
+	s.Len(s.eventProcessor.Events, len(s.users))
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersWithoutBulkProcessor() {
+	eventProcessor := new(MockProcessor)
+	eventProcessor.On("ProcessEvent", mock.AnythingOfType("event.UserEvent")).Return(true)
+	s.EventProcessor = eventProcessor
+
+	results, err := s.DecideForUsers(s.users, []string{"feature_2"}, BulkDecideOptions{Workers: 1, EventBatchSize: 2})
+	s.NoError(err)
+	s.Len(s.collect(results), len(s.users))
+	s.Len(eventProcessor.Events, len(s.users))
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersOptions() {
+	options := BulkDecideOptions{DecideOptions: []decide.OptimizelyDecideOptions{decide.DisableDecisionEvent, decide.EnabledFlagsOnly}}
+	results, err := s.DecideForUsers(s.users, []string{"feature_2", "invalid_flag"}, options)
+	s.NoError(err)
+
+	for _, result := range s.collect(results) {
+		s.Len(result.Decisions, 1)
+		s.Contains(result.Decisions, "feature_2")
+	}
+	s.Len(s.eventProcessor.Events, 0)
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersWithContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := s.DecideForUsersWithContext(ctx, s.users, []string{"feature_2"}, BulkDecideOptions{})
+	s.NoError(err)
+	for _, result := range s.collect(results) {
+		s.Equal([]string{decide.GetDecideMessage(decide.ContextDone, context.Canceled)}, result.Decisions["feature_2"].Reasons)
+	}
+	s.Len(s.eventProcessor.Events, 0)
+}
+
+func (s *BulkDecideTestSuite) TestDecideForUsersSDKNotReady() {
+	client := OptimizelyClient{logger: s.logger, tracer: s.tracer}
+	results, err := client.DecideForUsers(s.users, []string{"feature_2"}, BulkDecideOptions{})
+	s.Error(err)
+	s.Nil(results)
+}
+
+func TestBulkDecideTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkDecideTestSuite))
+}
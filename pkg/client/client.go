@@ -37,6 +37,7 @@ import (
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
 	"github.com/optimizely/go-sdk/v2/pkg/odp"
+	pkgOdpEvent "github.com/optimizely/go-sdk/v2/pkg/odp/event"
 	pkgOdpSegment "github.com/optimizely/go-sdk/v2/pkg/odp/segment"
 	pkgOdpUtils "github.com/optimizely/go-sdk/v2/pkg/odp/utils"
 	"github.com/optimizely/go-sdk/v2/pkg/optimizelyjson"
@@ -53,6 +54,8 @@ const (
 	SpanNameDecideForKeys = "decideForKeys"
 	// SpanNameDecideAll is the name of the span used by the Optimizely SDK for tracing decideAll call
 	SpanNameDecideAll = "decideAll"
+	// SpanNameDecideForUsers is the name of the span used by the Optimizely SDK for tracing DecideForUsers call
+	SpanNameDecideForUsers = "DecideForUsers"
 	// SpanNameActivate is the name of the span used by the Optimizely SDK for tracing Activate call
 	SpanNameActivate = "Activate"
 	// SpanNameFetchQualifiedSegments is the name of the span used by the Optimizely SDK for tracing fetchQualifiedSegments call
@@ -124,13 +127,77 @@ func (o *OptimizelyClient) CreateUserContext(userID string, attributes map[strin
 	return newOptimizelyUserContext(o, userID, attributes, nil, nil)
 }
 
-// WithTraceContext sets the context for the OptimizelyClient which can be used to propagate trace information
+// IsReady returns true once the client has a project config and is able to make decisions
+func (o *OptimizelyClient) IsReady() bool {
+	_, err := o.getReadyProjectConfig()
+	return err == nil
+}
+
+// WaitUntilReady blocks until the client has a project config, or returns the error of ctx once it is done.
+// Config managers which do not implement config.ReadyNotifier are only checked once.
+func (o *OptimizelyClient) WaitUntilReady(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, err := o.getReadyProjectConfig()
+	if err == nil {
+		return nil
+	}
+	readyNotifier, ok := o.ConfigManager.(config.ReadyNotifier)
+	if !ok {
+		return err
+	}
+
+	select {
+	case <-readyNotifier.Ready():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnReady registers a handler which is called once, when the client gets its first project config.
+// The handler is called right away if the client is already ready.
+func (o *OptimizelyClient) OnReady(callback func(notification.ReadyNotification)) (int, error) {
+	if readyNotifier, ok := o.ConfigManager.(config.ReadyNotifier); ok {
+		return readyNotifier.OnReady(callback)
+	}
+	projectConfig, err := o.getReadyProjectConfig()
+	if err != nil {
+		return 0, fmt.Errorf("config manager does not support ready notifications: %w", err)
+	}
+	callback(notification.ReadyNotification{Type: notification.Ready, Revision: projectConfig.GetRevision()})
+	return 0, nil
+}
+
+// RemoveOnReady removes handler for Ready notification with given id
+func (o *OptimizelyClient) RemoveOnReady(id int) error {
+	if readyNotifier, ok := o.ConfigManager.(config.ReadyNotifier); ok {
+		return readyNotifier.RemoveOnReady(id)
+	}
+	return nil
+}
+
+// defaultContext returns the context used by the calls that do not take a context. It carries the values of the
+// client context, such as the trace context, but is not canceled with it, so those calls keep working after the
+// context given to the factory is done.
+func (o *OptimizelyClient) defaultContext() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return context.WithoutCancel(o.ctx)
+}
+
+// WithTraceContext sets the context for the OptimizelyClient which can be used to propagate trace information.
+// To propagate a per-call context, use the WithContext variants of the OptimizelyUserContext APIs instead.
 func (o *OptimizelyClient) WithTraceContext(ctx context.Context) *OptimizelyClient {
 	o.ctx = ctx
 	return o
 }
 
-func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string, options *decide.Options) OptimizelyDecision {
+// decide makes the decision for a single flag, userProfileTracker is nil unless the call decides several flags
+func (o *OptimizelyClient) decide(ctx context.Context, userContext OptimizelyUserContext, key string, options *decide.Options,
+	userProfileTracker *decision.UserProfileTracker) OptimizelyDecision {
 	var err error
 	defer func() {
 		if r := recover(); r != nil {
@@ -148,23 +215,43 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 		}
 	}()
 
-	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameDecide)
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameDecide)
 	defer span.End()
 
-	decisionContext := decision.FeatureDecisionContext{
-		ForcedDecisionService: userContext.forcedDecisionService,
+	if err = contextError(ctx); err != nil {
+		return NewErrorDecision(key, userContext, decide.GetDecideError(decide.ContextDone, err))
 	}
-	projectConfig, err := o.getProjectConfig()
+
+	projectConfig, err := o.getPinnedProjectConfig(userContext.configPin)
 	if err != nil {
 		return NewErrorDecision(key, userContext, decide.GetDecideError(decide.SDKNotReady))
 	}
-	decisionContext.ProjectConfig = projectConfig
 
-	feature, err := projectConfig.GetFeatureByKey(key)
+	flag, err := newDecideFlag(projectConfig, key)
 	if err != nil {
-		return NewErrorDecision(key, userContext, decide.GetDecideError(decide.FlagKeyInvalid, key))
+		errorDecision := NewErrorDecision(key, userContext, decide.GetDecideError(decide.FlagKeyInvalid, key))
+		errorDecision.Revision = projectConfig.GetRevision()
+		return errorDecision
+	}
+
+	allOptions := o.getAllOptions(options)
+	return o.decideFlag(ctx, projectConfig, flag, userContext, &allOptions, userProfileTracker, func(userEvent event.UserEvent) {
+		o.EventProcessor.ProcessEvent(userEvent)
+	})
+}
+
+// decideFlag makes the decision for an already resolved flag, impressions are handed to processEvent
+func (o *OptimizelyClient) decideFlag(ctx context.Context, projectConfig config.ProjectConfig, flag *decideFlag, userContext OptimizelyUserContext,
+	allOptions *decide.Options, userProfileTracker *decision.UserProfileTracker, processEvent func(userEvent event.UserEvent)) OptimizelyDecision {
+	var err error
+	key := flag.feature.Key
+	decisionContext := decision.FeatureDecisionContext{
+		Context:               ctx,
+		ForcedDecisionService: userContext.forcedDecisionService,
+		ProjectConfig:         projectConfig,
+		Feature:               &flag.feature,
+		UserProfileTracker:    userProfileTracker,
 	}
-	decisionContext.Feature = &feature
 
 	usrContext := entities.UserContext{
 		ID:                userContext.GetUserID(),
@@ -173,8 +260,7 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 	}
 	var variationKey string
 	var eventSent, flagEnabled bool
-	allOptions := o.getAllOptions(options)
-	decisionReasons := decide.NewDecisionReasons(&allOptions)
+	decisionReasons := decide.NewDecisionReasons(allOptions)
 	decisionContext.Variable = entities.Variable{}
 	var featureDecision decision.FeatureDecision
 	var reasons decide.DecisionReasons
@@ -182,7 +268,7 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 	// To avoid cyclo-complexity warning
 	findRegularDecision := func() {
 		// regular decision
-		featureDecision, reasons, err = o.DecisionService.GetFeatureDecision(decisionContext, usrContext, &allOptions)
+		featureDecision, reasons, err = o.DecisionService.GetFeatureDecision(decisionContext, usrContext, allOptions)
 		decisionReasons.Append(reasons)
 	}
 
@@ -190,7 +276,7 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 	// Passing empty rule-key because checking mapping with flagKey only
 	if userContext.forcedDecisionService != nil {
 		var variation *entities.Variation
-		variation, reasons, err = userContext.forcedDecisionService.FindValidatedForcedDecision(projectConfig, decision.OptimizelyDecisionContext{FlagKey: key, RuleKey: ""}, &allOptions)
+		variation, reasons, err = userContext.forcedDecisionService.FindValidatedForcedDecision(projectConfig, decision.OptimizelyDecisionContext{FlagKey: key, RuleKey: ""}, allOptions)
 		decisionReasons.Append(reasons)
 		if err != nil {
 			findRegularDecision()
@@ -205,6 +291,22 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 		o.logger.Warning(fmt.Sprintf(`Received error while making a decision for feature %q: %s`, key, err))
 	}
 
+	// the caller gave up while the decision was being made, so it is neither reported nor tracked
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		errorDecision := NewErrorDecision(key, userContext, decide.GetDecideError(decide.ContextDone, ctxErr))
+		errorDecision.Revision = projectConfig.GetRevision()
+		return errorDecision
+	}
+
+	// the user may be in the CMAB rule, so no other rule decides for them
+	if errors.Is(err, decision.ErrCmabFetchFailed) {
+		errorDecision := NewErrorDecision(key, userContext, decide.GetDecideError(decide.CmabFetchFailed, key, err))
+		errorDecision.StructuredReasons[0].Code = pkgReasons.CmabFetchFailed
+		errorDecision.StructuredReasons[0].RuleKey = featureDecision.Experiment.Key
+		errorDecision.Revision = projectConfig.GetRevision()
+		return errorDecision
+	}
+
 	if featureDecision.Variation != nil {
 		variationKey = featureDecision.Variation.Key
 		flagEnabled = featureDecision.Variation.FeatureEnabled
@@ -213,32 +315,46 @@ func (o *OptimizelyClient) decide(userContext OptimizelyUserContext, key string,
 	if !allOptions.DisableDecisionEvent {
 		if ue, ok := event.CreateImpressionUserEvent(decisionContext.ProjectConfig, featureDecision.Experiment,
 			featureDecision.Variation, usrContext, key, featureDecision.Experiment.Key, featureDecision.Source, flagEnabled); ok {
-			o.EventProcessor.ProcessEvent(ue)
+			ue.Impression.Metadata.CmabUUID = featureDecision.CmabUUID
+			processEvent(ue)
 			eventSent = true
 		}
 	}
 
 	variableMap := map[string]interface{}{}
 	if !allOptions.ExcludeVariables {
-		variableMap, reasons = o.getDecisionVariableMap(feature, featureDecision.Variation, flagEnabled)
+		variableMap, reasons = o.getDecisionVariableMap(flag.feature, featureDecision.Variation, flagEnabled)
 		decisionReasons.Append(reasons)
 	}
 	optimizelyJSON := optimizelyjson.NewOptimizelyJSONfromMap(variableMap)
-	reasonsToReport := decisionReasons.ToReport()
 	ruleKey := featureDecision.Experiment.Key
+	if featureDecision.Reason != "" {
+		decisionReasons.AddDetail(decide.StructuredReason{
+			Code:    featureDecision.Reason,
+			RuleKey: ruleKey,
+			Message: string(featureDecision.Reason),
+		})
+	}
+	reasonsToReport := decisionReasons.ToReport()
+	structuredReasons := decisionReasons.ToStructuredReport()
 
 	if o.notificationCenter != nil {
-		decisionNotification := decision.FlagNotification(key, variationKey, ruleKey, flagEnabled, eventSent, usrContext, variableMap, reasonsToReport)
+		decisionNotification := decision.FlagNotification(key, variationKey, ruleKey, flagEnabled, eventSent, usrContext, variableMap, reasonsToReport, structuredReasons)
+		decisionNotification.Context = ctx
 		o.logger.Info(fmt.Sprintf(`Feature %q is enabled for user %q? %v`, key, usrContext.ID, flagEnabled))
 		if e := o.notificationCenter.Send(notification.Decision, *decisionNotification); e != nil {
 			o.logger.Warning("Problem with sending notification")
 		}
 	}
 
-	return NewOptimizelyDecision(variationKey, ruleKey, key, flagEnabled, optimizelyJSON, userContext, reasonsToReport)
+	optimizelyDecision := NewOptimizelyDecision(variationKey, ruleKey, key, flagEnabled, optimizelyJSON, userContext, reasonsToReport)
+	optimizelyDecision.StructuredReasons = structuredReasons
+	optimizelyDecision.Revision = projectConfig.GetRevision()
+	optimizelyDecision.variableTypes = flag.variableTypes
+	return optimizelyDecision
 }
 
-func (o *OptimizelyClient) decideForKeys(userContext OptimizelyUserContext, keys []string, options *decide.Options) map[string]OptimizelyDecision {
+func (o *OptimizelyClient) decideForKeys(ctx context.Context, userContext OptimizelyUserContext, keys []string, options *decide.Options) map[string]OptimizelyDecision {
 	var err error
 	defer func() {
 		if r := recover(); r != nil {
@@ -256,11 +372,11 @@ func (o *OptimizelyClient) decideForKeys(userContext OptimizelyUserContext, keys
 		}
 	}()
 
-	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameDecideForKeys)
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameDecideForKeys)
 	defer span.End()
 
 	decisionMap := map[string]OptimizelyDecision{}
-	if _, err = o.getProjectConfig(); err != nil {
+	if _, err = o.getPinnedProjectConfig(userContext.configPin); err != nil {
 		o.logger.Error("Optimizely instance is not valid, failing decideForKeys call.", err)
 		return decisionMap
 	}
@@ -270,17 +386,27 @@ func (o *OptimizelyClient) decideForKeys(userContext OptimizelyUserContext, keys
 	}
 
 	enabledFlagsOnly := o.getAllOptions(options).EnabledFlagsOnly
+	// the profile of the user is looked up once and saved once for all the flags
+	userProfileTracker := decision.NewUserProfileTracker()
 	for _, key := range keys {
-		optimizelyDecision := o.decide(userContext, key, options)
+		optimizelyDecision := o.decide(ctx, userContext, key, options, userProfileTracker)
 		if !enabledFlagsOnly || optimizelyDecision.Enabled {
 			decisionMap[key] = optimizelyDecision
 		}
 	}
+	o.saveUserProfile(ctx, userProfileTracker, userContext.GetUserID())
 
 	return decisionMap
 }
 
-func (o *OptimizelyClient) decideAll(userContext OptimizelyUserContext, options *decide.Options) map[string]OptimizelyDecision {
+// saveUserProfile saves the decisions collected by userProfileTracker, logging failures
+func (o *OptimizelyClient) saveUserProfile(ctx context.Context, userProfileTracker *decision.UserProfileTracker, userID string) {
+	if err := userProfileTracker.Save(ctx); err != nil {
+		o.logger.Warning(fmt.Sprintf(`Unable to save the user profile of user %q: %s`, userID, err))
+	}
+}
+
+func (o *OptimizelyClient) decideAll(ctx context.Context, userContext OptimizelyUserContext, options *decide.Options) map[string]OptimizelyDecision {
 
 	var err error
 	defer func() {
@@ -299,10 +425,10 @@ func (o *OptimizelyClient) decideAll(userContext OptimizelyUserContext, options
 		}
 	}()
 
-	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameDecideAll)
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameDecideAll)
 	defer span.End()
 
-	projectConfig, err := o.getProjectConfig()
+	projectConfig, err := o.getPinnedProjectConfig(userContext.configPin)
 	if err != nil {
 		o.logger.Error("Optimizely instance is not valid, failing decideAll call.", err)
 		return map[string]OptimizelyDecision{}
@@ -313,12 +439,12 @@ func (o *OptimizelyClient) decideAll(userContext OptimizelyUserContext, options
 		allFlagKeys = append(allFlagKeys, flag.Key)
 	}
 
-	return o.decideForKeys(userContext, allFlagKeys, options)
+	return o.decideForKeys(ctx, userContext, allFlagKeys, options)
 }
 
 // fetchQualifiedSegments fetches all qualified segments for the user context.
 // request is performed asynchronously only when callback is provided
-func (o *OptimizelyClient) fetchQualifiedSegments(userContext *OptimizelyUserContext, options []pkgOdpSegment.OptimizelySegmentOption, callback func(success bool)) {
+func (o *OptimizelyClient) fetchQualifiedSegments(ctx context.Context, userContext *OptimizelyUserContext, options []pkgOdpSegment.OptimizelySegmentOption, callback func(success bool)) {
 	var err error
 	defer func() {
 		if r := recover(); r != nil {
@@ -335,7 +461,7 @@ func (o *OptimizelyClient) fetchQualifiedSegments(userContext *OptimizelyUserCon
 		}
 	}()
 
-	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameFetchQualifiedSegments)
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameFetchQualifiedSegments)
 	defer span.End()
 
 	// on failure, qualifiedSegments should be reset if a previous value exists.
@@ -349,7 +475,7 @@ func (o *OptimizelyClient) fetchQualifiedSegments(userContext *OptimizelyUserCon
 		return
 	}
 
-	qualifiedSegments, segmentsError := o.OdpManager.FetchQualifiedSegments(userContext.GetUserID(), options)
+	qualifiedSegments, segmentsError := o.fetchSegmentsWithContext(ctx, userContext.GetUserID(), options)
 	success := segmentsError == nil
 
 	if success {
@@ -363,6 +489,39 @@ func (o *OptimizelyClient) fetchQualifiedSegments(userContext *OptimizelyUserCon
 	}
 }
 
+// fetchSegmentsWithContext fetches qualified segments from the odp manager, giving up when the context is done
+func (o *OptimizelyClient) fetchSegmentsWithContext(ctx context.Context, userID string, options []pkgOdpSegment.OptimizelySegmentOption) ([]string, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	// contexts which can never be canceled are served synchronously
+	if ctx == nil || ctx.Done() == nil {
+		return o.OdpManager.FetchQualifiedSegments(userID, options)
+	}
+
+	type fetchResult struct {
+		segments []string
+		err      error
+	}
+	resultChan := make(chan fetchResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				resultChan <- fetchResult{err: fmt.Errorf("fetchQualifiedSegments panicked: %v", r)}
+			}
+		}()
+		segments, err := o.OdpManager.FetchQualifiedSegments(userID, options)
+		resultChan <- fetchResult{segments: segments, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.segments, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // SendOdpEvent sends an event to the ODP server.
 func (o *OptimizelyClient) SendOdpEvent(eventType, action string, identifiers map[string]string, data map[string]interface{}) (err error) {
 
@@ -952,6 +1111,10 @@ func (o *OptimizelyClient) GetVariation(experimentKey string, userContext entiti
 // Track generates a conversion event with the given event key if it exists and queues it up to be sent to the Optimizely
 // log endpoint for results processing.
 func (o *OptimizelyClient) Track(eventKey string, userContext entities.UserContext, eventTags map[string]interface{}) (err error) {
+	return o.track(o.defaultContext(), nil, eventKey, userContext, eventTags)
+}
+
+func (o *OptimizelyClient) track(ctx context.Context, pin *configPin, eventKey string, userContext entities.UserContext, eventTags map[string]interface{}) (err error) {
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -969,10 +1132,15 @@ func (o *OptimizelyClient) Track(eventKey string, userContext entities.UserConte
 		}
 	}()
 
-	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameTrack)
+	ctx, span := o.tracer.StartSpan(ctx, DefaultTracerName, SpanNameTrack)
 	defer span.End()
 
-	projectConfig, e := o.getProjectConfig()
+	if err = contextError(ctx); err != nil {
+		o.logger.Error("Optimizely SDK tracking error", err)
+		return err
+	}
+
+	projectConfig, e := o.getPinnedProjectConfig(pin)
 	if e != nil {
 		o.logger.Error("Optimizely SDK tracking error", e)
 		return e
@@ -988,7 +1156,7 @@ func (o *OptimizelyClient) Track(eventKey string, userContext entities.UserConte
 
 	userEvent := event.CreateConversionUserEvent(projectConfig, configEvent, userContext, eventTags)
 	if o.EventProcessor.ProcessEvent(userEvent) && o.notificationCenter != nil {
-		trackNotification := notification.TrackNotification{EventKey: eventKey, UserContext: userContext, EventTags: eventTags, ConversionEvent: *userEvent.Conversion}
+		trackNotification := notification.TrackNotification{EventKey: eventKey, UserContext: userContext, EventTags: eventTags, ConversionEvent: *userEvent.Conversion, Context: ctx}
 		if err = o.notificationCenter.Send(notification.Track, trackNotification); err != nil {
 			o.logger.Warning("Problem with sending notification")
 		}
@@ -1168,6 +1336,23 @@ func (o *OptimizelyClient) getProjectConfig() (projectConfig config.ProjectConfi
 	return projectConfig, nil
 }
 
+// getReadyProjectConfig returns the project config, or an error if there is none yet
+func (o *OptimizelyClient) getReadyProjectConfig() (config.ProjectConfig, error) {
+	projectConfig, err := o.getProjectConfig()
+	if err == nil && isNil(projectConfig) {
+		err = errors.New("project config is not available yet")
+	}
+	return projectConfig, err
+}
+
+// getPinnedProjectConfig returns the config pinned by a user context, or the current one if pin is nil
+func (o *OptimizelyClient) getPinnedProjectConfig(pin *configPin) (config.ProjectConfig, error) {
+	if pin == nil {
+		return o.getProjectConfig()
+	}
+	return pin.get(o.getProjectConfig)
+}
+
 func (o *OptimizelyClient) getAllOptions(options *decide.Options) decide.Options {
 	return decide.Options{
 		DisableDecisionEvent:     o.defaultDecideOptions.DisableDecisionEvent || options.DisableDecisionEvent,
@@ -1175,6 +1360,8 @@ func (o *OptimizelyClient) getAllOptions(options *decide.Options) decide.Options
 		ExcludeVariables:         o.defaultDecideOptions.ExcludeVariables || options.ExcludeVariables,
 		IgnoreUserProfileService: o.defaultDecideOptions.IgnoreUserProfileService || options.IgnoreUserProfileService,
 		IncludeReasons:           o.defaultDecideOptions.IncludeReasons || options.IncludeReasons,
+		IgnoreCMABCache:          o.defaultDecideOptions.IgnoreCMABCache || options.IgnoreCMABCache,
+		ResetCMABCache:           o.defaultDecideOptions.ResetCMABCache || options.ResetCMABCache,
 	}
 }
 
@@ -1195,6 +1382,60 @@ func (o *OptimizelyClient) Close() {
 	o.execGroup.TerminateAndWait()
 }
 
+// ShutdownReport holds the number of events dispatched, dropped and still pending per component when the
+// Optimizely instance was closed
+type ShutdownReport struct {
+	EventProcessor  event.FlushReport
+	OdpEventManager event.FlushReport
+}
+
+// CloseWithContext flushes the queued events and closes the Optimizely instance like Close, but returns once ctx is
+// done even if events are still pending. The context's error is returned in that case, and the components which
+// have not stopped yet keep shutting down in the background.
+func (o *OptimizelyClient) CloseWithContext(ctx context.Context) (report ShutdownReport, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	batchProcessor, _ := o.EventProcessor.(*event.BatchEventProcessor)
+	if batchProcessor != nil {
+		if err = batchProcessor.Flush(ctx); err != nil {
+			o.logger.Warning(fmt.Sprintf("Events were not flushed before closing: %s", err))
+		}
+	}
+
+	var odpEventManager *pkgOdpEvent.BatchEventManager
+	if odpManager, ok := o.OdpManager.(*odp.DefaultOdpManager); ok && odpManager.OdpConfig != nil {
+		if odpEventManager, _ = odpManager.EventManager.(*pkgOdpEvent.BatchEventManager); odpEventManager != nil {
+			if e := odpEventManager.FlushWithContext(ctx, odpManager.OdpConfig.GetAPIKey(), odpManager.OdpConfig.GetAPIHost()); e != nil {
+				o.logger.Warning(fmt.Sprintf("ODP events were not flushed before closing: %s", e))
+				err = e
+			}
+		}
+	}
+
+	if o.execGroup != nil {
+		terminated := make(chan struct{})
+		go func() {
+			o.execGroup.TerminateAndWait()
+			close(terminated)
+		}()
+		select {
+		case <-terminated:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	if batchProcessor != nil {
+		report.EventProcessor = batchProcessor.Report()
+	}
+	if odpEventManager != nil {
+		report.OdpEventManager = odpEventManager.Report()
+	}
+	return report, err
+}
+
 func (o *OptimizelyClient) getDecisionVariableMap(feature entities.Feature, variation *entities.Variation, featureEnabled bool) (map[string]interface{}, decide.DecisionReasons) {
 	_, span := o.tracer.StartSpan(o.ctx, DefaultTracerName, SpanNameGetDecisionVariableMap)
 	defer span.End()
@@ -1221,6 +1462,14 @@ func (o *OptimizelyClient) getDecisionVariableMap(feature entities.Feature, vari
 	return valuesMap, reasons
 }
 
+// contextError returns the error of a done context. A nil context is never done.
+func contextError(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
 func isNil(v interface{}) bool {
 	return v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil())
 }
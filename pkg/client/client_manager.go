@@ -0,0 +1,480 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/metrics"
+	"github.com/optimizely/go-sdk/v2/pkg/odp"
+	odpEvent "github.com/optimizely/go-sdk/v2/pkg/odp/event"
+	"github.com/optimizely/go-sdk/v2/pkg/odp/segment"
+	odpUtils "github.com/optimizely/go-sdk/v2/pkg/odp/utils"
+	"github.com/optimizely/go-sdk/v2/pkg/registry"
+	"github.com/optimizely/go-sdk/v2/pkg/utils"
+)
+
+// DefaultClientIdleTimeout is the default duration after which a ClientManager evicts a client which was not requested
+const DefaultClientIdleTimeout = 30 * time.Minute
+
+// ErrClientManagerClosed is returned when a client is requested from a closed ClientManager
+var ErrClientManagerClosed = errors.New("client manager is closed")
+
+// ClientManager creates OptimizelyClient instances per SDK key on demand. The clients share one HTTP transport for
+// polling datafiles, fetching ODP segments and sending ODP events, and one event dispatcher. Clients which were not
+// used for longer than the idle timeout are closed and evicted, see Get and Acquire.
+type ClientManager struct {
+	clientOptions     []OptionFunc
+	idleTimeout       time.Duration
+	httpClient        *http.Client
+	requester         *utils.HTTPRequester
+	segmentRequester  *utils.HTTPRequester
+	odpEventRequester *utils.HTTPRequester
+	eventDispatcher   *sharedDispatcher
+	metricsRegistry   metrics.Registry
+	ctx               context.Context
+	execGroup         *utils.ExecGroup
+	logger            logging.OptimizelyLogProducer
+
+	mutex   sync.Mutex
+	clients map[string]*managedClient
+	closed  bool
+	created int
+	evicted int
+	// events of the clients which were evicted or closed
+	retiredEvents event.FlushReport
+}
+
+// ClientManagerOptionFunc is used to provide custom configuration to the ClientManager.
+type ClientManagerOptionFunc func(*ClientManager)
+
+// ClientHealth describes the state of a client held by a ClientManager
+type ClientHealth struct {
+	SDKKey   string
+	Ready    bool
+	Revision string
+	LastUsed time.Time
+}
+
+// ClientManagerMetrics holds the combined metrics of the clients of a ClientManager
+type ClientManagerMetrics struct {
+	Clients int
+	Created int
+	Evicted int
+	// Events sums the events of the batch event processors of all clients, including the evicted ones. Events handed
+	// to the shared dispatcher count as dispatched, and events still pending when a client was closed as dropped.
+	Events event.FlushReport
+	// Dispatcher holds the events sent and still queued by the shared dispatcher, when it is a QueueEventDispatcher
+	Dispatcher event.FlushReport
+}
+
+type managedClient struct {
+	client   *OptimizelyClient
+	err      error
+	created  chan struct{}
+	lastUsed time.Time
+	// leases counts the Acquire calls which were not released, the client is not evicted while it is leased
+	leases int
+}
+
+// sharedDispatcher hides the shared dispatcher from the event processors, so that closing one client does not wait
+// for the events of the other clients to be dispatched
+type sharedDispatcher struct {
+	event.Dispatcher
+}
+
+// WithManagerClientOptions sets the options used to create every client of the manager.
+func WithManagerClientOptions(clientOptions ...OptionFunc) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.clientOptions = append(m.clientOptions, clientOptions...)
+	}
+}
+
+// WithManagerIdleTimeout sets the duration after which a client which was not requested is evicted.
+// Clients are never evicted when the timeout is 0.
+func WithManagerIdleTimeout(idleTimeout time.Duration) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.idleTimeout = idleTimeout
+	}
+}
+
+// WithManagerHTTPClient sets the http client shared by the clients for polling datafiles and dispatching events.
+func WithManagerHTTPClient(httpClient http.Client) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.httpClient = &httpClient
+	}
+}
+
+// WithManagerEventDispatcher sets the event dispatcher shared by the clients.
+func WithManagerEventDispatcher(eventDispatcher event.Dispatcher) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.eventDispatcher = &sharedDispatcher{Dispatcher: eventDispatcher}
+	}
+}
+
+// WithManagerMetricsRegistry sets the metrics registry shared by the clients.
+func WithManagerMetricsRegistry(metricsRegistry metrics.Registry) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.metricsRegistry = metricsRegistry
+	}
+}
+
+// WithManagerContext sets the context of the manager and its clients.
+func WithManagerContext(ctx context.Context) ClientManagerOptionFunc {
+	return func(m *ClientManager) {
+		m.ctx = ctx
+	}
+}
+
+// NewClientManager returns a ClientManager with the given options
+func NewClientManager(options ...ClientManagerOptionFunc) *ClientManager {
+	m := &ClientManager{
+		idleTimeout: DefaultClientIdleTimeout,
+		ctx:         context.Background(),
+		clients:     map[string]*managedClient{},
+		logger:      logging.GetLogger("", "ClientManager"),
+	}
+
+	for _, opt := range options {
+		opt(m)
+	}
+
+	if m.metricsRegistry == nil {
+		m.metricsRegistry = metrics.NewNoopRegistry()
+	}
+
+	m.requester = m.newRequester("HTTPRequester")
+	// the ODP requesters copy the http client, so they share its transport but keep the ODP timeouts
+	m.segmentRequester = m.newRequester("SegmentAPIManager", utils.Timeout(odpUtils.DefaultSegmentFetchTimeout))
+	m.odpEventRequester = m.newRequester("EventAPIManager", utils.Timeout(odpUtils.DefaultOdpEventTimeout))
+
+	if m.eventDispatcher == nil {
+		dispatcher := event.NewQueueEventDispatcher("", m.metricsRegistry)
+		dispatcher.Dispatcher = event.NewHTTPEventDispatcher("", m.requester, nil)
+		m.eventDispatcher = &sharedDispatcher{Dispatcher: dispatcher}
+	}
+
+	m.execGroup = utils.NewExecGroup(m.ctx, logging.GetLogger("", "ExecGroup"))
+	if m.idleTimeout > 0 {
+		m.execGroup.Go(m.startEvictionTicker)
+	}
+	return m
+}
+
+// Get returns the client of the given SDK key and creates it when the manager does not hold one yet. The client
+// options are only applied when the client is created, after the options of the manager.
+//
+// Only Get marks the client as used, so a client which is held on to is closed once it is idle for longer than the
+// idle timeout, and its later decisions and events are lost. Call Get for every request, or use Acquire to hold the
+// client.
+func (m *ClientManager) Get(sdkKey string, clientOptions ...OptionFunc) (*OptimizelyClient, error) {
+	entry, err := m.get(sdkKey, clientOptions, false)
+	if err != nil {
+		return nil, err
+	}
+	return entry.client, nil
+}
+
+// Acquire returns the client of the given SDK key like Get does, and keeps the client from being evicted as idle
+// until release is called. Calling release more than once has no effect.
+func (m *ClientManager) Acquire(sdkKey string, clientOptions ...OptionFunc) (client *OptimizelyClient, release func(), err error) {
+	entry, err := m.get(sdkKey, clientOptions, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	once := sync.Once{}
+	release = func() {
+		once.Do(func() {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			entry.leases--
+			entry.lastUsed = time.Now()
+		})
+	}
+	return entry.client, release, nil
+}
+
+func (m *ClientManager) get(sdkKey string, clientOptions []OptionFunc, lease bool) (*managedClient, error) {
+	if sdkKey == "" {
+		return nil, errors.New("unable to get client: no SDK key provided")
+	}
+
+	m.mutex.Lock()
+	if m.closed {
+		m.mutex.Unlock()
+		return nil, ErrClientManagerClosed
+	}
+	entry, ok := m.clients[sdkKey]
+	if !ok {
+		entry = &managedClient{created: make(chan struct{})}
+		m.clients[sdkKey] = entry
+	}
+	entry.lastUsed = time.Now()
+	if lease {
+		entry.leases++
+	}
+	m.mutex.Unlock()
+
+	if !ok {
+		// the client is created without holding the lock, since fetching the datafile may take a while
+		entry.client, entry.err = m.newClient(sdkKey, clientOptions)
+		m.mutex.Lock()
+		if entry.err != nil {
+			delete(m.clients, sdkKey)
+		} else {
+			m.created++
+		}
+		m.mutex.Unlock()
+		close(entry.created)
+	}
+
+	<-entry.created
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry, nil
+}
+
+// Evict closes and removes the client of the given SDK key. It returns false if the manager does not hold one.
+func (m *ClientManager) Evict(sdkKey string) bool {
+	m.mutex.Lock()
+	entry, ok := m.clients[sdkKey]
+	if !ok || !entry.isCreated() {
+		m.mutex.Unlock()
+		return false
+	}
+	delete(m.clients, sdkKey)
+	m.mutex.Unlock()
+
+	m.retire(sdkKey, entry, true)
+	return true
+}
+
+// Health returns the state of every client, sorted by SDK key
+func (m *ClientManager) Health() []ClientHealth {
+	health := []ClientHealth{}
+	for sdkKey, entry := range m.snapshot() {
+		clientHealth := ClientHealth{SDKKey: sdkKey, LastUsed: entry.lastUsed}
+		if entry.client != nil {
+			if projectConfig, err := entry.client.getReadyProjectConfig(); err == nil {
+				clientHealth.Ready = true
+				clientHealth.Revision = projectConfig.GetRevision()
+			}
+		}
+		health = append(health, clientHealth)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].SDKKey < health[j].SDKKey
+	})
+	return health
+}
+
+// IsHealthy returns true if every client of the manager has a project config
+func (m *ClientManager) IsHealthy() bool {
+	for _, clientHealth := range m.Health() {
+		if !clientHealth.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// Metrics returns the combined metrics of the clients
+func (m *ClientManager) Metrics() ClientManagerMetrics {
+	entries := m.snapshot()
+
+	m.mutex.Lock()
+	managerMetrics := ClientManagerMetrics{
+		Clients: len(entries),
+		Created: m.created,
+		Evicted: m.evicted,
+		Events:  m.retiredEvents,
+	}
+	m.mutex.Unlock()
+
+	for _, entry := range entries {
+		if entry.client == nil {
+			continue
+		}
+		if batchProcessor, ok := entry.client.EventProcessor.(*event.BatchEventProcessor); ok {
+			report := batchProcessor.Report()
+			managerMetrics.Events.Dispatched += report.Dispatched
+			managerMetrics.Events.Dropped += report.Dropped
+			managerMetrics.Events.Pending += report.Pending
+		}
+	}
+
+	if dispatcher, ok := m.eventDispatcher.Dispatcher.(*event.QueueEventDispatcher); ok {
+		managerMetrics.Dispatcher = dispatcher.Report()
+	}
+	return managerMetrics
+}
+
+// Close closes all the clients and waits for the shared dispatcher to send the remaining events.
+func (m *ClientManager) Close() {
+	m.mutex.Lock()
+	if m.closed {
+		m.mutex.Unlock()
+		return
+	}
+	m.closed = true
+	entries := m.clients
+	m.clients = map[string]*managedClient{}
+	m.mutex.Unlock()
+
+	m.execGroup.TerminateAndWait()
+	for sdkKey, entry := range entries {
+		<-entry.created
+		if entry.client != nil {
+			m.retire(sdkKey, entry, false)
+		}
+	}
+
+	if dispatcher, ok := m.eventDispatcher.Dispatcher.(*event.QueueEventDispatcher); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), event.CloseEventDispatchTimeout)
+		defer cancel()
+		if err := dispatcher.Flush(ctx); err != nil {
+			m.logger.Warning(fmt.Sprintf("Events were not dispatched before closing: %s", err))
+		}
+	}
+}
+
+func (m *ClientManager) newRequester(name string, options ...func(*utils.HTTPRequester)) *utils.HTTPRequester {
+	if m.httpClient != nil {
+		options = append([]func(*utils.HTTPRequester){utils.Client(*m.httpClient)}, options...)
+	}
+	return utils.NewHTTPRequester(logging.GetLogger("", name), options...)
+}
+
+func (m *ClientManager) newClient(sdkKey string, clientOptions []OptionFunc) (*OptimizelyClient, error) {
+	factory := &OptimizelyFactory{SDKKey: sdkKey}
+	options := []OptionFunc{WithContext(m.ctx), WithMetricsRegistry(m.metricsRegistry)}
+	options = append(options, m.clientOptions...)
+	options = append(options, clientOptions...)
+	options = append(options, m.withSharedServices)
+	return factory.Client(options...)
+}
+
+// withSharedServices sets the shared requesters and event dispatcher, unless the client options provide their own
+// config manager, ODP manager or dispatcher. These use requesters of their own.
+func (m *ClientManager) withSharedServices(f *OptimizelyFactory) {
+	if f.configManager == nil {
+		f.configManager = config.NewPollingProjectConfigManager(
+			f.SDKKey,
+			config.WithInitialDatafile(f.Datafile),
+			config.WithDatafileAccessToken(f.DatafileAccessToken),
+			config.WithRequester(m.requester),
+		)
+	}
+	if f.odpManager == nil && !f.odpDisabled {
+		segmentManager := segment.NewSegmentManager(f.SDKKey,
+			segment.WithSegmentsCacheSize(f.segmentsCacheSize),
+			segment.WithSegmentsCacheTimeout(f.segmentsCacheTimeout),
+			segment.WithAPIManager(segment.NewSegmentAPIManager(f.SDKKey, m.segmentRequester)),
+		)
+		eventManager := odpEvent.NewBatchEventManager(
+			odpEvent.WithSDKKey(f.SDKKey),
+			odpEvent.WithAPIManager(odpEvent.NewEventAPIManager(f.SDKKey, m.odpEventRequester)),
+		)
+		f.odpManager = odp.NewOdpManager(f.SDKKey, false, odp.WithSegmentManager(segmentManager), odp.WithEventManager(eventManager))
+	}
+	if f.eventDispatcher == nil {
+		f.eventDispatcher = m.eventDispatcher
+	}
+}
+
+func (m *ClientManager) startEvictionTicker(ctx context.Context) {
+	ticker := time.NewTicker(m.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.evictIdle(now)
+		}
+	}
+}
+
+// evictIdle closes and removes the clients which are not leased and were not used since the idle timeout before now
+func (m *ClientManager) evictIdle(now time.Time) int {
+	idle := map[string]*managedClient{}
+	m.mutex.Lock()
+	for sdkKey, entry := range m.clients {
+		if entry.isCreated() && entry.leases == 0 && now.Sub(entry.lastUsed) >= m.idleTimeout {
+			idle[sdkKey] = entry
+			delete(m.clients, sdkKey)
+		}
+	}
+	m.mutex.Unlock()
+
+	for sdkKey, entry := range idle {
+		m.logger.Debug(fmt.Sprintf("Evicting idle client of SDK key %s", sdkKey))
+		m.retire(sdkKey, entry, true)
+	}
+	return len(idle)
+}
+
+// retire closes a client which was removed from the manager and keeps its event counts
+func (m *ClientManager) retire(sdkKey string, entry *managedClient, evicted bool) {
+	entry.client.Close()
+	registry.RemoveNotificationCenter(sdkKey)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if evicted {
+		m.evicted++
+	}
+	if batchProcessor, ok := entry.client.EventProcessor.(*event.BatchEventProcessor); ok {
+		report := batchProcessor.Report()
+		m.retiredEvents.Dispatched += report.Dispatched
+		m.retiredEvents.Dropped += report.Dropped + report.Pending
+	}
+}
+
+// snapshot returns the clients which were created, with the time they were last requested
+func (m *ClientManager) snapshot() map[string]managedClient {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entries := map[string]managedClient{}
+	for sdkKey, entry := range m.clients {
+		if entry.isCreated() {
+			entries[sdkKey] = managedClient{client: entry.client, lastUsed: entry.lastUsed}
+		}
+	}
+	return entries
+}
+
+func (c *managedClient) isCreated() bool {
+	select {
+	case <-c.created:
+		return true
+	default:
+		return false
+	}
+}
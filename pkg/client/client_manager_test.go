@@ -0,0 +1,251 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+const managerDatafile = `{"version":"4","revision":"42","projectId":"1","accountId":"2","events":[{"id":"3","key":"purchase","experimentIds":[]}]}`
+
+const managerOdpDatafile = `{"version":"4","revision":"42","projectId":"1","accountId":"2","events":[],
+	"integrations":[{"key":"odp","publicKey":"odp_key","host":"https://odp.example.com"}],
+	"typedAudiences":[{"id":"5","name":"segment1","conditions":["or",{"type":"third_party_dimension","name":"odp.audiences","match":"qualified","value":"segment1"}]}]}`
+
+// managerTransport serves the datafile for every GET request and accepts every POSTed event batch
+type managerTransport struct {
+	mutex            sync.Mutex
+	datafile         string
+	datafileRequests []string
+	eventRequests    int
+	hosts            map[string]bool
+}
+
+func (t *managerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.hosts == nil {
+		t.hosts = map[string]bool{}
+	}
+	t.hosts[req.URL.Host] = true
+	datafile := t.datafile
+	if datafile == "" {
+		datafile = managerDatafile
+	}
+	response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: req, Body: io.NopCloser(strings.NewReader(datafile))}
+	if req.Method == http.MethodPost {
+		t.eventRequests++
+		response.StatusCode = http.StatusNoContent
+		response.Body = io.NopCloser(strings.NewReader(""))
+	} else {
+		t.datafileRequests = append(t.datafileRequests, req.URL.Path)
+	}
+	return response, nil
+}
+
+func (t *managerTransport) counts() (datafileRequests, eventRequests int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return len(t.datafileRequests), t.eventRequests
+}
+
+type ClientManagerTestSuite struct {
+	suite.Suite
+	transport *managerTransport
+	manager   *ClientManager
+}
+
+func (s *ClientManagerTestSuite) SetupTest() {
+	s.transport = &managerTransport{}
+	s.manager = NewClientManager(
+		WithManagerHTTPClient(http.Client{Transport: s.transport}),
+		WithManagerIdleTimeout(time.Hour),
+		WithManagerClientOptions(WithOdpDisabled(true)),
+	)
+}
+
+func (s *ClientManagerTestSuite) TearDownTest() {
+	s.manager.Close()
+}
+
+func (s *ClientManagerTestSuite) TestGetReusesClients() {
+	clientA, err := s.manager.Get("manager_sdk_key_a")
+	s.NoError(err)
+	clientA2, err := s.manager.Get("manager_sdk_key_a")
+	s.NoError(err)
+	clientB, err := s.manager.Get("manager_sdk_key_b")
+	s.NoError(err)
+
+	s.Same(clientA, clientA2)
+	s.NotSame(clientA, clientB)
+
+	datafileRequests, _ := s.transport.counts()
+	s.Equal(2, datafileRequests)
+
+	metrics := s.manager.Metrics()
+	s.Equal(2, metrics.Clients)
+	s.Equal(2, metrics.Created)
+	s.Equal(0, metrics.Evicted)
+
+	health := s.manager.Health()
+	s.Len(health, 2)
+	s.Equal("manager_sdk_key_a", health[0].SDKKey)
+	s.True(health[0].Ready)
+	s.Equal("42", health[0].Revision)
+	s.Equal("manager_sdk_key_b", health[1].SDKKey)
+	s.True(s.manager.IsHealthy())
+}
+
+func (s *ClientManagerTestSuite) TestGetCreatesClientOnce() {
+	wg := sync.WaitGroup{}
+	clients := make([]*OptimizelyClient, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = s.manager.Get("manager_sdk_key_concurrent")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, client := range clients {
+		s.Same(clients[0], client)
+	}
+	s.Equal(1, s.manager.Metrics().Created)
+}
+
+func (s *ClientManagerTestSuite) TestClientsShareEventDispatcher() {
+	clientA, err := s.manager.Get("manager_sdk_key_events_a")
+	s.NoError(err)
+	clientB, err := s.manager.Get("manager_sdk_key_events_b")
+	s.NoError(err)
+
+	s.NoError(clientA.Track("purchase", entities.UserContext{ID: "user1"}, nil))
+	s.NoError(clientB.Track("purchase", entities.UserContext{ID: "user2"}, nil))
+
+	s.manager.Close()
+
+	_, eventRequests := s.transport.counts()
+	s.Equal(2, eventRequests)
+
+	metrics := s.manager.Metrics()
+	s.Equal(0, metrics.Clients)
+	s.Equal(2, metrics.Events.Dispatched)
+	s.Equal(0, metrics.Events.Dropped)
+	s.Equal(2, metrics.Dispatcher.Dispatched)
+	s.Equal(0, metrics.Dispatcher.Pending)
+}
+
+func (s *ClientManagerTestSuite) TestEvictIdleClients() {
+	client, err := s.manager.Get("manager_sdk_key_idle")
+	s.NoError(err)
+
+	s.Equal(0, s.manager.evictIdle(time.Now()))
+	s.Equal(1, s.manager.evictIdle(time.Now().Add(2*time.Hour)))
+
+	newClient, err := s.manager.Get("manager_sdk_key_idle")
+	s.NoError(err)
+	s.NotSame(client, newClient)
+
+	metrics := s.manager.Metrics()
+	s.Equal(1, metrics.Clients)
+	s.Equal(2, metrics.Created)
+	s.Equal(1, metrics.Evicted)
+}
+
+func (s *ClientManagerTestSuite) TestAcquireKeepsClientFromEviction() {
+	client, release, err := s.manager.Acquire("manager_sdk_key_acquire")
+	s.NoError(err)
+
+	// a leased client is not evicted however long it is held
+	s.Equal(0, s.manager.evictIdle(time.Now().Add(2*time.Hour)))
+	getClient, err := s.manager.Get("manager_sdk_key_acquire")
+	s.NoError(err)
+	s.Same(client, getClient)
+
+	release()
+	release()
+	s.Equal(0, s.manager.evictIdle(time.Now()))
+	s.Equal(1, s.manager.evictIdle(time.Now().Add(2*time.Hour)))
+
+	_, _, err = s.manager.Acquire("")
+	s.Error(err)
+}
+
+func (s *ClientManagerTestSuite) TestEvict() {
+	_, err := s.manager.Get("manager_sdk_key_evict")
+	s.NoError(err)
+
+	s.True(s.manager.Evict("manager_sdk_key_evict"))
+	s.False(s.manager.Evict("manager_sdk_key_evict"))
+	s.Empty(s.manager.Health())
+}
+
+func (s *ClientManagerTestSuite) TestGetErrors() {
+	_, err := s.manager.Get("")
+	s.Error(err)
+
+	s.manager.Close()
+	_, err = s.manager.Get("manager_sdk_key_closed")
+	s.ErrorIs(err, ErrClientManagerClosed)
+}
+
+func TestClientManagerEvictionTicker(t *testing.T) {
+	manager := NewClientManager(
+		WithManagerHTTPClient(http.Client{Transport: &managerTransport{}}),
+		WithManagerIdleTimeout(20*time.Millisecond),
+		WithManagerClientOptions(WithOdpDisabled(true)),
+	)
+	defer manager.Close()
+
+	_, err := manager.Get("manager_sdk_key_ticker")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return manager.Metrics().Evicted == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClientManagerSharesTransportWithOdp(t *testing.T) {
+	transport := &managerTransport{datafile: managerOdpDatafile}
+	manager := NewClientManager(WithManagerHTTPClient(http.Client{Transport: transport}))
+	defer manager.Close()
+
+	client, err := manager.Get("manager_sdk_key_odp")
+	assert.NoError(t, err)
+	userContext := client.CreateUserContext("user1", nil)
+	// the transport does not answer like ODP, only the request matters
+	userContext.FetchQualifiedSegments(nil)
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	assert.True(t, transport.hosts["odp.example.com"])
+}
+
+func TestClientManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientManagerTestSuite))
+}
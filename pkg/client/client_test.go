@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"sync"
 	"testing"
@@ -37,6 +38,7 @@ import (
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
 	"github.com/optimizely/go-sdk/v2/pkg/odp"
+	pkgOdpEvent "github.com/optimizely/go-sdk/v2/pkg/odp/event"
 	"github.com/optimizely/go-sdk/v2/pkg/odp/segment"
 	pkgOdpUtils "github.com/optimizely/go-sdk/v2/pkg/odp/utils"
 	"github.com/optimizely/go-sdk/v2/pkg/tracing"
@@ -1439,6 +1441,81 @@ func TestGetProjectConfigIsInValid(t *testing.T) {
 	assert.True(t, client.tracer.(*MockTracer).StartSpanCalled)
 }
 
+type datafileRequester struct {
+	utils.Requester
+	datafile []byte
+}
+
+func (r *datafileRequester) Get(string, ...utils.Header) ([]byte, http.Header, int, error) {
+	return r.datafile, http.Header{}, http.StatusOK, nil
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	requester := &datafileRequester{datafile: []byte(`{"revision":"42","version": "4"}`)}
+	configManager := config.NewAsyncPollingProjectConfigManager("wait_until_ready_sdk_key", config.WithRequester(requester))
+	client := OptimizelyClient{
+		ConfigManager: configManager,
+		logger:        logging.GetLogger("", ""),
+		tracer:        &MockTracer{},
+	}
+	assert.False(t, client.IsReady())
+
+	revisions := make(chan string, 1)
+	_, err := client.OnReady(func(readyNotification notification.ReadyNotification) {
+		revisions <- readyNotification.Revision
+	})
+	assert.NoError(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		configManager.SyncConfig()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, client.WaitUntilReady(ctx))
+	assert.True(t, client.IsReady())
+	assert.Equal(t, "42", <-revisions)
+}
+
+func TestWaitUntilReadyContextDone(t *testing.T) {
+	configManager := config.NewAsyncPollingProjectConfigManager("wait_until_ready_timeout_sdk_key", config.WithRequester(&datafileRequester{}))
+	client := OptimizelyClient{
+		ConfigManager: configManager,
+		logger:        logging.GetLogger("", ""),
+		tracer:        &MockTracer{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.WaitUntilReady(ctx), context.DeadlineExceeded)
+	assert.False(t, client.IsReady())
+}
+
+func TestWaitUntilReadyWithoutReadyNotifier(t *testing.T) {
+	client := OptimizelyClient{
+		ConfigManager: ValidProjectConfigManager(),
+		logger:        logging.GetLogger("", ""),
+		tracer:        &MockTracer{},
+	}
+	assert.NoError(t, client.WaitUntilReady(context.Background()))
+	assert.True(t, client.IsReady())
+
+	var revision string
+	id, err := client.OnReady(func(readyNotification notification.ReadyNotification) {
+		revision = readyNotification.Revision
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, id)
+	assert.Equal(t, client.ConfigManager.(*MockProjectConfigManager).projectConfig.GetRevision(), revision)
+
+	client.ConfigManager = InValidProjectConfigManager()
+	assert.Error(t, client.WaitUntilReady(context.Background()))
+	assert.False(t, client.IsReady())
+	_, err = client.OnReady(func(notification.ReadyNotification) {})
+	assert.Error(t, err)
+}
+
 func TestGetOptimizelyConfig(t *testing.T) {
 	mockConfigManager := ValidProjectConfigManager()
 
@@ -2835,6 +2912,62 @@ func TestClose(t *testing.T) {
 	wg.Wait()
 }
 
+func TestCloseWithContext(t *testing.T) {
+	eg := utils.NewExecGroup(context.Background(), logging.GetLogger("", "ExecGroup"))
+	dispatcher := &MockDispatcher{}
+	processor := event.NewBatchEventProcessor(event.WithEventDispatcher(dispatcher))
+	eg.Go(processor.Start)
+
+	apiManager := &MockEventAPIManager{}
+	apiManager.wg.Add(1)
+	odpManager := odp.NewOdpManager("TestCloseWithContext", false,
+		odp.WithEventManager(pkgOdpEvent.NewBatchEventManager(pkgOdpEvent.WithAPIManager(apiManager))))
+	odpManager.Update("api-key", "api-host", nil)
+
+	client := OptimizelyClient{
+		ConfigManager:  ValidProjectConfigManager(),
+		EventProcessor: processor,
+		OdpManager:     odpManager,
+		execGroup:      eg,
+		logger:         logging.GetLogger("", ""),
+		tracer:         &MockTracer{},
+	}
+
+	assert.True(t, processor.ProcessEvent(event.UserEvent{}))
+	assert.True(t, processor.ProcessEvent(event.UserEvent{}))
+	assert.NoError(t, odpManager.SendOdpEvent("t1", "a1", map[string]string{"fs_user_id": "u1"}, nil))
+
+	report, err := client.CloseWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, event.FlushReport{Dispatched: 2}, report.EventProcessor)
+	assert.Equal(t, event.FlushReport{Dispatched: 1}, report.OdpEventManager)
+	assert.Len(t, dispatcher.Events, 1)
+	assert.Len(t, apiManager.eventsSent, 1)
+}
+
+func TestCloseWithContextDeadlineExceeded(t *testing.T) {
+	eg := utils.NewExecGroup(context.Background(), logging.GetLogger("", "ExecGroup"))
+	release := make(chan struct{})
+	defer close(release)
+	eg.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		<-release
+	})
+
+	client := OptimizelyClient{
+		ConfigManager:  ValidProjectConfigManager(),
+		EventProcessor: &MockProcessor{},
+		execGroup:      eg,
+		logger:         logging.GetLogger("", ""),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	report, err := client.CloseWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, ShutdownReport{}, report)
+}
+
 type ClientTestSuiteTrackEvent struct {
 	suite.Suite
 	mockProcessor       *MockProcessor
@@ -3001,7 +3134,7 @@ func (s *ClientTestSuiteTrackEvent) TestTrackNotificationNotCalledWhenSendThrows
 	configEvent, err := config.GetEventByKey("sample_conversion")
 	s.NoError(err)
 	userEvent := event.CreateConversionUserEvent(config, configEvent, expectedUserContext, map[string]interface{}{})
-	expectedTrackNotification := notification.TrackNotification{EventKey: "sample_conversion", UserContext: expectedUserContext, EventTags: map[string]interface{}{}, ConversionEvent: *userEvent.Conversion}
+	expectedTrackNotification := notification.TrackNotification{EventKey: "sample_conversion", UserContext: expectedUserContext, EventTags: map[string]interface{}{}, ConversionEvent: *userEvent.Conversion, Context: context.Background()}
 
 	mockNotificationCenter.On("Send", notification.Track, expectedTrackNotification).Return(fmt.Errorf(""))
 	mockNotificationCenter.On("AddHandler", notification.Track, mock.AnythingOfType("func(interface {})")).Return(1, nil)
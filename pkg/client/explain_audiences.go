@@ -0,0 +1,84 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgDecision "github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// RuleAudienceExplanation is the evaluated audience condition tree of a rule of a flag
+type RuleAudienceExplanation struct {
+	RuleKey string `json:"ruleKey"`
+	RuleID  string `json:"ruleId"`
+	// Source is the kind of rule, "holdout", "feature-test" or "rollout"
+	Source pkgDecision.Source `json:"source"`
+	// Conditions is the evaluated audience condition tree, nil when the rule targets everyone
+	Conditions *evaluator.ExplainedNode `json:"conditions"`
+	// Result is true if the user meets the audience conditions of the rule, nil if they could not be evaluated
+	Result *bool `json:"result"`
+}
+
+// ExplainAudiences evaluates the audience conditions of every rule of the flag for the user, in the order the rules
+// are decided, and returns the evaluated condition trees. Nothing is tracked, notified or saved.
+func (o *OptimizelyClient) ExplainAudiences(userContext OptimizelyUserContext, flagKey string) ([]RuleAudienceExplanation, error) {
+	projectConfig, err := o.getPinnedProjectConfig(userContext.configPin)
+	if err != nil {
+		return nil, decide.GetDecideError(decide.SDKNotReady)
+	}
+
+	feature, err := projectConfig.GetFeatureByKey(flagKey)
+	if err != nil {
+		return nil, decide.GetDecideError(decide.FlagKeyInvalid, flagKey)
+	}
+
+	usrContext := entities.UserContext{
+		ID:                userContext.GetUserID(),
+		Attributes:        userContext.GetUserAttributes(),
+		QualifiedSegments: userContext.GetQualifiedSegments(),
+	}
+	condTreeParams := entities.NewTreeParameters(&usrContext, projectConfig.GetAudienceMap())
+	treeEvaluator := evaluator.NewMixedTreeEvaluator(o.logger)
+
+	explain := func(experiment entities.Experiment, source pkgDecision.Source) RuleAudienceExplanation {
+		explanation := RuleAudienceExplanation{
+			RuleKey: experiment.Key,
+			RuleID:  experiment.ID,
+			Source:  source,
+			Result:  decide.EvaluationResult(true),
+		}
+		if experiment.AudienceConditionTree != nil {
+			explanation.Conditions = treeEvaluator.Explain(experiment.AudienceConditionTree, condTreeParams)
+			explanation.Result = explanation.Conditions.Result
+		}
+		return explanation
+	}
+
+	explanations := []RuleAudienceExplanation{}
+	for _, holdout := range feature.Holdouts {
+		explanations = append(explanations, explain(holdout.ToExperiment(), pkgDecision.Holdout))
+	}
+	for _, experiment := range feature.FeatureExperiments {
+		explanations = append(explanations, explain(experiment, pkgDecision.FeatureTest))
+	}
+	for _, experiment := range feature.Rollout.Experiments {
+		explanations = append(explanations, explain(experiment, pkgDecision.Rollout))
+	}
+	return explanations, nil
+}
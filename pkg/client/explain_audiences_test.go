@@ -0,0 +1,70 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+)
+
+func (s *OptimizelyUserContextTestSuite) TestExplainAudiences() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, map[string]interface{}{"gender": "m", "country": "US"})
+	explanations, err := s.OptimizelyClient.ExplainAudiences(user, "feature_1")
+	s.NoError(err)
+	s.Len(explanations, 4)
+
+	experiment := explanations[0]
+	s.Equal("exp_with_audience", experiment.RuleKey)
+	s.Equal(decision.FeatureTest, experiment.Source)
+	s.Equal(decide.EvaluationResult(false), experiment.Result)
+	audience := experiment.Conditions.Nodes[0]
+	s.Equal("13389141123", audience.AudienceID)
+	// walk down the and/or/or operators of the audience conditions to the leaf
+	leaf := audience.Nodes[0].Nodes[0].Nodes[0].Nodes[0]
+	s.Equal("gender", leaf.ConditionName)
+	s.Equal("exact", leaf.Matcher)
+	s.Equal("f", leaf.ConditionValue)
+	s.Equal("m", leaf.AttributeValue)
+	s.Equal(decide.EvaluationResult(false), leaf.Result)
+
+	s.Equal("3332020515", explanations[1].RuleKey)
+	s.Equal(decision.Rollout, explanations[1].Source)
+	s.Equal(decide.EvaluationResult(true), explanations[1].Result)
+
+	// the browser attribute is missing, so the audience evaluates to null
+	s.Equal("3332020494", explanations[2].RuleKey)
+	s.Nil(explanations[2].Result)
+
+	everyoneElse := explanations[3]
+	s.Equal("18322080788", everyoneElse.RuleKey)
+	s.Nil(everyoneElse.Conditions)
+	s.Equal(decide.EvaluationResult(true), everyoneElse.Result)
+
+	// the explanations can be handed to tooling as JSON
+	_, err = json.Marshal(explanations)
+	s.NoError(err)
+	s.Len(s.eventProcessor.Events, 0)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestExplainAudiencesInvalidFlag() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	explanations, err := s.OptimizelyClient.ExplainAudiences(user, "invalid_flag")
+	s.Nil(explanations)
+	s.Equal(decide.GetDecideError(decide.FlagKeyInvalid, "invalid_flag"), err)
+}
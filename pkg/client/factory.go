@@ -52,6 +52,8 @@ type OptimizelyFactory struct {
 	tracer               tracing.Tracer
 	overrideStore        decision.ExperimentOverrideStore
 	userProfileService   decision.UserProfileService
+	stickyRolloutFlags   []string
+	cmabService          decision.ExperimentService
 	notificationCenter   notification.Center
 
 	// ODP
@@ -155,8 +157,16 @@ func (f *OptimizelyFactory) Client(clientOptions ...OptionFunc) (*OptimizelyClie
 		if f.overrideStore != nil {
 			experimentServiceOptions = append(experimentServiceOptions, decision.WithOverrideStore(f.overrideStore))
 		}
+		if f.cmabService != nil {
+			experimentServiceOptions = append(experimentServiceOptions, decision.WithCmabService(f.cmabService))
+		}
 		compositeExperimentService := decision.NewCompositeExperimentService(f.SDKKey, experimentServiceOptions...)
-		compositeService := decision.NewCompositeService(f.SDKKey, decision.WithCompositeExperimentService(compositeExperimentService))
+		compositeServiceOptions := []decision.CSOptionFunc{decision.WithCompositeExperimentService(compositeExperimentService)}
+		if f.userProfileService != nil && len(f.stickyRolloutFlags) > 0 {
+			compositeServiceOptions = append(compositeServiceOptions,
+				decision.WithRolloutServiceOptions(decision.WithStickyRollouts(f.userProfileService, f.stickyRolloutFlags...)))
+		}
+		compositeService := decision.NewCompositeService(f.SDKKey, compositeServiceOptions...)
 		appClient.DecisionService = compositeService
 	}
 
@@ -259,6 +269,22 @@ func WithUserProfileService(userProfileService decision.UserProfileService) Opti
 	}
 }
 
+// WithUserProfileServiceV2 sets a user profile service which reports storage failures on the decision service.
+func WithUserProfileServiceV2(userProfileService decision.UserProfileServiceV2) OptionFunc {
+	return func(f *OptimizelyFactory) {
+		f.userProfileService = decision.UserProfileServiceFromV2(userProfileService)
+	}
+}
+
+// WithStickyRollouts saves and honors the rollout rule decisions of the given flags in the user profile service, so
+// that lowering the traffic of a delivery rule does not turn the flag off for users who already got it. It requires a
+// user profile service and has no effect with a custom decision service.
+func WithStickyRollouts(flagKeys ...string) OptionFunc {
+	return func(f *OptimizelyFactory) {
+		f.stickyRolloutFlags = append(f.stickyRolloutFlags, flagKeys...)
+	}
+}
+
 // WithExperimentOverrides sets the experiment override store on the decision service.
 func WithExperimentOverrides(overrideStore decision.ExperimentOverrideStore) OptionFunc {
 	return func(f *OptimizelyFactory) {
@@ -266,6 +292,14 @@ func WithExperimentOverrides(overrideStore decision.ExperimentOverrideStore) Opt
 	}
 }
 
+// WithCmabService sets the service used to decide CMAB rules on the decision service, e.g. decision.NewCmabService.
+// CMAB rules send user attributes to a prediction endpoint, so users are only bucketed into them with this option.
+func WithCmabService(cmabService decision.ExperimentService) OptionFunc {
+	return func(f *OptimizelyFactory) {
+		f.cmabService = cmabService
+	}
+}
+
 // WithBatchEventProcessor sets event processor on a client.
 func WithBatchEventProcessor(batchSize, queueSize int, flushInterval time.Duration) OptionFunc {
 	return func(f *OptimizelyFactory) {
@@ -402,6 +436,10 @@ func convertDecideOptions(options []decide.OptimizelyDecideOptions) *decide.Opti
 			finalOptions.IncludeReasons = true
 		case decide.ExcludeVariables:
 			finalOptions.ExcludeVariables = true
+		case decide.IgnoreCMABCache:
+			finalOptions.IgnoreCMABCache = true
+		case decide.ResetCMABCache:
+			finalOptions.ResetCMABCache = true
 		}
 	}
 	return &finalOptions
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/decision"
 	"github.com/optimizely/go-sdk/v2/pkg/event"
@@ -307,14 +308,59 @@ func TestClientWithCustomDecisionServiceOptions(t *testing.T) {
 
 	mockUserProfileService := new(MockUserProfileService)
 	mockOverrideStore := new(decision.MapExperimentOverridesStore)
+	cmabService := decision.NewCmabService("1212", decision.WithCmabCacheSize(10))
 	optimizelyClient, err := factory.Client(
 		WithUserProfileService(mockUserProfileService),
 		WithExperimentOverrides(mockOverrideStore),
+		WithCmabService(cmabService),
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, optimizelyClient.DecisionService)
 }
 
+type mapUserProfileService map[string]decision.UserProfile
+
+func (m mapUserProfileService) Lookup(userID string) decision.UserProfile {
+	return m[userID]
+}
+
+func (m mapUserProfileService) Save(userProfile decision.UserProfile) {
+	m[userProfile.ID] = userProfile
+}
+
+func TestClientWithStickyRollouts(t *testing.T) {
+	userProfileService := mapUserProfileService{}
+	decideWithPercentage := func(percentage float64) map[string]OptimizelyDecision {
+		b := builder.New()
+		b.Flag("search").Rule("ramp").Enabled(true).Percentage(percentage)
+		b.Flag("checkout").Rule("ramp_checkout").Enabled(true).Percentage(percentage)
+		configManager, err := b.ConfigManager()
+		assert.NoError(t, err)
+		factory := OptimizelyFactory{}
+		optimizelyClient, err := factory.Client(
+			WithConfigManager(configManager),
+			WithEventDispatcher(&MockDispatcher{}),
+			WithUserProfileService(userProfileService),
+			WithStickyRollouts("search"),
+		)
+		assert.NoError(t, err)
+		defer optimizelyClient.Close()
+		userContext := optimizelyClient.CreateUserContext("user1", nil)
+		return userContext.DecideAll(nil)
+	}
+
+	decisions := decideWithPercentage(100)
+	assert.True(t, decisions["search"].Enabled)
+	assert.True(t, decisions["checkout"].Enabled)
+	assert.Len(t, userProfileService["user1"].ExperimentBucketMap, 1)
+
+	// only the sticky flag stays on for the user once the rules are rolled back
+	decisions = decideWithPercentage(0)
+	assert.True(t, decisions["search"].Enabled)
+	assert.Equal(t, "ramp", decisions["search"].RuleKey)
+	assert.False(t, decisions["checkout"].Enabled)
+}
+
 func TestClientWithEventDispatcher(t *testing.T) {
 	factory := OptimizelyFactory{SDKKey: "1212"}
 
@@ -18,6 +18,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/optimizely/go-sdk/v2/pkg/config"
@@ -190,6 +191,35 @@ func (m *MockUserProfileService) Save(userProfile decision.UserProfile) {
 	m.Called(userProfile)
 }
 
+// MockUserProfileServiceWithV2 is a UserProfileService which also implements UserProfileServiceV2
+type MockUserProfileServiceWithV2 struct {
+	MockUserProfileService
+}
+
+func (m *MockUserProfileServiceWithV2) LookupProfile(ctx context.Context, userID string) (decision.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(decision.UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileServiceWithV2) SaveProfile(ctx context.Context, userProfile decision.UserProfile) error {
+	args := m.Called(ctx, userProfile)
+	return args.Error(0)
+}
+
+type MockUserProfileServiceV2 struct {
+	mock.Mock
+}
+
+func (m *MockUserProfileServiceV2) LookupProfile(ctx context.Context, userID string) (decision.UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(decision.UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileServiceV2) SaveProfile(ctx context.Context, userProfile decision.UserProfile) error {
+	args := m.Called(ctx, userProfile)
+	return args.Error(0)
+}
+
 // Helper methods for creating test entities
 func makeTestExperiment(experimentKey string) entities.Experiment {
 	return entities.Experiment{
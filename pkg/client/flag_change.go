@@ -0,0 +1,199 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/notification"
+)
+
+// OnFlagChange registers a handler which is called when one of the given flags differs between two project config
+// revisions, that is when its rules, holdouts, variations or variables, or the audiences its rules and holdouts
+// target, changed. The handler
+// gets the feature of the old and of the new revision, the old feature is nil for an added flag and the new feature
+// is nil for a removed flag. The handler is called for every changed flag when no flag keys are given.
+func (o *OptimizelyClient) OnFlagChange(flagKeys []string, callback func(oldFeature, newFeature *config.OptimizelyFeature)) (int, error) {
+	subscription := &flagChangeSubscription{
+		configManager: o.ConfigManager,
+		flagKeys:      map[string]bool{},
+		callback:      callback,
+	}
+	for _, key := range flagKeys {
+		subscription.flagKeys[key] = true
+	}
+
+	id, err := o.ConfigManager.OnProjectConfigUpdate(subscription.onProjectConfigUpdate)
+	if err != nil {
+		return 0, err
+	}
+	if projectConfig, err := o.ConfigManager.GetConfig(); err == nil {
+		subscription.init(projectConfig)
+	}
+	return id, nil
+}
+
+// RemoveOnFlagChange removes handler for flag changes with given id
+func (o *OptimizelyClient) RemoveOnFlagChange(id int) error {
+	return o.ConfigManager.RemoveOnProjectConfigUpdate(id)
+}
+
+type flagChangeSubscription struct {
+	configManager config.ProjectConfigManager
+	flagKeys      map[string]bool
+	callback      func(oldFeature, newFeature *config.OptimizelyFeature)
+
+	mutex            sync.Mutex
+	projectConfig    config.ProjectConfig     // the last project config the subscription has seen
+	optimizelyConfig *config.OptimizelyConfig // the optimizely config of projectConfig, nil until a handler needed it
+}
+
+// init sets the project config the first update is compared to, unless an update already came in
+func (s *flagChangeSubscription) init(projectConfig config.ProjectConfig) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.projectConfig == nil {
+		s.projectConfig = projectConfig
+	}
+}
+
+func (s *flagChangeSubscription) onProjectConfigUpdate(update notification.ProjectConfigUpdateNotification) {
+	newConfig, err := s.configManager.GetConfig()
+	if err != nil {
+		return
+	}
+	s.mutex.Lock()
+	oldConfig, oldOptimizelyConfig := s.projectConfig, s.optimizelyConfig
+	s.projectConfig, s.optimizelyConfig = newConfig, nil
+	s.mutex.Unlock()
+
+	// the first project config is no change
+	if oldConfig == nil {
+		return
+	}
+	// the diff of the update is between other configs than the ones the subscription has seen when updates race
+	// with each other
+	diff := update.Diff
+	if diff == nil || diff.OldRevision != oldConfig.GetRevision() || diff.NewRevision != newConfig.GetRevision() {
+		diff = configdiff.Compare(oldConfig, newConfig)
+	}
+	flagKeys := s.changedFlagKeys(diff, oldConfig, newConfig)
+	if len(flagKeys) == 0 {
+		return
+	}
+
+	if oldOptimizelyConfig == nil {
+		oldOptimizelyConfig = config.NewOptimizelyConfig(oldConfig)
+	}
+	newOptimizelyConfig := s.getOptimizelyConfig(newConfig)
+	s.mutex.Lock()
+	if s.projectConfig != nil && s.projectConfig.GetRevision() == newConfig.GetRevision() {
+		s.optimizelyConfig = newOptimizelyConfig
+	}
+	s.mutex.Unlock()
+
+	for _, key := range flagKeys {
+		s.callback(featureByKey(oldOptimizelyConfig.FeaturesMap, key), featureByKey(newOptimizelyConfig.FeaturesMap, key))
+	}
+}
+
+// getOptimizelyConfig returns the optimizely config of the project config, the one of the config manager is shared by
+// all subscriptions
+func (s *flagChangeSubscription) getOptimizelyConfig(projectConfig config.ProjectConfig) *config.OptimizelyConfig {
+	if optimizelyConfig := s.configManager.GetOptimizelyConfig(); optimizelyConfig != nil && optimizelyConfig.Revision == projectConfig.GetRevision() {
+		return optimizelyConfig
+	}
+	return config.NewOptimizelyConfig(projectConfig)
+}
+
+// changedFlagKeys returns the sorted keys of the subscribed flags which changed, or whose rules or holdouts target a
+// changed audience in either config
+func (s *flagChangeSubscription) changedFlagKeys(diff *configdiff.Diff, oldConfig, newConfig config.ProjectConfig) []string {
+	changed := map[string]bool{}
+	for _, key := range diff.FlagKeys() {
+		changed[key] = true
+	}
+	audienceIDs := map[string]bool{}
+	for _, change := range diff.Changes {
+		if change.Entity == configdiff.Audience {
+			audienceIDs[change.Key] = true
+		}
+	}
+	if len(audienceIDs) > 0 {
+		for _, projectConfig := range []config.ProjectConfig{oldConfig, newConfig} {
+			for _, feature := range projectConfig.GetFeatureList() {
+				if !changed[feature.Key] && targetsAudience(feature, audienceIDs) {
+					changed[feature.Key] = true
+				}
+			}
+		}
+	}
+
+	keys := []string{}
+	for key := range changed {
+		if len(s.flagKeys) == 0 || s.flagKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// targetsAudience returns true if a rule or a holdout of the feature targets one of the audiences
+func targetsAudience(feature entities.Feature, audienceIDs map[string]bool) bool {
+	rules := append(append([]entities.Experiment{}, feature.FeatureExperiments...), feature.Rollout.Experiments...)
+	for _, holdout := range feature.Holdouts {
+		rules = append(rules, holdout.ToExperiment())
+	}
+	for _, rule := range rules {
+		for _, id := range rule.AudienceIds {
+			if audienceIDs[id] {
+				return true
+			}
+		}
+		if treeTargetsAudience(rule.AudienceConditionTree, audienceIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+func treeTargetsAudience(node *entities.TreeNode, audienceIDs map[string]bool) bool {
+	if node == nil {
+		return false
+	}
+	if id, ok := node.Item.(string); ok && audienceIDs[id] {
+		return true
+	}
+	for _, child := range node.Nodes {
+		if treeTargetsAudience(child, audienceIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+func featureByKey(features map[string]config.OptimizelyFeature, key string) *config.OptimizelyFeature {
+	if feature, ok := features[key]; ok {
+		return &feature
+	}
+	return nil
+}
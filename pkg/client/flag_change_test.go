@@ -0,0 +1,265 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/notification"
+)
+
+type flagChange struct {
+	oldFeature, newFeature *config.OptimizelyFeature
+}
+
+type FlagChangeTestSuite struct {
+	suite.Suite
+	datafile      *datafileEntities.Datafile
+	requester     *datafileRequester
+	configManager *config.PollingProjectConfigManager
+	client        *OptimizelyClient
+}
+
+func (s *FlagChangeTestSuite) SetupTest() {
+	b := builder.New().Revision("1")
+	b.Audience("adults", builder.Match("age", "ge", 18))
+	checkout := b.Flag("checkout").Variable("limit", entities.Integer, 10)
+	checkout.Experiment("checkout_test").Audiences("adults").Variation("blue", true, nil)
+	checkout.EveryoneElse().Enabled(true)
+	b.Flag("search").EveryoneElse().Enabled(true)
+
+	var err error
+	s.datafile, err = b.Datafile()
+	s.Require().NoError(err)
+	s.requester = &datafileRequester{}
+	s.sync("1")
+
+	s.configManager = config.NewPollingProjectConfigManager("flag_change_sdk_key_"+s.T().Name(), config.WithRequester(s.requester))
+	s.client = &OptimizelyClient{
+		ConfigManager: s.configManager,
+		logger:        logging.GetLogger("", ""),
+		tracer:        &MockTracer{},
+	}
+}
+
+// sync serves the datafile with the given revision and syncs the config manager with it
+func (s *FlagChangeTestSuite) sync(revision string) {
+	s.datafile.Revision = revision
+	jsonDatafile, err := json.Marshal(s.datafile)
+	s.Require().NoError(err)
+	s.requester.datafile = jsonDatafile
+	if s.configManager != nil {
+		s.configManager.SyncConfig()
+	}
+}
+
+func (s *FlagChangeTestSuite) subscribe(flagKeys ...string) (int, *[]flagChange) {
+	changes := &[]flagChange{}
+	id, err := s.client.OnFlagChange(flagKeys, func(oldFeature, newFeature *config.OptimizelyFeature) {
+		*changes = append(*changes, flagChange{oldFeature, newFeature})
+	})
+	s.Require().NoError(err)
+	return id, changes
+}
+
+func (s *FlagChangeTestSuite) TestChangedVariable() {
+	_, checkoutChanges := s.subscribe("checkout")
+	_, searchChanges := s.subscribe("search")
+	_, allChanges := s.subscribe()
+
+	s.datafile.FeatureFlags[0].Variables[0].DefaultValue = "15"
+	s.sync("2")
+
+	s.Require().Len(*checkoutChanges, 1)
+	change := (*checkoutChanges)[0]
+	s.Equal("10", change.oldFeature.VariablesMap["limit"].Value)
+	s.Equal("15", change.newFeature.VariablesMap["limit"].Value)
+	s.Empty(*searchChanges)
+	s.Equal(*checkoutChanges, *allChanges)
+}
+
+func (s *FlagChangeTestSuite) TestNewRevisionWithoutChanges() {
+	_, allChanges := s.subscribe()
+	s.sync("2")
+	s.Empty(*allChanges)
+}
+
+func (s *FlagChangeTestSuite) TestChangedAudience() {
+	_, checkoutChanges := s.subscribe("checkout")
+	_, searchChanges := s.subscribe("search")
+
+	// the feature is the same, but the users its experiment targets are not
+	s.datafile.TypedAudiences[0].Conditions = []interface{}{"and", builder.Match("age", "ge", 21)}
+	s.sync("2")
+
+	s.Require().Len(*checkoutChanges, 1)
+	s.Equal((*checkoutChanges)[0].oldFeature, (*checkoutChanges)[0].newFeature)
+	s.Empty(*searchChanges)
+}
+
+// addHoldout adds a running holdout for the search flag which targets the adults audience
+func (s *FlagChangeTestSuite) addHoldout() *datafileEntities.Holdout {
+	searchID := ""
+	for _, flag := range s.datafile.FeatureFlags {
+		if flag.Key == "search" {
+			searchID = flag.ID
+		}
+	}
+	s.datafile.Holdouts = append(s.datafile.Holdouts, datafileEntities.Holdout{
+		ID:                "9001",
+		Key:               "search_holdout",
+		Status:            string(entities.HoldoutStatusRunning),
+		Variations:        []datafileEntities.Variation{{ID: "9002", Key: "off"}},
+		TrafficAllocation: []datafileEntities.TrafficAllocation{{EntityID: "9002", EndOfRange: 500}},
+		AudienceIds:       []string{s.datafile.TypedAudiences[0].ID},
+		IncludedFlags:     []string{searchID},
+	})
+	return &s.datafile.Holdouts[len(s.datafile.Holdouts)-1]
+}
+
+func (s *FlagChangeTestSuite) TestChangedHoldout() {
+	holdout := s.addHoldout()
+	s.sync("2")
+	_, checkoutChanges := s.subscribe("checkout")
+	_, searchChanges := s.subscribe("search")
+
+	holdout.TrafficAllocation[0].EndOfRange = 1000
+	s.sync("3")
+
+	s.Empty(*checkoutChanges)
+	s.Require().Len(*searchChanges, 1)
+	s.Equal("search", (*searchChanges)[0].newFeature.Key)
+}
+
+func (s *FlagChangeTestSuite) TestChangedHoldoutAudience() {
+	// the experiment of checkout and the holdout of search target the audience
+	s.addHoldout()
+	s.sync("2")
+	_, allChanges := s.subscribe()
+
+	s.datafile.TypedAudiences[0].Conditions = []interface{}{"and", builder.Match("age", "ge", 21)}
+	s.sync("3")
+
+	s.Require().Len(*allChanges, 2)
+	s.Equal("checkout", (*allChanges)[0].newFeature.Key)
+	s.Equal("search", (*allChanges)[1].newFeature.Key)
+}
+
+func (s *FlagChangeTestSuite) TestAddedAndRemovedFlags() {
+	_, searchChanges := s.subscribe("search")
+	featureFlags, rollouts := s.datafile.FeatureFlags, s.datafile.Rollouts
+
+	s.datafile.FeatureFlags, s.datafile.Rollouts = featureFlags[:1], rollouts[:1]
+	s.sync("2")
+	s.Require().Len(*searchChanges, 1)
+	s.Equal("search", (*searchChanges)[0].oldFeature.Key)
+	s.Nil((*searchChanges)[0].newFeature)
+
+	s.datafile.FeatureFlags, s.datafile.Rollouts = featureFlags, rollouts
+	s.sync("3")
+	s.Require().Len(*searchChanges, 2)
+	s.Nil((*searchChanges)[1].oldFeature)
+	s.Equal("search", (*searchChanges)[1].newFeature.Key)
+}
+
+func (s *FlagChangeTestSuite) TestComparesTheConfigsItHasSeen() {
+	changes := []flagChange{}
+	oldConfig, err := s.configManager.GetConfig()
+	s.Require().NoError(err)
+	subscription := &flagChangeSubscription{
+		configManager: s.configManager,
+		flagKeys:      map[string]bool{},
+		callback: func(oldFeature, newFeature *config.OptimizelyFeature) {
+			changes = append(changes, flagChange{oldFeature, newFeature})
+		},
+		projectConfig: oldConfig,
+	}
+
+	// the subscription gets the update with the diff from revision 2 after the config changed twice
+	s.datafile.FeatureFlags[0].Variables[0].DefaultValue = "15"
+	s.sync("2")
+	s.sync("3")
+	subscription.onProjectConfigUpdate(notification.ProjectConfigUpdateNotification{
+		Type:     notification.ProjectConfigUpdate,
+		Revision: "3",
+		Diff:     &configdiff.Diff{OldRevision: "2", NewRevision: "3", Changes: []configdiff.Change{}},
+	})
+
+	s.Require().Len(changes, 1)
+	s.Equal("10", changes[0].oldFeature.VariablesMap["limit"].Value)
+	s.Equal("15", changes[0].newFeature.VariablesMap["limit"].Value)
+}
+
+func (s *FlagChangeTestSuite) TestUsesTheDiffOfTheUpdate() {
+	changes := []flagChange{}
+	oldConfig, err := s.configManager.GetConfig()
+	s.Require().NoError(err)
+	subscription := &flagChangeSubscription{
+		configManager: s.configManager,
+		flagKeys:      map[string]bool{},
+		callback: func(oldFeature, newFeature *config.OptimizelyFeature) {
+			changes = append(changes, flagChange{oldFeature, newFeature})
+		},
+		projectConfig: oldConfig,
+	}
+
+	// the configs are the same, the flag is only in the diff of the update
+	s.sync("2")
+	subscription.onProjectConfigUpdate(notification.ProjectConfigUpdateNotification{
+		Type:     notification.ProjectConfigUpdate,
+		Revision: "2",
+		Diff: &configdiff.Diff{OldRevision: "1", NewRevision: "2", Changes: []configdiff.Change{
+			{Type: configdiff.Changed, Entity: configdiff.Flag, FlagKey: "search"},
+		}},
+	})
+
+	s.Require().Len(changes, 1)
+	s.Equal("search", changes[0].newFeature.Key)
+	// the handler gets the optimizely config the config manager built
+	s.Equal(s.configManager.GetOptimizelyConfig().FeaturesMap["search"], *changes[0].newFeature)
+}
+
+func (s *FlagChangeTestSuite) TestRemoveOnFlagChange() {
+	id, checkoutChanges := s.subscribe("checkout")
+	s.NoError(s.client.RemoveOnFlagChange(id))
+
+	s.datafile.FeatureFlags[0].Variables[0].DefaultValue = "15"
+	s.sync("2")
+	s.Empty(*checkoutChanges)
+}
+
+func (s *FlagChangeTestSuite) TestStaticConfigManager() {
+	// a static project config never changes
+	configManager, err := config.NewStaticProjectConfigManagerFromPayload(s.requester.datafile, logging.GetLogger("", ""))
+	s.Require().NoError(err)
+	s.client.ConfigManager = configManager
+	_, err = s.client.OnFlagChange(nil, func(oldFeature, newFeature *config.OptimizelyFeature) {})
+	s.Error(err)
+}
+
+func TestFlagChangeTestSuite(t *testing.T) {
+	suite.Run(t, new(FlagChangeTestSuite))
+}
@@ -18,6 +18,11 @@
 package client
 
 import (
+	"reflect"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/optimizelyjson"
 )
 
@@ -30,6 +35,13 @@ type OptimizelyDecision struct {
 	FlagKey      string                         `json:"flagKey"`
 	UserContext  OptimizelyUserContext          `json:"userContext"`
 	Reasons      []string                       `json:"reasons"`
+	// StructuredReasons are the machine-readable counterparts of Reasons, they include details such as
+	// the result of every audience evaluated and the reason code of the final decision
+	StructuredReasons []decide.StructuredReason `json:"structuredReasons,omitempty"`
+	// Revision is the revision of the project config the decision was made with
+	Revision string `json:"revision"`
+
+	variableTypes map[string]entities.VariableType
 }
 
 // NewOptimizelyDecision creates and returns a new instance of OptimizelyDecision
@@ -52,5 +64,58 @@ func NewErrorDecision(key string, user OptimizelyUserContext, err error) Optimiz
 		UserContext: user,
 		Variables:   optimizelyjson.NewOptimizelyJSONfromMap(map[string]interface{}{}),
 		Reasons:     []string{err.Error()},
+		StructuredReasons: []decide.StructuredReason{
+			{Code: pkgReasons.Error, Message: err.Error()},
+		},
+	}
+}
+
+// GetVariable returns the decision variable converted to T. It returns an *optimizelyjson.ValueError when the
+// variable is missing, or when T does not match the variable type defined in the datafile.
+func GetVariable[T any](decision OptimizelyDecision, key string) (T, error) {
+	var zero T
+	if decision.Variables == nil {
+		return zero, &optimizelyjson.ValueError{Path: key, Err: optimizelyjson.ErrValueNotFound}
+	}
+	value, ok := decision.Variables.ToMap()[key]
+	if !ok {
+		return zero, &optimizelyjson.ValueError{Path: key, Err: optimizelyjson.ErrValueNotFound}
+	}
+	if variableType, ok := decision.variableTypes[key]; ok && !matchesVariableType[T](variableType) {
+		return zero, &optimizelyjson.ValueError{
+			Path: key,
+			Want: optimizelyjson.TypeName[T](),
+			Got:  string(variableType) + " variable",
+			Err:  optimizelyjson.ErrTypeMismatch,
+		}
+	}
+	return optimizelyjson.Convert[T](key, value)
+}
+
+// GetVariableOr returns the decision variable converted to T, or fallback if it is missing or has a different type
+func GetVariableOr[T any](decision OptimizelyDecision, key string, fallback T) T {
+	if value, err := GetVariable[T](decision, key); err == nil {
+		return value
+	}
+	return fallback
+}
+
+func matchesVariableType[T any](variableType entities.VariableType) bool {
+	kind := reflect.TypeOf((*T)(nil)).Elem().Kind()
+	if kind == reflect.Interface {
+		return true
+	}
+	switch variableType {
+	case entities.Boolean:
+		return kind == reflect.Bool
+	case entities.Integer:
+		return kind >= reflect.Int && kind <= reflect.Int64
+	case entities.Double:
+		return kind == reflect.Float32 || kind == reflect.Float64
+	case entities.String:
+		return kind == reflect.String
+	case entities.JSON:
+		return kind == reflect.Map || kind == reflect.Struct || kind == reflect.Slice || kind == reflect.Ptr
 	}
+	return true
 }
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/optimizelyjson"
 
 	"github.com/stretchr/testify/suite"
@@ -75,6 +76,84 @@ func (s *OptimizelyDecisionTestSuite) TestNewErrorDecision() {
 	s.Equal(errorString, decision.Reasons[0])
 }
 
+func (s *OptimizelyDecisionTestSuite) TestGetVariable() {
+	variables := optimizelyjson.NewOptimizelyJSONfromMap(map[string]interface{}{
+		"b": true,
+		"i": 10,
+		"d": 1.5,
+		"s": "text",
+		"j": map[string]interface{}{"k1": "v1"},
+	})
+	decision := NewOptimizelyDecision("var1", "rule1", "flag1", true, variables, OptimizelyUserContext{}, nil)
+	decision.variableTypes = map[string]entities.VariableType{
+		"b": entities.Boolean,
+		"i": entities.Integer,
+		"d": entities.Double,
+		"s": entities.String,
+		"j": entities.JSON,
+	}
+
+	b, err := GetVariable[bool](decision, "b")
+	s.NoError(err)
+	s.True(b)
+
+	i, err := GetVariable[int](decision, "i")
+	s.NoError(err)
+	s.Equal(10, i)
+
+	d, err := GetVariable[float64](decision, "d")
+	s.NoError(err)
+	s.Equal(1.5, d)
+
+	str, err := GetVariable[string](decision, "s")
+	s.NoError(err)
+	s.Equal("text", str)
+
+	type jsonVariable struct {
+		K1 string `json:"k1"`
+	}
+	j, err := GetVariable[jsonVariable](decision, "j")
+	s.NoError(err)
+	s.Equal(jsonVariable{K1: "v1"}, j)
+
+	raw, err := GetVariable[interface{}](decision, "i")
+	s.NoError(err)
+	s.Equal(10, raw)
+}
+
+func (s *OptimizelyDecisionTestSuite) TestGetVariableErrors() {
+	variables := optimizelyjson.NewOptimizelyJSONfromMap(map[string]interface{}{"i": 10, "d": 2.0})
+	decision := NewOptimizelyDecision("var1", "rule1", "flag1", true, variables, OptimizelyUserContext{}, nil)
+	decision.variableTypes = map[string]entities.VariableType{"i": entities.Integer, "d": entities.Double}
+
+	_, err := GetVariable[string](decision, "missing")
+	s.ErrorIs(err, optimizelyjson.ErrValueNotFound)
+
+	// a whole double is still rejected for an int since the datafile declares it as double
+	_, err = GetVariable[int](decision, "d")
+	s.ErrorIs(err, optimizelyjson.ErrTypeMismatch)
+	s.EqualError(err, `value for "d" is double variable, expected int`)
+
+	_, err = GetVariable[bool](decision, "i")
+	s.ErrorIs(err, optimizelyjson.ErrTypeMismatch)
+
+	_, err = GetVariable[int](NewErrorDecision("flag1", OptimizelyUserContext{}, errors.New("error")), "i")
+	s.ErrorIs(err, optimizelyjson.ErrValueNotFound)
+
+	_, err = GetVariable[int](OptimizelyDecision{}, "i")
+	s.ErrorIs(err, optimizelyjson.ErrValueNotFound)
+}
+
+func (s *OptimizelyDecisionTestSuite) TestGetVariableOr() {
+	variables := optimizelyjson.NewOptimizelyJSONfromMap(map[string]interface{}{"i": 10})
+	decision := NewOptimizelyDecision("var1", "rule1", "flag1", true, variables, OptimizelyUserContext{}, nil)
+	decision.variableTypes = map[string]entities.VariableType{"i": entities.Integer}
+
+	s.Equal(10, GetVariableOr(decision, "i", 5))
+	s.Equal(5, GetVariableOr(decision, "missing", 5))
+	s.Equal("fallback", GetVariableOr(decision, "i", "fallback"))
+}
+
 func TestOptimizelyDecisionTestSuite(t *testing.T) {
 	suite.Run(t, new(OptimizelyDecisionTestSuite))
 }
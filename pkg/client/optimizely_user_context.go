@@ -18,9 +18,11 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"sync"
 
+	"github.com/optimizely/go-sdk/v2/pkg/config"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	pkgDecision "github.com/optimizely/go-sdk/v2/pkg/decision"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
@@ -35,9 +37,31 @@ type OptimizelyUserContext struct {
 	qualifiedSegments     []string
 	optimizely            *OptimizelyClient
 	forcedDecisionService *pkgDecision.ForcedDecisionService
+	configPin             *configPin
 	mutex                 *sync.RWMutex
 }
 
+// configPin holds the project config a user context is pinned to, it is shared by the copies of the context
+type configPin struct {
+	mutex         sync.Mutex
+	projectConfig config.ProjectConfig
+}
+
+// get returns the pinned config, pinning the one returned by fetch if none is pinned yet
+func (p *configPin) get(fetch func() (config.ProjectConfig, error)) (config.ProjectConfig, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.projectConfig != nil {
+		return p.projectConfig, nil
+	}
+	projectConfig, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	p.projectConfig = projectConfig
+	return projectConfig, nil
+}
+
 // returns an instance of the optimizely user context.
 func newOptimizelyUserContext(optimizely *OptimizelyClient, userID string, attributes map[string]interface{}, forcedDecisionService *pkgDecision.ForcedDecisionService, qualifiedSegments []string) OptimizelyUserContext {
 	// store a copy of the provided attributes so it isn't affected by changes made afterwards.
@@ -87,6 +111,48 @@ func (o OptimizelyUserContext) getForcedDecisionService() *pkgDecision.ForcedDec
 	return nil
 }
 
+// defaultContext returns the context used by the calls that do not take a context
+func (o OptimizelyUserContext) defaultContext() context.Context {
+	if o.optimizely == nil {
+		return context.Background()
+	}
+	return o.optimizely.defaultContext()
+}
+
+// WithPinnedConfig returns a copy of the user context which is pinned to the project config revision it first sees.
+// All Decide and TrackEvent calls made with the returned context use that revision, even if the datafile is updated
+// in between. The config is pinned right away when the SDK is ready, otherwise by the first call which gets one.
+func (o OptimizelyUserContext) WithPinnedConfig() OptimizelyUserContext {
+	if o.configPin == nil {
+		o.configPin = &configPin{}
+	}
+	if o.optimizely != nil {
+		_, _ = o.configPin.get(o.optimizely.getProjectConfig)
+	}
+	return o
+}
+
+// GetPinnedRevision returns the revision of the pinned project config, or an empty string if none is pinned
+func (o OptimizelyUserContext) GetPinnedRevision() string {
+	if o.configPin == nil {
+		return ""
+	}
+	o.configPin.mutex.Lock()
+	defer o.configPin.mutex.Unlock()
+	if o.configPin.projectConfig == nil {
+		return ""
+	}
+	return o.configPin.projectConfig.GetRevision()
+}
+
+// copyForDecision returns a copy of the user context so that changes made to it afterwards are not reflected
+// inside a decision, the copy keeps the pinned config
+func (o *OptimizelyUserContext) copyForDecision() OptimizelyUserContext {
+	userContextCopy := newOptimizelyUserContext(o.GetOptimizely(), o.GetUserID(), o.GetUserAttributes(), o.getForcedDecisionService(), o.GetQualifiedSegments())
+	userContextCopy.configPin = o.configPin
+	return userContextCopy
+}
+
 // SetAttribute sets an attribute for a given key.
 func (o *OptimizelyUserContext) SetAttribute(key string, value interface{}) {
 	o.mutex.Lock()
@@ -99,7 +165,13 @@ func (o *OptimizelyUserContext) SetAttribute(key string, value interface{}) {
 
 // FetchQualifiedSegments fetches all qualified segments for the user context.
 func (o *OptimizelyUserContext) FetchQualifiedSegments(options []pkgOdpSegment.OptimizelySegmentOption) (success bool) {
-	o.optimizely.fetchQualifiedSegments(o, options, func(result bool) {
+	return o.FetchQualifiedSegmentsWithContext(o.defaultContext(), options)
+}
+
+// FetchQualifiedSegmentsWithContext fetches all qualified segments for the user context.
+// The fetch fails if ctx is done before the segments are received.
+func (o *OptimizelyUserContext) FetchQualifiedSegmentsWithContext(ctx context.Context, options []pkgOdpSegment.OptimizelySegmentOption) (success bool) {
+	o.optimizely.fetchQualifiedSegments(ctx, o, options, func(result bool) {
 		success = result
 	})
 	return
@@ -107,7 +179,7 @@ func (o *OptimizelyUserContext) FetchQualifiedSegments(options []pkgOdpSegment.O
 
 // FetchQualifiedSegmentsAsync fetches all qualified segments aysnchronously for the user context.
 func (o *OptimizelyUserContext) FetchQualifiedSegmentsAsync(options []pkgOdpSegment.OptimizelySegmentOption, callback func(success bool)) {
-	go o.optimizely.fetchQualifiedSegments(o, options, callback)
+	go o.optimizely.fetchQualifiedSegments(o.defaultContext(), o, options, callback)
 }
 
 // SetQualifiedSegments clears and adds qualified segments for Optimizely user context
@@ -128,33 +200,54 @@ func (o *OptimizelyUserContext) IsQualifiedFor(segment string) bool {
 // Decide returns a decision result for a given flag key and a user context, which contains
 // all data required to deliver the flag or experiment.
 func (o *OptimizelyUserContext) Decide(key string, options []decide.OptimizelyDecideOptions) OptimizelyDecision {
-	// use a copy of the user context so that any changes to the original context are not reflected inside the decision
-	userContextCopy := newOptimizelyUserContext(o.GetOptimizely(), o.GetUserID(), o.GetUserAttributes(), o.getForcedDecisionService(), o.GetQualifiedSegments())
-	return o.optimizely.decide(userContextCopy, key, convertDecideOptions(options))
+	return o.DecideWithContext(o.defaultContext(), key, options)
+}
+
+// DecideWithContext is like Decide, but ctx is propagated to tracing spans, user profile lookups and
+// the decision notification. An error decision is returned if ctx is done before the decision is made.
+func (o *OptimizelyUserContext) DecideWithContext(ctx context.Context, key string, options []decide.OptimizelyDecideOptions) OptimizelyDecision {
+	userContextCopy := o.copyForDecision()
+	return o.optimizely.decide(ctx, userContextCopy, key, convertDecideOptions(options), nil)
 }
 
 // DecideAll returns a key-map of decision results for all active flag keys with options.
 func (o *OptimizelyUserContext) DecideAll(options []decide.OptimizelyDecideOptions) map[string]OptimizelyDecision {
-	// use a copy of the user context so that any changes to the original context are not reflected inside the decision
-	userContextCopy := newOptimizelyUserContext(o.GetOptimizely(), o.GetUserID(), o.GetUserAttributes(), o.getForcedDecisionService(), o.GetQualifiedSegments())
-	return o.optimizely.decideAll(userContextCopy, convertDecideOptions(options))
+	return o.DecideAllWithContext(o.defaultContext(), options)
+}
+
+// DecideAllWithContext is like DecideAll, but honors the deadline and cancellation of ctx.
+// Flags which are not decided before ctx is done get an error decision.
+func (o *OptimizelyUserContext) DecideAllWithContext(ctx context.Context, options []decide.OptimizelyDecideOptions) map[string]OptimizelyDecision {
+	userContextCopy := o.copyForDecision()
+	return o.optimizely.decideAll(ctx, userContextCopy, convertDecideOptions(options))
 }
 
 // DecideForKeys returns a key-map of decision results for multiple flag keys and options.
 func (o *OptimizelyUserContext) DecideForKeys(keys []string, options []decide.OptimizelyDecideOptions) map[string]OptimizelyDecision {
-	// use a copy of the user context so that any changes to the original context are not reflected inside the decision
-	userContextCopy := newOptimizelyUserContext(o.GetOptimizely(), o.GetUserID(), o.GetUserAttributes(), o.getForcedDecisionService(), o.GetQualifiedSegments())
-	return o.optimizely.decideForKeys(userContextCopy, keys, convertDecideOptions(options))
+	return o.DecideForKeysWithContext(o.defaultContext(), keys, options)
+}
+
+// DecideForKeysWithContext is like DecideForKeys, but honors the deadline and cancellation of ctx.
+// Flags which are not decided before ctx is done get an error decision.
+func (o *OptimizelyUserContext) DecideForKeysWithContext(ctx context.Context, keys []string, options []decide.OptimizelyDecideOptions) map[string]OptimizelyDecision {
+	userContextCopy := o.copyForDecision()
+	return o.optimizely.decideForKeys(ctx, userContextCopy, keys, convertDecideOptions(options))
 }
 
 // TrackEvent generates a conversion event with the given event key if it exists and queues it up to be sent to the Optimizely
 // log endpoint for results processing.
 func (o *OptimizelyUserContext) TrackEvent(eventKey string, eventTags map[string]interface{}) (err error) {
+	return o.TrackEventWithContext(o.defaultContext(), eventKey, eventTags)
+}
+
+// TrackEventWithContext is like TrackEvent, but ctx is propagated to tracing spans and the track notification.
+// No event is queued and the context's error is returned if ctx is already done.
+func (o *OptimizelyUserContext) TrackEventWithContext(ctx context.Context, eventKey string, eventTags map[string]interface{}) (err error) {
 	userContext := entities.UserContext{
 		ID:         o.GetUserID(),
 		Attributes: o.GetUserAttributes(),
 	}
-	return o.optimizely.Track(eventKey, userContext, eventTags)
+	return o.optimizely.track(ctx, o.configPin, eventKey, userContext, eventTags)
 }
 
 // SetForcedDecision sets the forced decision (variation key) for a given decision context (flag key and optional rule key).
@@ -174,6 +267,37 @@ func (o *OptimizelyUserContext) GetForcedDecision(ctx pkgDecision.OptimizelyDeci
 	return o.forcedDecisionService.GetForcedDecision(ctx)
 }
 
+// GetForcedDecisions returns all the forced decisions bound to this user context
+func (o *OptimizelyUserContext) GetForcedDecisions() map[pkgDecision.OptimizelyDecisionContext]pkgDecision.OptimizelyForcedDecision {
+	if o.forcedDecisionService == nil {
+		return map[pkgDecision.OptimizelyDecisionContext]pkgDecision.OptimizelyForcedDecision{}
+	}
+	return o.forcedDecisionService.GetForcedDecisions()
+}
+
+// ExportForcedDecisions returns the forced decisions of the user context as a JSON array of mappings, e.g. to share
+// them with QA as a file
+func (o *OptimizelyUserContext) ExportForcedDecisions() ([]byte, error) {
+	if o.forcedDecisionService == nil {
+		return pkgDecision.NewForcedDecisionService(o.GetUserID()).Export()
+	}
+	return o.forcedDecisionService.Export()
+}
+
+// ImportForcedDecisions sets the forced decisions of the mappings which are valid for the project config and returns
+// the invalid ones. The mappings can be read from an exported JSON file or parsed from a query string with
+// decision.ParseForcedDecisions.
+func (o *OptimizelyUserContext) ImportForcedDecisions(mappings []pkgDecision.ForcedDecisionMapping) []pkgDecision.InvalidForcedDecision {
+	var projectConfig config.ProjectConfig
+	if o.optimizely != nil {
+		projectConfig, _ = o.optimizely.getPinnedProjectConfig(o.configPin)
+	}
+	if o.forcedDecisionService == nil {
+		o.forcedDecisionService = pkgDecision.NewForcedDecisionService(o.GetUserID())
+	}
+	return o.forcedDecisionService.Import(projectConfig, mappings)
+}
+
 // RemoveForcedDecision removes the forced decision for a given flag and an optional rule.
 func (o *OptimizelyUserContext) RemoveForcedDecision(ctx pkgDecision.OptimizelyDecisionContext) bool {
 	if o.forcedDecisionService == nil {
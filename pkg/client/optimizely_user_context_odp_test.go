@@ -17,11 +17,13 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -136,6 +138,38 @@ func (o *OptimizelyUserContextODPTestSuite) TestFetchQualifiedSegmentsSuccessDef
 	segmentManager.AssertExpectations(o.T())
 }
 
+func (o *OptimizelyUserContextODPTestSuite) TestFetchQualifiedSegmentsWithContext() {
+	segmentManager := &MockSegmentManager{}
+	segmentManager.On("Reset")
+	segmentManager.On("FetchQualifiedSegments", o.apiKey, o.apiHost, o.userID, o.qualifiedSegments, mock.Anything).Return([]string{"odp-segment-1"}, nil)
+	odpManager := odp.NewOdpManager("", false, odp.WithSegmentManager(segmentManager))
+	factory := OptimizelyFactory{Datafile: o.datafile, odpManager: odpManager}
+	optimizelyClient, _ := factory.Client()
+	userContext := optimizelyClient.CreateUserContext(o.userID, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	o.True(userContext.FetchQualifiedSegmentsWithContext(ctx, nil))
+	o.Equal([]string{"odp-segment-1"}, userContext.GetQualifiedSegments())
+	segmentManager.AssertExpectations(o.T())
+}
+
+func (o *OptimizelyUserContextODPTestSuite) TestFetchQualifiedSegmentsWithContextDeadlineExceeded() {
+	segmentManager := &MockSegmentManager{}
+	segmentManager.On("Reset")
+	segmentManager.On("FetchQualifiedSegments", o.apiKey, o.apiHost, o.userID, o.qualifiedSegments, mock.Anything).Return([]string{"odp-segment-1"}, nil).After(500 * time.Millisecond)
+	odpManager := odp.NewOdpManager("", false, odp.WithSegmentManager(segmentManager))
+	factory := OptimizelyFactory{Datafile: o.datafile, odpManager: odpManager}
+	optimizelyClient, _ := factory.Client()
+	userContext := optimizelyClient.CreateUserContext(o.userID, nil)
+	userContext.SetQualifiedSegments([]string{"stale-segment"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	o.False(userContext.FetchQualifiedSegmentsWithContext(ctx, nil))
+	o.Nil(userContext.GetQualifiedSegments())
+}
+
 func (o *OptimizelyUserContextODPTestSuite) TestFetchQualifiedSegmentsSDKNotReady() {
 	factory := OptimizelyFactory{SDKKey: "121"}
 	client, _ := factory.Client()
@@ -17,18 +17,25 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/event"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
 	"github.com/optimizely/go-sdk/v2/pkg/optimizelyjson"
 )
@@ -270,6 +277,19 @@ func (s *OptimizelyUserContextTestSuite) TestDecideResponseContainsUserContextCo
 	s.Len(decisionUserContext.qualifiedSegments, 0)
 }
 
+func (s *OptimizelyUserContextTestSuite) TestDecideTypedVariables() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decision := user.Decide("feature_2", nil)
+
+	value, err := GetVariable[int](decision, "i_42")
+	s.NoError(err)
+	s.Equal(42, value)
+
+	_, err = GetVariable[float64](decision, "i_42")
+	s.ErrorIs(err, optimizelyjson.ErrTypeMismatch)
+	s.Equal(4.2, GetVariableOr(decision, "i_42", 4.2))
+}
+
 func (s *OptimizelyUserContextTestSuite) TestDecideFeatureTest() {
 	flagKey := "feature_2"
 	ruleKey := "exp_no_audience"
@@ -303,6 +323,122 @@ func (s *OptimizelyUserContextTestSuite) TestDecideFeatureTest() {
 	s.Equal("10418551353", impressionEvent.VariationID)
 }
 
+func (s *OptimizelyUserContextTestSuite) TestWithPinnedConfig() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	pinnedUser := s.OptimizelyClient.CreateUserContext(s.userID, nil).WithPinnedConfig()
+	s.Equal("", user.GetPinnedRevision())
+	s.Equal("241", pinnedUser.GetPinnedRevision())
+
+	// the datafile is updated to a revision without any flags or events
+	var rawDatafile map[string]interface{}
+	s.NoError(json.Unmarshal(datafile, &rawDatafile))
+	rawDatafile["revision"] = "242"
+	rawDatafile["featureFlags"] = []interface{}{}
+	rawDatafile["events"] = []interface{}{}
+	updatedDatafile, err := json.Marshal(rawDatafile)
+	s.NoError(err)
+	updatedConfig, err := datafileprojectconfig.NewDatafileProjectConfig(updatedDatafile, logging.GetLogger("", "DatafileProjectConfig"))
+	s.NoError(err)
+	s.OptimizelyClient.ConfigManager = &MockConfigManager{projectConfig: updatedConfig}
+
+	decision := pinnedUser.Decide("feature_2", nil)
+	s.Equal("variation_with_traffic", decision.VariationKey)
+	s.Equal("241", decision.Revision)
+	s.Equal("241", decision.UserContext.GetPinnedRevision())
+	s.Len(pinnedUser.DecideAll(nil), 3)
+	s.Len(pinnedUser.DecideForKeys([]string{"feature_1", "feature_2"}, nil), 2)
+
+	decision = user.Decide("feature_2", nil)
+	s.Equal("", decision.VariationKey)
+	s.Equal("242", decision.Revision)
+	s.Equal([]string{decide.GetDecideMessage(decide.FlagKeyInvalid, "feature_2")}, decision.Reasons)
+	s.Len(user.DecideAll(nil), 0)
+
+	eventCount := len(s.eventProcessor.Events)
+	s.NoError(pinnedUser.TrackEvent("event1", nil))
+	s.Len(s.eventProcessor.Events, eventCount+1)
+	s.NotNil(s.eventProcessor.Events[eventCount].Conversion)
+	s.Equal("241", s.eventProcessor.Events[eventCount].EventContext.Revision)
+
+	s.NoError(user.TrackEvent("event1", nil))
+	s.Len(s.eventProcessor.Events, eventCount+1)
+}
+
+func (s *OptimizelyUserContextTestSuite) getClientWithHoldouts(holdouts []map[string]interface{}) *OptimizelyClient {
+	var rawDatafile map[string]interface{}
+	s.NoError(json.Unmarshal(datafile, &rawDatafile))
+	rawDatafile["holdouts"] = holdouts
+	holdoutDatafile, err := json.Marshal(rawDatafile)
+	s.NoError(err)
+
+	factory := OptimizelyFactory{Datafile: holdoutDatafile}
+	client, err := factory.Client(WithEventProcessor(s.eventProcessor))
+	s.NoError(err)
+	return client
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideHoldout() {
+	holdout := map[string]interface{}{
+		"id":     "1681267",
+		"key":    "global_holdout",
+		"status": "Running",
+		"variations": []map[string]interface{}{
+			{"id": "1681267", "key": "ho_off_key", "featureEnabled": false, "variables": []interface{}{}},
+		},
+		"trafficAllocation": []map[string]interface{}{
+			{"entityId": "1681267", "endOfRange": 10000},
+		},
+		"audienceIds":   []string{},
+		"includedFlags": []string{},
+		"excludedFlags": []string{"4482920078"},
+	}
+	client := s.getClientWithHoldouts([]map[string]interface{}{holdout})
+
+	user := client.CreateUserContext(s.userID, nil)
+	decision := user.Decide("feature_1", []decide.OptimizelyDecideOptions{decide.IncludeReasons})
+
+	s.Equal("ho_off_key", decision.VariationKey)
+	s.Equal("global_holdout", decision.RuleKey)
+	s.False(decision.Enabled)
+	s.Contains(decision.Reasons, `User "tester" is bucketed into holdout "global_holdout" for feature flag "feature_1".`)
+
+	s.Len(s.eventProcessor.Events, 1)
+	impressionEvent := s.eventProcessor.Events[0].Impression
+	s.Equal("feature_1", impressionEvent.Metadata.FlagKey)
+	s.Equal("global_holdout", impressionEvent.Metadata.RuleKey)
+	s.Equal("holdout", impressionEvent.Metadata.RuleType)
+	s.Equal("ho_off_key", impressionEvent.Metadata.VariationKey)
+	s.Equal("1681267", impressionEvent.ExperimentID)
+
+	// excluded flag is not affected by the global holdout
+	decision = user.Decide("feature_2", nil)
+	s.Equal("exp_no_audience", decision.RuleKey)
+	s.True(decision.Enabled)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideHoldoutNotRunning() {
+	holdout := map[string]interface{}{
+		"id":     "1681268",
+		"key":    "flag_holdout",
+		"status": "Draft",
+		"variations": []map[string]interface{}{
+			{"id": "1681268", "key": "ho_off_key", "featureEnabled": false, "variables": []interface{}{}},
+		},
+		"trafficAllocation": []map[string]interface{}{
+			{"entityId": "1681268", "endOfRange": 10000},
+		},
+		"includedFlags": []string{"4482920078"},
+	}
+	client := s.getClientWithHoldouts([]map[string]interface{}{holdout})
+
+	user := client.CreateUserContext(s.userID, nil)
+	decision := user.Decide("feature_2", []decide.OptimizelyDecideOptions{decide.IncludeReasons})
+
+	s.Equal("exp_no_audience", decision.RuleKey)
+	s.Equal("variation_with_traffic", decision.VariationKey)
+	s.Contains(decision.Reasons, `Holdout "flag_holdout" is not running.`)
+}
+
 func (s *OptimizelyUserContextTestSuite) TestDecideFeatureTestWithForcedDecision() {
 	numberOfNotifications := 0
 	testForcedDecision := func(flagKey, ruleKey, experimentID, variationKey, reason string, expectedEventCount int) {
@@ -404,6 +540,116 @@ func (s *OptimizelyUserContextTestSuite) TestDecideFeatureTestWithForcedDecision
 	s.Equal("10416523121", impressionEvent.VariationID)
 }
 
+func (s *OptimizelyUserContextTestSuite) TestDecideStructuredReasons() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, map[string]interface{}{"gender": "m", "country": "US"})
+	decision := user.Decide("feature_1", []decide.OptimizelyDecideOptions{decide.IncludeReasons})
+	s.Equal("3332020515", decision.RuleKey)
+
+	s.Contains(decision.StructuredReasons, decide.StructuredReason{
+		Code:       pkgReasons.AudienceEvaluated,
+		AudienceID: "13389141123",
+		Result:     decide.EvaluationResult(false),
+		Message:    `Audience "13389141123" evaluated to false.`,
+	})
+	s.Contains(decision.StructuredReasons, decide.StructuredReason{
+		Code:    pkgReasons.FailedAudienceTargeting,
+		RuleKey: "exp_with_audience",
+		Message: `User "tester" does not meet conditions to be in experiment "exp_with_audience".`,
+	})
+	s.Contains(decision.StructuredReasons, decide.StructuredReason{
+		Code:       pkgReasons.AudienceEvaluated,
+		AudienceID: "13389130056",
+		Result:     decide.EvaluationResult(true),
+		Message:    `Audience "13389130056" evaluated to true.`,
+	})
+	// the reason of the final decision comes last
+	s.Equal(decide.StructuredReason{
+		Code:    pkgReasons.BucketedIntoRollout,
+		RuleKey: "3332020515",
+		Message: string(pkgReasons.BucketedIntoRollout),
+	}, decision.StructuredReasons[len(decision.StructuredReasons)-1])
+	// details are only reported in the structured reasons
+	s.NotContains(decision.Reasons, `Audience "13389130056" evaluated to true.`)
+
+	decision = user.Decide("feature_1", nil)
+	s.Empty(decision.StructuredReasons)
+}
+
+type stubCmabClient struct {
+	variationID string
+	err         error
+}
+
+func (c stubCmabClient) FetchDecision(ctx context.Context, ruleID, userID string, attributes map[string]interface{}, cmabUUID string) (string, error) {
+	return c.variationID, c.err
+}
+
+// cmabOptimizelyClient returns a client for which "exp_with_audience" is a CMAB rule decided by cmabClient,
+// CMAB rules are not decided when cmabClient is nil
+func (s *OptimizelyUserContextTestSuite) cmabOptimizelyClient(cmabClient decision.CmabClient) *OptimizelyClient {
+	var cmabDatafile map[string]interface{}
+	s.Require().NoError(json.Unmarshal(datafile, &cmabDatafile))
+	for _, experiment := range cmabDatafile["experiments"].([]interface{}) {
+		if experiment := experiment.(map[string]interface{}); experiment["key"] == "exp_with_audience" {
+			experiment["cmab"] = map[string]interface{}{"attributeIds": []string{}, "trafficAllocation": 10000}
+		}
+	}
+	jsonDatafile, err := json.Marshal(cmabDatafile)
+	s.Require().NoError(err)
+
+	options := []OptionFunc{WithEventProcessor(s.eventProcessor)}
+	if cmabClient != nil {
+		options = append(options, WithCmabService(decision.NewCmabService("", decision.WithCmabClient(cmabClient))))
+	}
+	factory := OptimizelyFactory{Datafile: jsonDatafile}
+	client, err := factory.Client(options...)
+	s.Require().NoError(err)
+	return client
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideCmab() {
+	client := s.cmabOptimizelyClient(stubCmabClient{variationID: "10389729780"})
+	user := client.CreateUserContext(s.userID, map[string]interface{}{"gender": "f"})
+	flagDecision := user.Decide("feature_1", nil)
+	s.Equal("exp_with_audience", flagDecision.RuleKey)
+	s.Equal("a", flagDecision.VariationKey)
+
+	// the impression carries the ID of the prediction
+	s.Len(s.eventProcessor.Calls, 1)
+	userEvent := s.eventProcessor.Calls[0].Arguments.Get(0).(event.UserEvent)
+	s.NotEmpty(userEvent.Impression.Metadata.CmabUUID)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideCmabFetchFailed() {
+	client := s.cmabOptimizelyClient(stubCmabClient{err: errors.New("prediction service unavailable")})
+
+	// the user is in the audience of the CMAB rule, so the rollout must not decide for them
+	user := client.CreateUserContext(s.userID, map[string]interface{}{"gender": "f"})
+	flagDecision := user.Decide("feature_1", nil)
+	s.Equal("", flagDecision.VariationKey)
+	s.Equal("", flagDecision.RuleKey)
+	s.False(flagDecision.Enabled)
+	s.Equal([]string{`Failed to fetch CMAB decision for flag "feature_1": failed to fetch CMAB decision for rule "exp_with_audience": prediction service unavailable.`}, flagDecision.Reasons)
+	s.Equal(pkgReasons.CmabFetchFailed, flagDecision.StructuredReasons[0].Code)
+	s.Equal("exp_with_audience", flagDecision.StructuredReasons[0].RuleKey)
+	s.eventProcessor.AssertNotCalled(s.T(), "ProcessEvent", mock.Anything)
+
+	// users outside of the CMAB rule still get the rollout
+	user = client.CreateUserContext(s.userID, map[string]interface{}{"gender": "m"})
+	flagDecision = user.Decide("feature_1", nil)
+	s.Equal("18322080788", flagDecision.RuleKey)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideCmabWithoutCmabService() {
+	client := s.cmabOptimizelyClient(nil)
+
+	// without a CMAB service the CMAB rule is skipped
+	user := client.CreateUserContext(s.userID, map[string]interface{}{"gender": "f"})
+	flagDecision := user.Decide("feature_1", []decide.OptimizelyDecideOptions{decide.IncludeReasons})
+	s.Equal("18322080788", flagDecision.RuleKey)
+	s.Contains(flagDecision.Reasons, `Skipping CMAB rule "exp_with_audience", no CMAB service is configured.`)
+}
+
 func (s *OptimizelyUserContextTestSuite) TestDecideRollout() {
 	flagKey := "feature_1"
 	ruleKey := "18322080788"
@@ -838,6 +1084,7 @@ func (s *OptimizelyUserContextTestSuite) TestDecisionNotification() {
 		"variables":               variablesExpected.ToMap(),
 		"ruleKey":                 ruleKey,
 		"reasons":                 reasons,
+		"structuredReasons":       []decide.StructuredReason{},
 		"decisionEventDispatched": true,
 	}
 	s.OptimizelyClient.DecisionService.OnDecision(callback)
@@ -854,6 +1101,186 @@ func (s *OptimizelyUserContextTestSuite) TestDecisionNotification() {
 	s.Equal(expectedDecisionInfo, receivedNotification.DecisionInfo)
 }
 
+func (s *OptimizelyUserContextTestSuite) TestDecideWithContext() {
+	type ctxKey string
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "r-1")
+
+	var receivedNotification notification.DecisionNotification
+	notificationCallback := func(n interface{}) {
+		receivedNotification = n.(notification.DecisionNotification)
+	}
+	_, err := s.OptimizelyClient.GetNotificationCenter().AddHandler(notification.Decision, notificationCallback)
+	s.NoError(err)
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decision := user.DecideWithContext(ctx, "feature_2", nil)
+	s.Equal("variation_with_traffic", decision.VariationKey)
+	s.Equal("r-1", receivedNotification.Context.Value(ctxKey("request")))
+	s.Equal(user.Decide("feature_2", nil).VariationKey, decision.VariationKey)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideWithContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decision := user.DecideWithContext(ctx, "feature_2", nil)
+	s.Equal("feature_2", decision.FlagKey)
+	s.Equal("", decision.VariationKey)
+	s.False(decision.Enabled)
+	s.Equal([]string{"Decision aborted: context canceled."}, decision.Reasons)
+	s.Len(s.eventProcessor.Events, 0)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideAfterClientContextDone() {
+	type ctxKey string
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey("trace"), "t-1"))
+	client, err := s.factory.Client(WithEventProcessor(s.eventProcessor), WithContext(ctx))
+	s.Require().NoError(err)
+	cancel()
+
+	var receivedNotification notification.DecisionNotification
+	_, err = client.GetNotificationCenter().AddHandler(notification.Decision, func(n interface{}) {
+		receivedNotification = n.(notification.DecisionNotification)
+	})
+	s.NoError(err)
+
+	// the calls without a context keep the values of the client context, but not its cancellation
+	user := client.CreateUserContext(s.userID, nil)
+	decision := user.Decide("feature_2", nil)
+	s.Equal("variation_with_traffic", decision.VariationKey)
+	s.Equal("t-1", receivedNotification.Context.Value(ctxKey("trace")))
+	s.NoError(receivedNotification.Context.Err())
+	s.Len(user.DecideAll(nil), 3)
+	s.NoError(user.TrackEvent("event1", nil))
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideForKeysWithContextDeadlineExceeded() {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decisions := user.DecideForKeysWithContext(ctx, []string{"feature_1", "feature_2"}, nil)
+	s.Len(decisions, 2)
+	for _, decision := range decisions {
+		s.Equal([]string{"Decision aborted: context deadline exceeded."}, decision.Reasons)
+	}
+	s.Len(s.eventProcessor.Events, 0)
+
+	// flags which are not decided are not enabled
+	decisions = user.DecideForKeysWithContext(ctx, []string{"feature_1", "feature_2"}, []decide.OptimizelyDecideOptions{decide.EnabledFlagsOnly})
+	s.Len(decisions, 0)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideAllWithContext() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	s.Equal(len(user.DecideAll(nil)), len(user.DecideAllWithContext(context.Background(), nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	decisions := user.DecideAllWithContext(ctx, nil)
+	s.NotEmpty(decisions)
+	for _, decision := range decisions {
+		s.Equal([]string{"Decision aborted: context canceled."}, decision.Reasons)
+	}
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideWithContextUserProfileServiceV2() {
+	type ctxKey string
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "r-1")
+	userProfileService := new(MockUserProfileServiceWithV2)
+	s.OptimizelyClient, _ = s.factory.Client(
+		WithEventProcessor(s.eventProcessor),
+		WithUserProfileService(userProfileService),
+	)
+	isRequestContext := mock.MatchedBy(func(c context.Context) bool {
+		return c.Value(ctxKey("request")) == "r-1"
+	})
+	userProfileService.On("LookupProfile", isRequestContext, s.userID).Return(decision.UserProfile{ID: s.userID}, nil)
+	userProfileService.On("SaveProfile", isRequestContext, mock.Anything).Return(nil)
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decision := user.DecideWithContext(ctx, "feature_2", nil)
+	s.Equal("variation_with_traffic", decision.VariationKey)
+	userProfileService.AssertExpectations(s.T())
+	userProfileService.AssertNotCalled(s.T(), "Lookup", mock.Anything)
+	userProfileService.AssertNotCalled(s.T(), "Save", mock.Anything)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideAllUserProfileServiceV2() {
+	userProfileService := new(MockUserProfileServiceV2)
+	s.OptimizelyClient, _ = s.factory.Client(
+		WithEventProcessor(s.eventProcessor),
+		WithUserProfileServiceV2(userProfileService),
+	)
+	userProfileService.On("LookupProfile", mock.Anything, s.userID).Return(decision.UserProfile{ID: s.userID}, nil).Once()
+	userProfileService.On("SaveProfile", mock.Anything, mock.Anything).Return(nil).Once()
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decisions := user.DecideAll(nil)
+	s.Equal("variation_with_traffic", decisions["feature_2"].VariationKey)
+	// a single lookup and a single save for all the flags
+	userProfileService.AssertExpectations(s.T())
+	savedProfile := userProfileService.Calls[1].Arguments.Get(1).(decision.UserProfile)
+	s.Equal(s.userID, savedProfile.ID)
+	s.Equal("10418551353", savedProfile.ExperimentBucketMap[decision.NewUserDecisionKey("10420810910")])
+}
+
+func (s *OptimizelyUserContextTestSuite) TestDecideForKeysUserProfileServiceV2LookupError() {
+	userProfileService := new(MockUserProfileServiceV2)
+	s.OptimizelyClient, _ = s.factory.Client(
+		WithEventProcessor(s.eventProcessor),
+		WithUserProfileServiceV2(userProfileService),
+	)
+	userProfileService.On("LookupProfile", mock.Anything, s.userID).Return(decision.UserProfile{}, errors.New("connection refused")).Once()
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	decisions := user.DecideForKeys([]string{"feature_2"}, nil)
+	s.Equal("variation_with_traffic", decisions["feature_2"].VariationKey)
+	s.Equal([]string{`Unable to look up the user profile of user "tester": connection refused`}, decisions["feature_2"].Reasons)
+	userProfileService.AssertExpectations(s.T())
+	userProfileService.AssertNotCalled(s.T(), "SaveProfile", mock.Anything, mock.Anything)
+}
+
+func (s *OptimizelyUserContextTestSuite) TestImportExportForcedDecisions() {
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	data, err := user.ExportForcedDecisions()
+	s.NoError(err)
+	s.JSONEq(`[]`, string(data))
+
+	mappings, parseErrors := decision.ParseForcedDecisions("feature_2=variation_no_traffic,feature_1:unknown_rule=a,broken")
+	s.Len(parseErrors, 1)
+	invalid := user.ImportForcedDecisions(mappings)
+	s.Len(invalid, 1)
+	s.Equal("feature_1:unknown_rule=a", invalid[0].Input)
+	s.Equal("variation_no_traffic", user.Decide("feature_2", nil).VariationKey)
+
+	data, err = user.ExportForcedDecisions()
+	s.NoError(err)
+	s.JSONEq(`[{"flagKey": "feature_2", "variationKey": "variation_no_traffic"}]`, string(data))
+}
+
+func (s *OptimizelyUserContextTestSuite) TestTrackEventWithContext() {
+	type ctxKey string
+	ctx := context.WithValue(context.Background(), ctxKey("request"), "r-1")
+
+	var receivedNotification notification.TrackNotification
+	_, err := s.OptimizelyClient.GetNotificationCenter().AddHandler(notification.Track, func(n interface{}) {
+		receivedNotification = n.(notification.TrackNotification)
+	})
+	s.NoError(err)
+
+	user := s.OptimizelyClient.CreateUserContext(s.userID, nil)
+	s.NoError(user.TrackEventWithContext(ctx, "event1", nil))
+	s.Len(s.eventProcessor.Events, 1)
+	s.Equal("r-1", receivedNotification.Context.Value(ctxKey("request")))
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Equal(context.Canceled, user.TrackEventWithContext(canceledCtx, "event1", nil))
+	s.Len(s.eventProcessor.Events, 1)
+}
+
 func (s *OptimizelyUserContextTestSuite) TestDecideOptionsBypassUps() {
 	flagKey := "feature_2" // embedding experiment: "exp_no_audience"
 	experimentID := "10420810910"
@@ -1062,6 +1489,8 @@ func (s *OptimizelyUserContextTestSuite) TestGetAllOptionsUsesOrOperator() {
 		decide.IgnoreUserProfileService,
 		decide.IncludeReasons,
 		decide.ExcludeVariables,
+		decide.IgnoreCMABCache,
+		decide.ResetCMABCache,
 	}
 	client, _ := s.factory.Client(WithDefaultDecideOptions(options1))
 	// Pass all false options
@@ -1073,6 +1502,8 @@ func (s *OptimizelyUserContextTestSuite) TestGetAllOptionsUsesOrOperator() {
 		IgnoreUserProfileService: true,
 		IncludeReasons:           true,
 		ExcludeVariables:         true,
+		IgnoreCMABCache:          true,
+		ResetCMABCache:           true,
 	}, options2)
 }
 
@@ -0,0 +1,78 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	pkgDecision "github.com/optimizely/go-sdk/v2/pkg/decision"
+)
+
+// UserContextSnapshotVersion is the version of the UserContextSnapshot format written by Snapshot
+const UserContextSnapshotVersion = 1
+
+// UserContextSnapshot holds everything a user context needs to make the same decisions on another client or
+// process, e.g. when a web request hands the user over to a background job. It is meant to be encoded as JSON, so
+// numeric attributes are restored as float64, which the audience conditions treat the same way.
+type UserContextSnapshot struct {
+	Version    int                    `json:"version"`
+	UserID     string                 `json:"userId"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// QualifiedSegments is nil when the segments were never fetched, which is not the same as no segments
+	QualifiedSegments []string `json:"qualifiedSegments"`
+	// ForcedDecisions are sorted by flag and rule, so that identical contexts encode identically
+	ForcedDecisions []pkgDecision.ForcedDecisionMapping `json:"forcedDecisions,omitempty"`
+}
+
+// Snapshot returns the user ID, attributes, qualified segments and forced decisions of the user context
+func (o *OptimizelyUserContext) Snapshot() UserContextSnapshot {
+	snapshot := UserContextSnapshot{
+		Version:           UserContextSnapshotVersion,
+		UserID:            o.GetUserID(),
+		Attributes:        o.GetUserAttributes(),
+		QualifiedSegments: o.GetQualifiedSegments(),
+	}
+	if o.forcedDecisionService != nil {
+		if mappings := o.forcedDecisionService.Mappings(); len(mappings) > 0 {
+			snapshot.ForcedDecisions = mappings
+		}
+	}
+	return snapshot
+}
+
+// RestoreUserContext creates a user context of this client identical to the one the snapshot was taken from
+func (o *OptimizelyClient) RestoreUserContext(snapshot UserContextSnapshot) (OptimizelyUserContext, error) {
+	if snapshot.Version > UserContextSnapshotVersion {
+		return OptimizelyUserContext{}, fmt.Errorf("unsupported user context snapshot version %d", snapshot.Version)
+	}
+	if snapshot.UserID == "" {
+		return OptimizelyUserContext{}, errors.New("user context snapshot has no user ID")
+	}
+
+	userContext := o.CreateUserContext(snapshot.UserID, snapshot.Attributes)
+	if snapshot.QualifiedSegments != nil {
+		userContext.SetQualifiedSegments(snapshot.QualifiedSegments)
+	}
+	for _, forcedDecision := range snapshot.ForcedDecisions {
+		userContext.SetForcedDecision(
+			pkgDecision.OptimizelyDecisionContext{FlagKey: forcedDecision.FlagKey, RuleKey: forcedDecision.RuleKey},
+			pkgDecision.OptimizelyForcedDecision{VariationKey: forcedDecision.VariationKey},
+		)
+	}
+	return userContext, nil
+}
@@ -0,0 +1,144 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgDecision "github.com/optimizely/go-sdk/v2/pkg/decision"
+)
+
+type UserContextSnapshotTestSuite struct {
+	suite.Suite
+	webClient    *OptimizelyClient
+	workerClient *OptimizelyClient
+}
+
+func (s *UserContextSnapshotTestSuite) SetupTest() {
+	b := builder.New()
+	b.Audience("adults", builder.Match("age", "ge", 18))
+	b.Audience("beta", builder.Qualified("beta_users"))
+	b.Flag("checkout").Experiment("checkout_test").
+		Audiences("adults").
+		Variation("control", false, nil).
+		Variation("treatment", true, nil).
+		Weights(1, 1)
+	b.Flag("search").Rule("beta_rule").Audiences("beta").Enabled(true)
+	b.Flag("pricing").Experiment("pricing_test").
+		Variation("a", true, nil).
+		Variation("b", true, nil).
+		Weights(1, 0)
+
+	newClient := func() *OptimizelyClient {
+		configManager, err := b.ConfigManager()
+		s.Require().NoError(err)
+		factory := OptimizelyFactory{}
+		optimizelyClient, err := factory.Client(WithConfigManager(configManager), WithEventDispatcher(&MockDispatcher{}))
+		s.Require().NoError(err)
+		return optimizelyClient
+	}
+	s.webClient = newClient()
+	s.workerClient = newClient()
+}
+
+func (s *UserContextSnapshotTestSuite) TearDownTest() {
+	s.webClient.Close()
+	s.workerClient.Close()
+}
+
+func (s *UserContextSnapshotTestSuite) roundTrip(snapshot UserContextSnapshot) UserContextSnapshot {
+	data, err := json.Marshal(snapshot)
+	s.Require().NoError(err)
+	var restored UserContextSnapshot
+	s.Require().NoError(json.Unmarshal(data, &restored))
+	return restored
+}
+
+func (s *UserContextSnapshotTestSuite) TestRestoreMakesIdenticalDecisions() {
+	userContext := s.webClient.CreateUserContext("user1", map[string]interface{}{"age": 30, "country": "ca"})
+	userContext.SetQualifiedSegments([]string{"beta_users"})
+	userContext.SetForcedDecision(pkgDecision.OptimizelyDecisionContext{FlagKey: "pricing", RuleKey: "pricing_test"}, pkgDecision.OptimizelyForcedDecision{VariationKey: "b"})
+
+	restored, err := s.workerClient.RestoreUserContext(s.roundTrip(userContext.Snapshot()))
+	s.Require().NoError(err)
+	s.Equal("user1", restored.GetUserID())
+	s.Equal(map[string]interface{}{"age": float64(30), "country": "ca"}, restored.GetUserAttributes())
+	s.Equal([]string{"beta_users"}, restored.GetQualifiedSegments())
+	s.Equal(userContext.GetForcedDecisions(), restored.GetForcedDecisions())
+
+	options := []decide.OptimizelyDecideOptions{decide.ExcludeVariables}
+	expected := userContext.DecideAll(options)
+	actual := restored.DecideAll(options)
+	s.Len(actual, 3)
+	for key, decision := range expected {
+		s.Equal(decision.VariationKey, actual[key].VariationKey, key)
+		s.Equal(decision.RuleKey, actual[key].RuleKey, key)
+		s.Equal(decision.Enabled, actual[key].Enabled, key)
+	}
+	s.Equal("b", actual["pricing"].VariationKey)
+	s.True(actual["search"].Enabled)
+}
+
+func (s *UserContextSnapshotTestSuite) TestSnapshotIsStable() {
+	userContext := s.webClient.CreateUserContext("user1", nil)
+	userContext.SetForcedDecision(pkgDecision.OptimizelyDecisionContext{FlagKey: "search"}, pkgDecision.OptimizelyForcedDecision{VariationKey: "on"})
+	userContext.SetForcedDecision(pkgDecision.OptimizelyDecisionContext{FlagKey: "pricing", RuleKey: "pricing_test"}, pkgDecision.OptimizelyForcedDecision{VariationKey: "b"})
+	userContext.SetForcedDecision(pkgDecision.OptimizelyDecisionContext{FlagKey: "pricing"}, pkgDecision.OptimizelyForcedDecision{VariationKey: "a"})
+
+	snapshot := userContext.Snapshot()
+	s.Equal([]pkgDecision.ForcedDecisionMapping{
+		{FlagKey: "pricing", VariationKey: "a"},
+		{FlagKey: "pricing", RuleKey: "pricing_test", VariationKey: "b"},
+		{FlagKey: "search", VariationKey: "on"},
+	}, snapshot.ForcedDecisions)
+
+	data, err := json.Marshal(snapshot)
+	s.NoError(err)
+	s.JSONEq(`{"version":1,"userId":"user1","qualifiedSegments":null,"forcedDecisions":[
+		{"flagKey":"pricing","variationKey":"a"},
+		{"flagKey":"pricing","ruleKey":"pricing_test","variationKey":"b"},
+		{"flagKey":"search","variationKey":"on"}]}`, string(data))
+}
+
+func (s *UserContextSnapshotTestSuite) TestQualifiedSegments() {
+	// segments which were never fetched stay nil, an empty list stays empty
+	userContext := s.webClient.CreateUserContext("user1", nil)
+	restored, err := s.workerClient.RestoreUserContext(s.roundTrip(userContext.Snapshot()))
+	s.NoError(err)
+	s.Nil(restored.GetQualifiedSegments())
+
+	userContext.SetQualifiedSegments([]string{})
+	restored, err = s.workerClient.RestoreUserContext(s.roundTrip(userContext.Snapshot()))
+	s.NoError(err)
+	s.Equal([]string{}, restored.GetQualifiedSegments())
+}
+
+func (s *UserContextSnapshotTestSuite) TestInvalidSnapshot() {
+	_, err := s.workerClient.RestoreUserContext(UserContextSnapshot{Version: UserContextSnapshotVersion})
+	s.Error(err)
+	_, err = s.workerClient.RestoreUserContext(UserContextSnapshot{Version: UserContextSnapshotVersion + 1, UserID: "user1"})
+	s.Error(err)
+}
+
+func TestUserContextSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(UserContextSnapshotTestSuite))
+}
@@ -0,0 +1,450 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package configdiff compares project configs and reports what changed between them
+package configdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// ChangeType tells whether an entity was added, removed or changed
+type ChangeType string
+
+const (
+	// Added - the entity is only in the new config
+	Added ChangeType = "added"
+	// Removed - the entity is only in the old config
+	Removed ChangeType = "removed"
+	// Changed - a field of the entity differs between the configs
+	Changed ChangeType = "changed"
+)
+
+// Entity is the kind of entity a change is about
+type Entity string
+
+const (
+	// Flag - a feature flag
+	Flag Entity = "flag"
+	// Rule - an experiment or a rollout rule of a flag
+	Rule Entity = "rule"
+	// Variation - a variation of a rule
+	Variation Entity = "variation"
+	// Variable - a variable of a flag
+	Variable Entity = "variable"
+	// Audience - an audience
+	Audience Entity = "audience"
+	// TrafficAllocation - the traffic allocation of a rule or a holdout
+	TrafficAllocation Entity = "traffic_allocation"
+	// Holdout - a holdout which applies to a flag
+	Holdout Entity = "holdout"
+)
+
+// Config is the part of a project config the diff is computed from, config.ProjectConfig implements it
+type Config interface {
+	GetRevision() string
+	GetFeatureList() []entities.Feature
+	GetAudienceList() []entities.Audience
+	GetHoldoutList() []entities.Holdout
+}
+
+// Change is a difference between two configs
+type Change struct {
+	Type    ChangeType `json:"type"`
+	Entity  Entity     `json:"entity"`
+	FlagKey string     `json:"flagKey,omitempty"`
+	// RuleKey is the key of the rule or of the holdout
+	RuleKey string `json:"ruleKey,omitempty"`
+	// Key is the key of the variation or variable, or the ID of the audience
+	Key string `json:"key,omitempty"`
+	// Field is the field of a changed entity which differs, e.g. "status" or "defaultValue"
+	Field string      `json:"field,omitempty"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	path := []string{}
+	for _, part := range []string{c.FlagKey, c.RuleKey, c.Key} {
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+	description := fmt.Sprintf("%s %s %s", c.Type, c.Entity, strings.Join(path, "/"))
+	if c.Type == Changed {
+		if c.Field != "" {
+			description += " " + c.Field + ":"
+		}
+		description += fmt.Sprintf(" %v -> %v", format(c.Old), format(c.New))
+	}
+	return description
+}
+
+// Allocation is a traffic allocation range, with the key of the variation instead of its ID
+type Allocation struct {
+	VariationKey string `json:"variationKey"`
+	EndOfRange   int    `json:"endOfRange"`
+}
+
+// Diff is the list of changes between two configs, ordered by flag and then by rule
+type Diff struct {
+	OldRevision string   `json:"oldRevision"`
+	NewRevision string   `json:"newRevision"`
+	Changes     []Change `json:"changes"`
+}
+
+// IsEmpty returns true if the configs have no differences
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// FlagKeys returns the sorted keys of the flags with changes
+func (d *Diff) FlagKeys() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, change := range d.Changes {
+		if change.FlagKey != "" && !seen[change.FlagKey] {
+			seen[change.FlagKey] = true
+			keys = append(keys, change.FlagKey)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Compare returns the changes from the old config to the new config
+func Compare(oldConfig, newConfig Config) *Diff {
+	d := &Diff{
+		OldRevision: oldConfig.GetRevision(),
+		NewRevision: newConfig.GetRevision(),
+		Changes:     []Change{},
+	}
+	d.compareFlags(oldConfig.GetFeatureList(), newConfig.GetFeatureList())
+	d.compareHoldouts(oldConfig, newConfig)
+	d.compareAudiences(oldConfig.GetAudienceList(), newConfig.GetAudienceList())
+	return d
+}
+
+func (d *Diff) add(change Change) {
+	d.Changes = append(d.Changes, change)
+}
+
+func (d *Diff) compareFlags(oldFlags, newFlags []entities.Feature) {
+	oldByKey := map[string]entities.Feature{}
+	for _, flag := range oldFlags {
+		oldByKey[flag.Key] = flag
+	}
+	newByKey := map[string]entities.Feature{}
+	for _, flag := range newFlags {
+		newByKey[flag.Key] = flag
+	}
+
+	for _, key := range unionKeys(oldByKey, newByKey) {
+		oldFlag, inOld := oldByKey[key]
+		newFlag, inNew := newByKey[key]
+		switch {
+		case !inOld:
+			d.add(Change{Type: Added, Entity: Flag, FlagKey: key})
+		case !inNew:
+			d.add(Change{Type: Removed, Entity: Flag, FlagKey: key})
+		default:
+			d.compareVariables(key, oldFlag.VariableMap, newFlag.VariableMap)
+			d.compareRules(oldFlag, newFlag)
+		}
+	}
+}
+
+func (d *Diff) compareVariables(flagKey string, oldVariables, newVariables map[string]entities.Variable) {
+	for _, key := range unionKeys(oldVariables, newVariables) {
+		oldVariable, inOld := oldVariables[key]
+		newVariable, inNew := newVariables[key]
+		change := Change{Entity: Variable, FlagKey: flagKey, Key: key}
+		switch {
+		case !inOld:
+			change.Type = Added
+			d.add(change)
+		case !inNew:
+			change.Type = Removed
+			d.add(change)
+		default:
+			change.Type = Changed
+			d.compareField(change, "type", oldVariable.Type, newVariable.Type)
+			d.compareField(change, "defaultValue", oldVariable.DefaultValue, newVariable.DefaultValue)
+		}
+	}
+}
+
+// rules returns the experiments and rollout rules of the flag, keyed by rule key, and the rule keys in decision order
+func rules(flag entities.Feature) (map[string]entities.Experiment, []string) {
+	byKey := map[string]entities.Experiment{}
+	keys := []string{}
+	for _, experiment := range append(append([]entities.Experiment{}, flag.FeatureExperiments...), flag.Rollout.Experiments...) {
+		if _, ok := byKey[experiment.Key]; !ok {
+			keys = append(keys, experiment.Key)
+		}
+		byKey[experiment.Key] = experiment
+	}
+	return byKey, keys
+}
+
+func (d *Diff) compareRules(oldFlag, newFlag entities.Feature) {
+	oldRules, oldKeys := rules(oldFlag)
+	newRules, newKeys := rules(newFlag)
+	oldVariableKeys := variableKeys(oldFlag)
+	newVariableKeys := variableKeys(newFlag)
+
+	for _, key := range newKeys {
+		if _, ok := oldRules[key]; !ok {
+			d.add(Change{Type: Added, Entity: Rule, FlagKey: newFlag.Key, RuleKey: key})
+		}
+	}
+	for _, key := range oldKeys {
+		oldRule := oldRules[key]
+		newRule, ok := newRules[key]
+		if !ok {
+			d.add(Change{Type: Removed, Entity: Rule, FlagKey: oldFlag.Key, RuleKey: key})
+			continue
+		}
+
+		change := Change{Type: Changed, Entity: Rule, FlagKey: newFlag.Key, RuleKey: key}
+		d.compareField(change, "status", oldRule.Status, newRule.Status)
+		d.compareField(change, "audienceConditions", audienceConditions(oldRule), audienceConditions(newRule))
+		d.compareField(Change{Type: Changed, Entity: TrafficAllocation, FlagKey: newFlag.Key, RuleKey: key}, "",
+			allocations(oldRule), allocations(newRule))
+		d.compareVariations(newFlag.Key, key, oldRule, newRule, oldVariableKeys, newVariableKeys)
+	}
+}
+
+func (d *Diff) compareVariations(flagKey, ruleKey string, oldRule, newRule entities.Experiment, oldVariableKeys, newVariableKeys map[string]string) {
+	oldVariations := variationsByKey(oldRule)
+	newVariations := variationsByKey(newRule)
+	for _, key := range unionKeys(oldVariations, newVariations) {
+		oldVariation, inOld := oldVariations[key]
+		newVariation, inNew := newVariations[key]
+		change := Change{Entity: Variation, FlagKey: flagKey, RuleKey: ruleKey, Key: key}
+		switch {
+		case !inOld:
+			change.Type = Added
+			d.add(change)
+		case !inNew:
+			change.Type = Removed
+			d.add(change)
+		default:
+			change.Type = Changed
+			d.compareField(change, "featureEnabled", oldVariation.FeatureEnabled, newVariation.FeatureEnabled)
+			d.compareField(change, "variables", variableValues(oldVariation, oldVariableKeys), variableValues(newVariation, newVariableKeys))
+		}
+	}
+}
+
+// compareHoldouts adds the holdout changes once for every flag the holdout applies to in either config, a holdout
+// which starts or stops applying to a flag is added to or removed from that flag
+func (d *Diff) compareHoldouts(oldConfig, newConfig Config) {
+	oldByKey := holdoutsByKey(oldConfig.GetHoldoutList())
+	newByKey := holdoutsByKey(newConfig.GetHoldoutList())
+	oldFlagKeys := holdoutFlagKeys(oldConfig.GetFeatureList())
+	newFlagKeys := holdoutFlagKeys(newConfig.GetFeatureList())
+
+	for _, key := range unionKeys(oldByKey, newByKey) {
+		oldHoldout, inOld := oldByKey[key]
+		newHoldout, inNew := newByKey[key]
+		flagKeys := unionKeys(oldFlagKeys[key], newFlagKeys[key])
+		if len(flagKeys) == 0 {
+			// a holdout which applies to no flag is still reported, without a flag
+			flagKeys = []string{""}
+		}
+		for _, flagKey := range flagKeys {
+			appliesToOld := inOld && (flagKey == "" || oldFlagKeys[key][flagKey])
+			appliesToNew := inNew && (flagKey == "" || newFlagKeys[key][flagKey])
+			switch {
+			case !appliesToOld:
+				d.add(Change{Type: Added, Entity: Holdout, FlagKey: flagKey, RuleKey: key})
+			case !appliesToNew:
+				d.add(Change{Type: Removed, Entity: Holdout, FlagKey: flagKey, RuleKey: key})
+			default:
+				d.compareHoldout(flagKey, oldHoldout, newHoldout)
+			}
+		}
+	}
+}
+
+func (d *Diff) compareHoldout(flagKey string, oldHoldout, newHoldout entities.Holdout) {
+	oldRule, newRule := oldHoldout.ToExperiment(), newHoldout.ToExperiment()
+	change := Change{Type: Changed, Entity: Holdout, FlagKey: flagKey, RuleKey: newHoldout.Key}
+	d.compareField(change, "status", oldHoldout.Status, newHoldout.Status)
+	d.compareField(change, "audienceConditions", audienceConditions(oldRule), audienceConditions(newRule))
+	d.compareField(Change{Type: Changed, Entity: TrafficAllocation, FlagKey: flagKey, RuleKey: newHoldout.Key}, "",
+		allocations(oldRule), allocations(newRule))
+	d.compareVariations(flagKey, newHoldout.Key, oldRule, newRule, map[string]string{}, map[string]string{})
+}
+
+func (d *Diff) compareAudiences(oldAudiences, newAudiences []entities.Audience) {
+	oldByID := map[string]entities.Audience{}
+	for _, audience := range oldAudiences {
+		oldByID[audience.ID] = audience
+	}
+	newByID := map[string]entities.Audience{}
+	for _, audience := range newAudiences {
+		newByID[audience.ID] = audience
+	}
+
+	for _, id := range unionKeys(oldByID, newByID) {
+		oldAudience, inOld := oldByID[id]
+		newAudience, inNew := newByID[id]
+		change := Change{Entity: Audience, Key: id}
+		switch {
+		case !inOld:
+			change.Type = Added
+			d.add(change)
+		case !inNew:
+			change.Type = Removed
+			d.add(change)
+		default:
+			change.Type = Changed
+			d.compareField(change, "name", oldAudience.Name, newAudience.Name)
+			d.compareField(change, "conditions", conditions(oldAudience), conditions(newAudience))
+		}
+	}
+}
+
+// compareField adds the change with the given field and values if the values differ
+func (d *Diff) compareField(change Change, field string, oldValue, newValue interface{}) {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	change.Field, change.Old, change.New = field, oldValue, newValue
+	d.add(change)
+}
+
+// conditions returns the condition tree of the audience, legacy audiences carry it as a JSON encoded string while
+// typed audiences carry it decoded
+func conditions(audience entities.Audience) interface{} {
+	if encoded, ok := audience.Conditions.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(encoded), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return audience.Conditions
+}
+
+// audienceConditions returns the conditions the audience condition tree of the rule is built from
+func audienceConditions(rule entities.Experiment) interface{} {
+	if rule.AudienceConditions != nil {
+		return rule.AudienceConditions
+	}
+	if len(rule.AudienceIds) == 0 {
+		return nil
+	}
+	return rule.AudienceIds
+}
+
+func allocations(rule entities.Experiment) []Allocation {
+	result := make([]Allocation, 0, len(rule.TrafficAllocation))
+	for _, trafficRange := range rule.TrafficAllocation {
+		variationKey := trafficRange.EntityID
+		if variation, ok := rule.Variations[trafficRange.EntityID]; ok {
+			variationKey = variation.Key
+		}
+		result = append(result, Allocation{VariationKey: variationKey, EndOfRange: trafficRange.EndOfRange})
+	}
+	return result
+}
+
+func holdoutsByKey(holdouts []entities.Holdout) map[string]entities.Holdout {
+	byKey := map[string]entities.Holdout{}
+	for _, holdout := range holdouts {
+		byKey[holdout.Key] = holdout
+	}
+	return byKey
+}
+
+// holdoutFlagKeys maps the holdout keys to the keys of the flags they apply to
+func holdoutFlagKeys(flags []entities.Feature) map[string]map[string]bool {
+	flagKeys := map[string]map[string]bool{}
+	for _, flag := range flags {
+		for _, holdout := range flag.Holdouts {
+			if flagKeys[holdout.Key] == nil {
+				flagKeys[holdout.Key] = map[string]bool{}
+			}
+			flagKeys[holdout.Key][flag.Key] = true
+		}
+	}
+	return flagKeys
+}
+
+func variationsByKey(rule entities.Experiment) map[string]entities.Variation {
+	byKey := map[string]entities.Variation{}
+	for _, variation := range rule.Variations {
+		byKey[variation.Key] = variation
+	}
+	return byKey
+}
+
+// variableKeys maps the variable IDs of the flag to their keys
+func variableKeys(flag entities.Feature) map[string]string {
+	keys := map[string]string{}
+	for key, variable := range flag.VariableMap {
+		keys[variable.ID] = key
+	}
+	return keys
+}
+
+// variableValues returns the variable values of the variation by variable key
+func variableValues(variation entities.Variation, variableKeys map[string]string) map[string]string {
+	values := map[string]string{}
+	for id, variable := range variation.Variables {
+		key, ok := variableKeys[id]
+		if !ok {
+			key = id
+		}
+		values[key] = variable.Value
+	}
+	return values
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func format(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	if encoded, err := json.Marshal(value); err == nil {
+		return string(encoded)
+	}
+	return fmt.Sprintf("%v", value)
+}
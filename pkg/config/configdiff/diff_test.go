@@ -0,0 +1,254 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package configdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+type testConfig struct {
+	revision  string
+	features  []entities.Feature
+	audiences []entities.Audience
+	holdouts  []entities.Holdout
+}
+
+func (c *testConfig) GetRevision() string                  { return c.revision }
+func (c *testConfig) GetFeatureList() []entities.Feature   { return c.features }
+func (c *testConfig) GetAudienceList() []entities.Audience { return c.audiences }
+func (c *testConfig) GetHoldoutList() []entities.Holdout   { return c.holdouts }
+
+// setHoldout sets the holdout as the only holdout of the config and of the flags with the given keys
+func (c *testConfig) setHoldout(holdout entities.Holdout, flagKeys ...string) {
+	c.holdouts = []entities.Holdout{holdout}
+	for i := range c.features {
+		c.features[i].Holdouts = nil
+		for _, key := range flagKeys {
+			if c.features[i].Key == key {
+				c.features[i].Holdouts = []entities.Holdout{holdout}
+			}
+		}
+	}
+}
+
+func newTestHoldout() entities.Holdout {
+	return entities.Holdout{
+		ID:                "7001",
+		Key:               "global_holdout",
+		Status:            entities.HoldoutStatusRunning,
+		Variations:        map[string]entities.Variation{"2101": {ID: "2101", Key: "off", FeatureEnabled: false}},
+		TrafficAllocation: []entities.Range{{EntityID: "2101", EndOfRange: 500}},
+	}
+}
+
+// newTestConfig returns a config with a flag "checkout", which has an experiment and a rollout rule
+func newTestConfig(revision string) *testConfig {
+	experiment := entities.Experiment{
+		ID:          "1001",
+		Key:         "checkout_test",
+		AudienceIds: []string{"3001"},
+		Status:      entities.ExperimentStatusRunning,
+		Variations: map[string]entities.Variation{
+			"2001": {ID: "2001", Key: "control", FeatureEnabled: false},
+			"2002": {ID: "2002", Key: "treatment", FeatureEnabled: true,
+				Variables: map[string]entities.VariationVariable{"4001": {ID: "4001", Value: "20"}}},
+		},
+		TrafficAllocation: []entities.Range{{EntityID: "2001", EndOfRange: 5000}, {EntityID: "2002", EndOfRange: 10000}},
+	}
+	rolloutRule := entities.Experiment{
+		ID:                "1002",
+		Key:               "everyone_else",
+		Status:            entities.ExperimentStatusRunning,
+		Variations:        map[string]entities.Variation{"2003": {ID: "2003", Key: "on", FeatureEnabled: true}},
+		TrafficAllocation: []entities.Range{{EntityID: "2003", EndOfRange: 10000}},
+	}
+	return &testConfig{
+		revision: revision,
+		features: []entities.Feature{{
+			ID:                 "5001",
+			Key:                "checkout",
+			FeatureExperiments: []entities.Experiment{experiment},
+			Rollout:            entities.Rollout{ID: "6001", Experiments: []entities.Experiment{rolloutRule}},
+			VariableMap: map[string]entities.Variable{
+				"limit": {ID: "4001", Key: "limit", Type: entities.Integer, DefaultValue: "10"},
+			},
+		}},
+		audiences: []entities.Audience{{
+			ID:         "3001",
+			Name:       "adults",
+			Conditions: `["and", {"type": "custom_attribute", "name": "age", "match": "ge", "value": 18}]`,
+		}},
+	}
+}
+
+type DiffTestSuite struct {
+	suite.Suite
+	oldConfig *testConfig
+	newConfig *testConfig
+}
+
+func (s *DiffTestSuite) SetupTest() {
+	s.oldConfig = newTestConfig("1")
+	s.newConfig = newTestConfig("2")
+}
+
+func (s *DiffTestSuite) experiment() *entities.Experiment {
+	return &s.newConfig.features[0].FeatureExperiments[0]
+}
+
+func (s *DiffTestSuite) TestIdenticalConfigs() {
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.True(diff.IsEmpty())
+	s.Equal("1", diff.OldRevision)
+	s.Equal("2", diff.NewRevision)
+	s.Empty(diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestAddedAndRemovedFlags() {
+	s.oldConfig.features = append(s.oldConfig.features, entities.Feature{Key: "legacy"})
+	s.newConfig.features = append(s.newConfig.features, entities.Feature{Key: "search"})
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Removed, Entity: Flag, FlagKey: "legacy"},
+		{Type: Added, Entity: Flag, FlagKey: "search"},
+	}, diff.Changes)
+	s.Equal([]string{"legacy", "search"}, diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestChangedVariables() {
+	s.newConfig.features[0].VariableMap = map[string]entities.Variable{
+		"limit": {ID: "4001", Key: "limit", Type: entities.Integer, DefaultValue: "15"},
+		"theme": {ID: "4002", Key: "theme", Type: entities.String, DefaultValue: "dark"},
+	}
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Changed, Entity: Variable, FlagKey: "checkout", Key: "limit", Field: "defaultValue", Old: "10", New: "15"},
+		{Type: Added, Entity: Variable, FlagKey: "checkout", Key: "theme"},
+	}, diff.Changes)
+}
+
+func (s *DiffTestSuite) TestChangedRules() {
+	experiment := s.experiment()
+	experiment.Status = entities.ExperimentStatusPaused
+	experiment.AudienceIds = nil
+	experiment.TrafficAllocation = []entities.Range{{EntityID: "2001", EndOfRange: 2000}, {EntityID: "2002", EndOfRange: 4000}}
+	s.newConfig.features[0].Rollout.Experiments = nil
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Changed, Entity: Rule, FlagKey: "checkout", RuleKey: "checkout_test", Field: "status",
+			Old: entities.ExperimentStatusRunning, New: entities.ExperimentStatusPaused},
+		{Type: Changed, Entity: Rule, FlagKey: "checkout", RuleKey: "checkout_test", Field: "audienceConditions",
+			Old: []string{"3001"}, New: nil},
+		{Type: Changed, Entity: TrafficAllocation, FlagKey: "checkout", RuleKey: "checkout_test",
+			Old: []Allocation{{VariationKey: "control", EndOfRange: 5000}, {VariationKey: "treatment", EndOfRange: 10000}},
+			New: []Allocation{{VariationKey: "control", EndOfRange: 2000}, {VariationKey: "treatment", EndOfRange: 4000}}},
+		{Type: Removed, Entity: Rule, FlagKey: "checkout", RuleKey: "everyone_else"},
+	}, diff.Changes)
+	s.Equal([]string{"checkout"}, diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestChangedVariations() {
+	experiment := s.experiment()
+	experiment.Variations = map[string]entities.Variation{
+		"2001": {ID: "2001", Key: "control", FeatureEnabled: true},
+		"2002": {ID: "2002", Key: "treatment", FeatureEnabled: true,
+			Variables: map[string]entities.VariationVariable{"4001": {ID: "4001", Value: "30"}}},
+		"2004": {ID: "2004", Key: "treatment_2", FeatureEnabled: true},
+	}
+	// rebuilding a rule in the Optimizely app gives it new IDs, the diff matches rules and variations by key
+	experiment.ID = "1003"
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Changed, Entity: Variation, FlagKey: "checkout", RuleKey: "checkout_test", Key: "control",
+			Field: "featureEnabled", Old: false, New: true},
+		{Type: Changed, Entity: Variation, FlagKey: "checkout", RuleKey: "checkout_test", Key: "treatment",
+			Field: "variables", Old: map[string]string{"limit": "20"}, New: map[string]string{"limit": "30"}},
+		{Type: Added, Entity: Variation, FlagKey: "checkout", RuleKey: "checkout_test", Key: "treatment_2"},
+	}, diff.Changes)
+}
+
+func (s *DiffTestSuite) TestChangedAudiences() {
+	s.newConfig.audiences = []entities.Audience{
+		{
+			ID:   "3001",
+			Name: "grown-ups",
+			// typed audiences carry decoded conditions, equal conditions are not a change
+			Conditions: []interface{}{"and", map[string]interface{}{"type": "custom_attribute", "name": "age", "match": "ge", "value": 18.0}},
+		},
+		{ID: "3002", Name: "beta"},
+	}
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Changed, Entity: Audience, Key: "3001", Field: "name", Old: "adults", New: "grown-ups"},
+		{Type: Added, Entity: Audience, Key: "3002"},
+	}, diff.Changes)
+	s.Empty(diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestChangedHoldouts() {
+	s.oldConfig.features = append(s.oldConfig.features, entities.Feature{ID: "5002", Key: "search"})
+	s.newConfig.features = append(s.newConfig.features, entities.Feature{ID: "5002", Key: "search"})
+	s.oldConfig.setHoldout(newTestHoldout(), "checkout", "search")
+	holdout := newTestHoldout()
+	holdout.Status = entities.HoldoutStatusConcluded
+	holdout.TrafficAllocation = []entities.Range{{EntityID: "2101", EndOfRange: 1000}}
+	holdout.ExcludedFlags = []string{"5002"}
+	s.newConfig.setHoldout(holdout, "checkout")
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{
+		{Type: Changed, Entity: Holdout, FlagKey: "checkout", RuleKey: "global_holdout", Field: "status",
+			Old: entities.HoldoutStatusRunning, New: entities.HoldoutStatusConcluded},
+		{Type: Changed, Entity: TrafficAllocation, FlagKey: "checkout", RuleKey: "global_holdout",
+			Old: []Allocation{{VariationKey: "off", EndOfRange: 500}}, New: []Allocation{{VariationKey: "off", EndOfRange: 1000}}},
+		{Type: Removed, Entity: Holdout, FlagKey: "search", RuleKey: "global_holdout"},
+	}, diff.Changes)
+	s.Equal([]string{"checkout", "search"}, diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestAddedHoldoutWithoutFlags() {
+	s.newConfig.setHoldout(newTestHoldout())
+
+	diff := Compare(s.oldConfig, s.newConfig)
+	s.Equal([]Change{{Type: Added, Entity: Holdout, RuleKey: "global_holdout"}}, diff.Changes)
+	s.Empty(diff.FlagKeys())
+}
+
+func (s *DiffTestSuite) TestChangeString() {
+	s.Equal("added flag search", Change{Type: Added, Entity: Flag, FlagKey: "search"}.String())
+	s.Equal(`changed variable checkout/limit defaultValue: "10" -> "15"`,
+		Change{Type: Changed, Entity: Variable, FlagKey: "checkout", Key: "limit", Field: "defaultValue", Old: "10", New: "15"}.String())
+	s.Equal(`changed traffic_allocation checkout/checkout_test [{"variationKey":"on","endOfRange":10000}] -> []`,
+		Change{Type: Changed, Entity: TrafficAllocation, FlagKey: "checkout", RuleKey: "checkout_test",
+			Old: []Allocation{{VariationKey: "on", EndOfRange: 10000}}, New: []Allocation{}}.String())
+	s.Equal("changed rule checkout/checkout_test audienceConditions: [\"3001\"] -> <none>",
+		Change{Type: Changed, Entity: Rule, FlagKey: "checkout", RuleKey: "checkout_test", Field: "audienceConditions",
+			Old: []string{"3001"}}.String())
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
@@ -0,0 +1,317 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package builder provides a fluent builder of datafiles for tests and local development
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// firstID is the first of the IDs generated for the entities of a datafile
+const firstID = 10000
+
+// DatafileBuilder builds a datafile. IDs are generated in the order the entities are added, so the same calls always
+// produce the same datafile. Errors, such as an unknown audience or variable, are returned when the datafile is built.
+type DatafileBuilder struct {
+	settings     datafileEntities.Datafile
+	lastID       int
+	attributes   []datafileEntities.Attribute
+	audiences    []datafileEntities.Audience
+	events       []datafileEntities.Event
+	integrations []datafileEntities.Integration
+	flags        []*FlagBuilder
+	groups       []*GroupBuilder
+	keys         map[string]bool
+	err          error
+}
+
+// New returns a DatafileBuilder with generated account and project IDs and revision "1"
+func New() *DatafileBuilder {
+	b := &DatafileBuilder{keys: map[string]bool{}}
+	b.settings = datafileEntities.Datafile{
+		Version:           "4",
+		Revision:          "1",
+		AccountID:         b.nextID(),
+		ProjectID:         b.nextID(),
+		SendFlagDecisions: true,
+	}
+	return b
+}
+
+// Revision sets the revision of the datafile
+func (b *DatafileBuilder) Revision(revision string) *DatafileBuilder {
+	b.settings.Revision = revision
+	return b
+}
+
+// AccountID sets the account ID of the datafile
+func (b *DatafileBuilder) AccountID(accountID string) *DatafileBuilder {
+	b.settings.AccountID = accountID
+	return b
+}
+
+// ProjectID sets the project ID of the datafile
+func (b *DatafileBuilder) ProjectID(projectID string) *DatafileBuilder {
+	b.settings.ProjectID = projectID
+	return b
+}
+
+// SDKKey sets the SDK key of the datafile
+func (b *DatafileBuilder) SDKKey(sdkKey string) *DatafileBuilder {
+	b.settings.SDKKey = sdkKey
+	return b
+}
+
+// EnvironmentKey sets the environment key of the datafile
+func (b *DatafileBuilder) EnvironmentKey(environmentKey string) *DatafileBuilder {
+	b.settings.EnvironmentKey = environmentKey
+	return b
+}
+
+// AnonymizeIP sets whether the IP of the visitors is anonymized
+func (b *DatafileBuilder) AnonymizeIP(anonymizeIP bool) *DatafileBuilder {
+	b.settings.AnonymizeIP = anonymizeIP
+	return b
+}
+
+// BotFiltering sets whether bots are filtered out
+func (b *DatafileBuilder) BotFiltering(botFiltering bool) *DatafileBuilder {
+	b.settings.BotFiltering = botFiltering
+	return b
+}
+
+// SendFlagDecisions sets whether impressions are sent for rollout decisions. It is true by default.
+func (b *DatafileBuilder) SendFlagDecisions(sendFlagDecisions bool) *DatafileBuilder {
+	b.settings.SendFlagDecisions = sendFlagDecisions
+	return b
+}
+
+// ODPIntegration adds the ODP integration with the given public key and host
+func (b *DatafileBuilder) ODPIntegration(publicKey, host string) *DatafileBuilder {
+	key := "odp"
+	b.integrations = append(b.integrations, datafileEntities.Integration{Key: &key, PublicKey: publicKey, Host: host})
+	return b
+}
+
+// Attribute adds attributes of the given keys. Attributes used by audience conditions are added automatically.
+func (b *DatafileBuilder) Attribute(keys ...string) *DatafileBuilder {
+	for _, key := range keys {
+		if b.hasAttribute(key) {
+			continue
+		}
+		b.attributes = append(b.attributes, datafileEntities.Attribute{ID: b.nextID(), Key: key})
+	}
+	return b
+}
+
+// Audience adds an audience with the given condition tree, built with And, Or, Not and the leaf conditions
+func (b *DatafileBuilder) Audience(name string, conditions interface{}) *DatafileBuilder {
+	if b.claimKey("audience", name) {
+		b.audiences = append(b.audiences, datafileEntities.Audience{ID: b.nextID(), Name: name, Conditions: conditions})
+		b.Attribute(attributeNames(conditions)...)
+	}
+	return b
+}
+
+// Event adds an event of the given key
+func (b *DatafileBuilder) Event(key string) *DatafileBuilder {
+	if b.claimKey("event", key) {
+		b.events = append(b.events, datafileEntities.Event{ID: b.nextID(), Key: key, ExperimentIds: []string{}})
+	}
+	return b
+}
+
+// Flag adds a flag of the given key and returns its builder. The rollout of the flag ends with an "everyone else"
+// rule, which is disabled unless it is changed through FlagBuilder.EveryoneElse.
+func (b *DatafileBuilder) Flag(key string) *FlagBuilder {
+	b.claimKey("flag", key)
+	f := &FlagBuilder{
+		builder:   b,
+		id:        b.nextID(),
+		key:       key,
+		rolloutID: b.nextID(),
+	}
+	f.everyoneElse = newRuleBuilder(f, "everyone_else_"+key)
+	b.flags = append(b.flags, f)
+	return f
+}
+
+// Group adds a mutual exclusion group and returns its builder. The key only identifies the group in the builder.
+func (b *DatafileBuilder) Group(key string) *GroupBuilder {
+	b.claimKey("group", key)
+	g := &GroupBuilder{builder: b, id: b.nextID(), key: key}
+	b.groups = append(b.groups, g)
+	return g
+}
+
+// Datafile builds the datafile
+func (b *DatafileBuilder) Datafile() (*datafileEntities.Datafile, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	datafile := b.settings
+	datafile.Attributes = append([]datafileEntities.Attribute{}, b.attributes...)
+	datafile.TypedAudiences = append([]datafileEntities.Audience{}, b.audiences...)
+	datafile.Audiences = []datafileEntities.Audience{}
+	datafile.Events = append([]datafileEntities.Event{}, b.events...)
+	datafile.Integrations = append([]datafileEntities.Integration{}, b.integrations...)
+	datafile.Experiments = []datafileEntities.Experiment{}
+	datafile.FeatureFlags = []datafileEntities.FeatureFlag{}
+	datafile.Rollouts = []datafileEntities.Rollout{}
+	datafile.Groups = []datafileEntities.Group{}
+	datafile.Holdouts = []datafileEntities.Holdout{}
+	datafile.Variables = []string{}
+
+	groupExperiments := map[*GroupBuilder][]datafileEntities.Experiment{}
+	for _, f := range b.flags {
+		featureFlag, rollout, err := f.build()
+		if err != nil {
+			return nil, err
+		}
+		datafile.FeatureFlags = append(datafile.FeatureFlags, featureFlag)
+		datafile.Rollouts = append(datafile.Rollouts, rollout)
+
+		for _, e := range f.experiments {
+			experiment, err := e.build()
+			if err != nil {
+				return nil, err
+			}
+			if e.group != nil {
+				groupExperiments[e.group] = append(groupExperiments[e.group], experiment)
+			} else {
+				datafile.Experiments = append(datafile.Experiments, experiment)
+			}
+		}
+	}
+
+	for _, g := range b.groups {
+		group, err := g.build(groupExperiments[g])
+		if err != nil {
+			return nil, err
+		}
+		datafile.Groups = append(datafile.Groups, group)
+	}
+	return &datafile, nil
+}
+
+// JSON builds the datafile and returns its JSON encoding
+func (b *DatafileBuilder) JSON() ([]byte, error) {
+	datafile, err := b.Datafile()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(datafile)
+}
+
+// ProjectConfig builds the datafile and returns its project config
+func (b *DatafileBuilder) ProjectConfig() (*datafileprojectconfig.DatafileProjectConfig, error) {
+	jsonDatafile, err := b.JSON()
+	if err != nil {
+		return nil, err
+	}
+	return datafileprojectconfig.NewDatafileProjectConfig(jsonDatafile, logging.GetLogger(b.settings.SDKKey, "DatafileProjectConfig"))
+}
+
+// ConfigManager builds the datafile and returns a static config manager serving its project config
+func (b *DatafileBuilder) ConfigManager() (*config.StaticProjectConfigManager, error) {
+	projectConfig, err := b.ProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.NewStaticProjectConfigManager(projectConfig, logging.GetLogger(b.settings.SDKKey, "StaticProjectConfigManager")), nil
+}
+
+func (b *DatafileBuilder) nextID() string {
+	b.lastID++
+	return strconv.Itoa(firstID + b.lastID)
+}
+
+// fail keeps the first error, which is returned when the datafile is built
+func (b *DatafileBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// claimKey fails the builder if the key of the given kind was already used
+func (b *DatafileBuilder) claimKey(kind, key string) bool {
+	if key == "" {
+		b.fail(fmt.Errorf("%s key is empty", kind))
+		return false
+	}
+	if b.keys[kind+":"+key] {
+		b.fail(fmt.Errorf("%s %q is defined twice", kind, key))
+		return false
+	}
+	b.keys[kind+":"+key] = true
+	return true
+}
+
+func (b *DatafileBuilder) hasAttribute(key string) bool {
+	for _, attribute := range b.attributes {
+		if attribute.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// audienceConditions replaces the audience names of the condition tree with the audience IDs
+func (b *DatafileBuilder) audienceConditions(conditions interface{}) (interface{}, error) {
+	switch value := conditions.(type) {
+	case string:
+		if isOperator(value) {
+			return value, nil
+		}
+		for _, audience := range b.audiences {
+			if audience.Name == value {
+				return audience.ID, nil
+			}
+		}
+		return nil, fmt.Errorf("audience %q is not defined", value)
+	case []interface{}:
+		mapped := make([]interface{}, 0, len(value))
+		for _, node := range value {
+			mappedNode, err := b.audienceConditions(node)
+			if err != nil {
+				return nil, err
+			}
+			mapped = append(mapped, mappedNode)
+		}
+		return mapped, nil
+	case []string:
+		nodes := make([]interface{}, 0, len(value))
+		for _, node := range value {
+			nodes = append(nodes, node)
+		}
+		return b.audienceConditions(nodes)
+	default:
+		return nil, errors.New("audience conditions must be built from audience names with And, Or and Not")
+	}
+}
@@ -0,0 +1,235 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+)
+
+func checkoutDatafile() *DatafileBuilder {
+	b := New().Revision("7").SDKKey("builder_sdk_key")
+	b.Audience("adults", Match("age", "ge", 18))
+	b.Audience("beta_users", And(Exact("plan", "beta"), Not(Exists("blocked"))))
+	b.Event("purchase")
+
+	checkout := b.Flag("checkout").
+		Variable("color", entities.String, "red").
+		Variable("limit", entities.Integer, 10).
+		Variable("settings", entities.JSON, map[string]interface{}{"theme": "dark"})
+	checkout.Experiment("checkout_test").
+		Audiences("adults").
+		Variation("blue", true, Vars{"color": "blue"}).
+		Variation("green", true, Vars{"color": "green", "limit": 20}).
+		Weights(1, 0).
+		Whitelist("forced_user", "green")
+	checkout.Rule("beta").
+		Audiences("beta_users").
+		Enabled(true).
+		Variables(Vars{"settings": `{"theme":"light"}`})
+	return b
+}
+
+type noopDispatcher struct{}
+
+func (d *noopDispatcher) DispatchEvent(event event.LogEvent) (bool, error) {
+	return true, nil
+}
+
+type DatafileBuilderTestSuite struct {
+	suite.Suite
+}
+
+func (s *DatafileBuilderTestSuite) TestDatafile() {
+	datafile, err := checkoutDatafile().Datafile()
+	s.Require().NoError(err)
+
+	s.Equal("4", datafile.Version)
+	s.Equal("7", datafile.Revision)
+	s.Equal([]datafileEntities.Attribute{{ID: "10004", Key: "age"}, {ID: "10006", Key: "plan"}, {ID: "10007", Key: "blocked"}}, datafile.Attributes)
+	s.Len(datafile.TypedAudiences, 2)
+	s.Equal([]datafileEntities.Event{{ID: "10008", Key: "purchase", ExperimentIds: []string{}}}, datafile.Events)
+
+	s.Len(datafile.FeatureFlags, 1)
+	flag := datafile.FeatureFlags[0]
+	s.Equal([]string{datafile.Experiments[0].ID}, flag.ExperimentIDs)
+	s.Equal(entities.String, flag.Variables[2].Type)
+	s.Equal(entities.JSON, flag.Variables[2].SubType)
+	s.Equal(`{"theme":"dark"}`, flag.Variables[2].DefaultValue)
+
+	experiment := datafile.Experiments[0]
+	s.Equal([]string{"10003"}, experiment.AudienceIds)
+	s.Equal([]interface{}{"or", "10003"}, experiment.AudienceConditions)
+	s.Equal([]datafileEntities.TrafficAllocation{{EntityID: experiment.Variations[0].ID, EndOfRange: 10000}, {EntityID: experiment.Variations[1].ID, EndOfRange: 10000}}, experiment.TrafficAllocation)
+	s.Equal(map[string]string{"forced_user": "green"}, experiment.ForcedVariations)
+	s.Equal([]datafileEntities.VariationVariable{{ID: flag.Variables[0].ID, Value: "green"}, {ID: flag.Variables[1].ID, Value: "20"}}, experiment.Variations[1].Variables)
+
+	rollout := datafile.Rollouts[0]
+	s.Equal(flag.RolloutID, rollout.ID)
+	s.Len(rollout.Experiments, 2)
+	s.Equal("beta", rollout.Experiments[0].Key)
+	s.Equal("on", rollout.Experiments[0].Variations[0].Key)
+	s.Equal("everyone_else_checkout", rollout.Experiments[1].Key)
+	s.False(rollout.Experiments[1].Variations[0].FeatureEnabled)
+}
+
+func (s *DatafileBuilderTestSuite) TestSameCallsBuildSameDatafile() {
+	first, err := checkoutDatafile().JSON()
+	s.NoError(err)
+	second, err := checkoutDatafile().JSON()
+	s.NoError(err)
+	s.Equal(string(first), string(second))
+
+	b := checkoutDatafile()
+	first, _ = b.JSON()
+	second, _ = b.JSON()
+	s.Equal(string(first), string(second))
+}
+
+func (s *DatafileBuilderTestSuite) TestDecide() {
+	configManager, err := checkoutDatafile().ConfigManager()
+	s.Require().NoError(err)
+	optimizelyClient, err := (&client.OptimizelyFactory{}).Client(client.WithConfigManager(configManager), client.WithOdpDisabled(true), client.WithEventDispatcher(&noopDispatcher{}))
+	s.Require().NoError(err)
+	defer optimizelyClient.Close()
+
+	adult := optimizelyClient.CreateUserContext("adult", map[string]interface{}{"age": 30})
+	decision := adult.Decide("checkout", nil)
+	s.Equal("blue", decision.VariationKey)
+	s.Equal("checkout_test", decision.RuleKey)
+	s.Equal("blue", decision.Variables.ToMap()["color"])
+	s.Equal("7", decision.Revision)
+
+	forced := optimizelyClient.CreateUserContext("forced_user", map[string]interface{}{"age": 30})
+	s.Equal("green", forced.Decide("checkout", nil).VariationKey)
+
+	beta := optimizelyClient.CreateUserContext("beta", map[string]interface{}{"age": 10, "plan": "beta"})
+	decision = beta.Decide("checkout", nil)
+	s.True(decision.Enabled)
+	s.Equal("beta", decision.RuleKey)
+	s.Equal(map[string]interface{}{"theme": "light"}, decision.Variables.ToMap()["settings"])
+
+	blocked := optimizelyClient.CreateUserContext("blocked", map[string]interface{}{"age": 10, "plan": "beta", "blocked": true})
+	decision = blocked.Decide("checkout", nil)
+	s.False(decision.Enabled)
+	s.Equal("everyone_else_checkout", decision.RuleKey)
+	s.Equal("red", decision.Variables.ToMap()["color"])
+}
+
+func (s *DatafileBuilderTestSuite) TestGroups() {
+	b := New()
+	group := b.Group("mutex")
+	b.Flag("flag_a").Experiment("exp_a").Variation("on", true, nil).InGroup(group, 25)
+	b.Flag("flag_b").Experiment("exp_b").Variation("on", true, nil).InGroup(group, 50)
+	b.Flag("flag_c").Experiment("exp_c").Variation("on", true, nil).Percentage(10)
+
+	datafile, err := b.Datafile()
+	s.Require().NoError(err)
+	s.Len(datafile.Experiments, 1)
+	s.Equal("exp_c", datafile.Experiments[0].Key)
+	s.Equal(1000, datafile.Experiments[0].TrafficAllocation[0].EndOfRange)
+
+	s.Len(datafile.Groups, 1)
+	s.Equal("random", datafile.Groups[0].Policy)
+	s.Len(datafile.Groups[0].Experiments, 2)
+	s.Equal([]int{2500, 7500}, []int{datafile.Groups[0].TrafficAllocation[0].EndOfRange, datafile.Groups[0].TrafficAllocation[1].EndOfRange})
+
+	projectConfig, err := b.ProjectConfig()
+	s.Require().NoError(err)
+	experiment, err := projectConfig.GetExperimentByKey("exp_b")
+	s.NoError(err)
+	s.Equal(datafile.Groups[0].ID, experiment.GroupID)
+}
+
+func (s *DatafileBuilderTestSuite) TestErrors() {
+	tests := map[string]func(b *DatafileBuilder){
+		`experiment "e": audience "unknown" is not defined`: func(b *DatafileBuilder) {
+			b.Flag("f").Experiment("e").Variation("v", true, nil).Audiences("unknown")
+		},
+		`variable "size" of variation "v" is not defined by flag "f"`: func(b *DatafileBuilder) {
+			b.Flag("f").Experiment("e").Variation("v", true, Vars{"size": 1})
+		},
+		`flag "f" is defined twice`: func(b *DatafileBuilder) {
+			b.Flag("f")
+			b.Flag("f")
+		},
+		`experiment "e" has 1 variations but 2 weights`: func(b *DatafileBuilder) {
+			b.Flag("f").Experiment("e").Variation("v", true, nil).Weights(1, 1)
+		},
+		`experiment "e" has no variations`: func(b *DatafileBuilder) {
+			b.Flag("f").Experiment("e")
+		},
+		`whitelisted variation "w" is not a variation of experiment "e"`: func(b *DatafileBuilder) {
+			b.Flag("f").Experiment("e").Variation("v", true, nil).Whitelist("user", "w")
+		},
+		`experiments of group "g" get 150 percent of its traffic`: func(b *DatafileBuilder) {
+			g := b.Group("g")
+			b.Flag("f").Experiment("e1").Variation("v", true, nil).InGroup(g, 100)
+			b.Flag("f2").Experiment("e2").Variation("v", true, nil).InGroup(g, 50)
+		},
+		`variable "limit" of flag "f": value ten is not a valid integer`: func(b *DatafileBuilder) {
+			b.Flag("f").Variable("limit", entities.Integer, "ten")
+		},
+		`rule "r" of flag "f": percentage 120 is not between 0 and 100`: func(b *DatafileBuilder) {
+			b.Flag("f").Rule("r").Percentage(120)
+		},
+	}
+
+	for expected, build := range tests {
+		b := New()
+		build(b)
+		_, err := b.Datafile()
+		s.EqualError(err, expected)
+		_, err = b.ConfigManager()
+		s.Error(err)
+	}
+}
+
+func TestDatafileBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(DatafileBuilderTestSuite))
+}
+
+func TestConditions(t *testing.T) {
+	conditions := And(Or(Match("age", "gt", 1), Qualified("segment")), Not(Exists("blocked")))
+	assert.Equal(t, []interface{}{
+		"and",
+		[]interface{}{"or",
+			map[string]interface{}{"type": "custom_attribute", "name": "age", "match": "gt", "value": 1},
+			map[string]interface{}{"type": "third_party_dimension", "name": "odp.audiences", "match": "qualified", "value": "segment"},
+		},
+		[]interface{}{"not", map[string]interface{}{"type": "custom_attribute", "name": "blocked", "match": "exists"}},
+	}, conditions)
+	assert.Equal(t, []string{"age", "blocked"}, attributeNames(conditions))
+}
+
+func TestODPIntegration(t *testing.T) {
+	b := New().ODPIntegration("public-key", "https://odp.example.com")
+	b.Audience("qualified", Qualified("segment"))
+
+	projectConfig, err := b.ProjectConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "public-key", projectConfig.GetPublicKeyForODP())
+	assert.Equal(t, "https://odp.example.com", projectConfig.GetHostForODP())
+	assert.Equal(t, []string{"segment"}, projectConfig.GetSegmentList())
+}
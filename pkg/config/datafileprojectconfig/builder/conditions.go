@@ -0,0 +1,82 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package builder
+
+const (
+	customAttributeType = "custom_attribute"
+	thirdPartyType      = "third_party_dimension"
+	odpAudiences        = "odp.audiences"
+)
+
+// And returns a condition tree matching when all the given conditions match. The conditions are either leaf
+// conditions, other trees or, for experiments and rules, audience names.
+func And(conditions ...interface{}) []interface{} {
+	return append([]interface{}{"and"}, conditions...)
+}
+
+// Or returns a condition tree matching when any of the given conditions match
+func Or(conditions ...interface{}) []interface{} {
+	return append([]interface{}{"or"}, conditions...)
+}
+
+// Not returns a condition tree matching when the given condition does not match
+func Not(condition interface{}) []interface{} {
+	return []interface{}{"not", condition}
+}
+
+// Match returns a leaf condition comparing the user attribute of the given name with the given match type, such as
+// "exact", "gt", "le", "substring" or "semver_ge"
+func Match(name, match string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": customAttributeType, "name": name, "match": match, "value": value}
+}
+
+// Exact returns a leaf condition matching when the user attribute of the given name equals the value
+func Exact(name string, value interface{}) map[string]interface{} {
+	return Match(name, "exact", value)
+}
+
+// Exists returns a leaf condition matching when the user has the attribute of the given name
+func Exists(name string) map[string]interface{} {
+	return map[string]interface{}{"type": customAttributeType, "name": name, "match": "exists"}
+}
+
+// Qualified returns a leaf condition matching when the user qualifies for the given ODP segment
+func Qualified(segment string) map[string]interface{} {
+	return map[string]interface{}{"type": thirdPartyType, "name": odpAudiences, "match": "qualified", "value": segment}
+}
+
+func isOperator(value string) bool {
+	return value == "and" || value == "or" || value == "not"
+}
+
+// attributeNames returns the names of the custom attributes used by the condition tree
+func attributeNames(conditions interface{}) []string {
+	names := []string{}
+	switch value := conditions.(type) {
+	case map[string]interface{}:
+		if value["type"] == customAttributeType {
+			if name, ok := value["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	case []interface{}:
+		for _, node := range value {
+			names = append(names, attributeNames(node)...)
+		}
+	}
+	return names
+}
@@ -0,0 +1,531 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package builder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// maxTrafficAllocation is the end of the bucketing range
+const maxTrafficAllocation = 10000
+
+// Vars holds the variable values of a variation by variable key
+type Vars map[string]interface{}
+
+// FlagBuilder builds a flag with its variables, experiments and rollout
+type FlagBuilder struct {
+	builder      *DatafileBuilder
+	id           string
+	key          string
+	rolloutID    string
+	variables    []datafileEntities.Variable
+	experiments  []*ExperimentBuilder
+	rules        []*RuleBuilder
+	everyoneElse *RuleBuilder
+}
+
+// ExperimentBuilder builds an experiment of a flag. Variations share the traffic of the experiment equally unless
+// weights are given.
+type ExperimentBuilder struct {
+	flag               *FlagBuilder
+	id                 string
+	key                string
+	layerID            string
+	status             string
+	variations         []variation
+	weights            []int
+	percentage         float64
+	audienceConditions interface{}
+	whitelist          map[string]string
+	group              *GroupBuilder
+	groupPercentage    float64
+}
+
+// RuleBuilder builds a targeted delivery rule of the rollout of a flag
+type RuleBuilder struct {
+	flag               *FlagBuilder
+	id                 string
+	key                string
+	variation          variation
+	percentage         float64
+	audienceConditions interface{}
+}
+
+// GroupBuilder builds a mutual exclusion group, whose traffic is split between its experiments
+type GroupBuilder struct {
+	builder     *DatafileBuilder
+	id          string
+	key         string
+	experiments []*ExperimentBuilder
+}
+
+type variation struct {
+	id             string
+	key            string
+	featureEnabled bool
+	vars           Vars
+}
+
+// Variable adds a variable to the flag. JSON variables take either a JSON string or a value which is encoded.
+func (f *FlagBuilder) Variable(key string, variableType entities.VariableType, defaultValue interface{}) *FlagBuilder {
+	if !f.builder.claimKey("variable of flag "+f.key, key) {
+		return f
+	}
+	value, err := formatValue(defaultValue, variableType)
+	if err != nil {
+		f.builder.fail(fmt.Errorf("variable %q of flag %q: %w", key, f.key, err))
+		return f
+	}
+
+	variable := datafileEntities.Variable{ID: f.builder.nextID(), Key: key, Type: variableType, DefaultValue: value}
+	if variableType == entities.JSON {
+		variable.Type = entities.String
+		variable.SubType = entities.JSON
+	}
+	f.variables = append(f.variables, variable)
+	return f
+}
+
+// Experiment adds an experiment to the flag and returns its builder. Experiments are evaluated in the order they are
+// added, before the rollout.
+func (f *FlagBuilder) Experiment(key string) *ExperimentBuilder {
+	f.builder.claimKey("experiment", key)
+	e := &ExperimentBuilder{
+		flag:       f,
+		id:         f.builder.nextID(),
+		key:        key,
+		layerID:    f.builder.nextID(),
+		status:     string(entities.ExperimentStatusRunning),
+		percentage: 100,
+		whitelist:  map[string]string{},
+	}
+	f.experiments = append(f.experiments, e)
+	return e
+}
+
+// Rule adds a targeted delivery rule to the rollout of the flag and returns its builder. Rules are evaluated in the
+// order they are added, before the "everyone else" rule.
+func (f *FlagBuilder) Rule(key string) *RuleBuilder {
+	r := newRuleBuilder(f, key)
+	f.rules = append(f.rules, r)
+	return r
+}
+
+// EveryoneElse returns the builder of the last rule of the rollout, which applies to every user
+func (f *FlagBuilder) EveryoneElse() *RuleBuilder {
+	return f.everyoneElse
+}
+
+func (f *FlagBuilder) build() (datafileEntities.FeatureFlag, datafileEntities.Rollout, error) {
+	featureFlag := datafileEntities.FeatureFlag{
+		ID:            f.id,
+		Key:           f.key,
+		RolloutID:     f.rolloutID,
+		ExperimentIDs: []string{},
+		Variables:     append([]datafileEntities.Variable{}, f.variables...),
+	}
+	for _, e := range f.experiments {
+		featureFlag.ExperimentIDs = append(featureFlag.ExperimentIDs, e.id)
+	}
+
+	rollout := datafileEntities.Rollout{ID: f.rolloutID, Experiments: []datafileEntities.Experiment{}}
+	for _, r := range append(append([]*RuleBuilder{}, f.rules...), f.everyoneElse) {
+		rule, err := r.build()
+		if err != nil {
+			return featureFlag, rollout, err
+		}
+		rollout.Experiments = append(rollout.Experiments, rule)
+	}
+	return featureFlag, rollout, nil
+}
+
+// buildVariation returns the datafile variation with the variable values in the order of the flag variables
+func (f *FlagBuilder) buildVariation(v variation) (datafileEntities.Variation, error) {
+	datafileVariation := datafileEntities.Variation{
+		ID:             v.id,
+		Key:            v.key,
+		FeatureEnabled: v.featureEnabled,
+		Variables:      []datafileEntities.VariationVariable{},
+	}
+
+	for key := range v.vars {
+		if f.variable(key) == nil {
+			return datafileVariation, fmt.Errorf("variable %q of variation %q is not defined by flag %q", key, v.key, f.key)
+		}
+	}
+	for _, variable := range f.variables {
+		value, ok := v.vars[variable.Key]
+		if !ok {
+			continue
+		}
+		variableType := variable.Type
+		if variable.SubType == entities.JSON {
+			variableType = entities.JSON
+		}
+		formatted, err := formatValue(value, variableType)
+		if err != nil {
+			return datafileVariation, fmt.Errorf("variable %q of variation %q: %w", variable.Key, v.key, err)
+		}
+		datafileVariation.Variables = append(datafileVariation.Variables, datafileEntities.VariationVariable{ID: variable.ID, Value: formatted})
+	}
+	return datafileVariation, nil
+}
+
+func (f *FlagBuilder) variable(key string) *datafileEntities.Variable {
+	for i := range f.variables {
+		if f.variables[i].Key == key {
+			return &f.variables[i]
+		}
+	}
+	return nil
+}
+
+// Variation adds a variation with the given variable values to the experiment
+func (e *ExperimentBuilder) Variation(key string, featureEnabled bool, vars Vars) *ExperimentBuilder {
+	if e.flag.builder.claimKey("variation of experiment "+e.key, key) {
+		e.variations = append(e.variations, variation{id: e.flag.builder.nextID(), key: key, featureEnabled: featureEnabled, vars: vars})
+	}
+	return e
+}
+
+// Weights sets the relative share of traffic of each variation, in the order the variations are added
+func (e *ExperimentBuilder) Weights(weights ...int) *ExperimentBuilder {
+	e.weights = weights
+	return e
+}
+
+// Percentage sets the percentage of the users who are bucketed into the experiment. It is 100 by default.
+func (e *ExperimentBuilder) Percentage(percentage float64) *ExperimentBuilder {
+	e.percentage = percentage
+	return e
+}
+
+// Audiences targets the experiment at the users in any of the audiences of the given names
+func (e *ExperimentBuilder) Audiences(names ...string) *ExperimentBuilder {
+	e.audienceConditions = audiencesCondition(names)
+	return e
+}
+
+// AudienceConditions targets the experiment at the users matching the tree of audience names built with And, Or and
+// Not
+func (e *ExperimentBuilder) AudienceConditions(conditions interface{}) *ExperimentBuilder {
+	e.audienceConditions = conditions
+	return e
+}
+
+// Status sets the status of the experiment. It is "Running" by default.
+func (e *ExperimentBuilder) Status(status entities.ExperimentStatus) *ExperimentBuilder {
+	e.status = string(status)
+	return e
+}
+
+// Whitelist forces the user into the variation of the given key
+func (e *ExperimentBuilder) Whitelist(userID, variationKey string) *ExperimentBuilder {
+	e.whitelist[userID] = variationKey
+	return e
+}
+
+// InGroup adds the experiment to the group, which assigns the given percentage of its traffic to the experiment
+func (e *ExperimentBuilder) InGroup(group *GroupBuilder, percentage float64) *ExperimentBuilder {
+	if e.group != nil {
+		e.flag.builder.fail(fmt.Errorf("experiment %q is added to more than one group", e.key))
+		return e
+	}
+	e.group = group
+	e.groupPercentage = percentage
+	group.experiments = append(group.experiments, e)
+	return e
+}
+
+func (e *ExperimentBuilder) build() (datafileEntities.Experiment, error) {
+	experiment := datafileEntities.Experiment{
+		ID:               e.id,
+		Key:              e.key,
+		LayerID:          e.layerID,
+		Status:           e.status,
+		Variations:       []datafileEntities.Variation{},
+		ForcedVariations: map[string]string{},
+	}
+	if len(e.variations) == 0 {
+		return experiment, fmt.Errorf("experiment %q has no variations", e.key)
+	}
+
+	weights := e.weights
+	if weights == nil {
+		weights = make([]int, len(e.variations))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != len(e.variations) {
+		return experiment, fmt.Errorf("experiment %q has %d variations but %d weights", e.key, len(e.variations), len(weights))
+	}
+
+	entityIDs := []string{}
+	for _, v := range e.variations {
+		datafileVariation, err := e.flag.buildVariation(v)
+		if err != nil {
+			return experiment, err
+		}
+		experiment.Variations = append(experiment.Variations, datafileVariation)
+		entityIDs = append(entityIDs, v.id)
+	}
+
+	var err error
+	if experiment.TrafficAllocation, err = allocate(entityIDs, weights, e.percentage); err != nil {
+		return experiment, fmt.Errorf("experiment %q: %w", e.key, err)
+	}
+	if experiment.AudienceIds, experiment.AudienceConditions, err = e.flag.builder.targeting(e.audienceConditions); err != nil {
+		return experiment, fmt.Errorf("experiment %q: %w", e.key, err)
+	}
+
+	for userID, variationKey := range e.whitelist {
+		if !e.hasVariation(variationKey) {
+			return experiment, fmt.Errorf("whitelisted variation %q is not a variation of experiment %q", variationKey, e.key)
+		}
+		experiment.ForcedVariations[userID] = variationKey
+	}
+	return experiment, nil
+}
+
+func (e *ExperimentBuilder) hasVariation(key string) bool {
+	for _, v := range e.variations {
+		if v.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func newRuleBuilder(f *FlagBuilder, key string) *RuleBuilder {
+	f.builder.claimKey("rule of flag "+f.key, key)
+	return &RuleBuilder{
+		flag:       f,
+		id:         f.builder.nextID(),
+		key:        key,
+		variation:  variation{id: f.builder.nextID(), key: "off"},
+		percentage: 100,
+	}
+}
+
+// Enabled sets whether the flag is enabled for the users of the rule. Rules are disabled by default.
+func (r *RuleBuilder) Enabled(enabled bool) *RuleBuilder {
+	r.variation.featureEnabled = enabled
+	r.variation.key = "off"
+	if enabled {
+		r.variation.key = "on"
+	}
+	return r
+}
+
+// Variables sets the variable values for the users of the rule
+func (r *RuleBuilder) Variables(vars Vars) *RuleBuilder {
+	r.variation.vars = vars
+	return r
+}
+
+// Percentage sets the percentage of the users of the rule who get its variation. It is 100 by default.
+func (r *RuleBuilder) Percentage(percentage float64) *RuleBuilder {
+	r.percentage = percentage
+	return r
+}
+
+// Audiences targets the rule at the users in any of the audiences of the given names
+func (r *RuleBuilder) Audiences(names ...string) *RuleBuilder {
+	r.audienceConditions = audiencesCondition(names)
+	return r
+}
+
+// AudienceConditions targets the rule at the users matching the tree of audience names built with And, Or and Not
+func (r *RuleBuilder) AudienceConditions(conditions interface{}) *RuleBuilder {
+	r.audienceConditions = conditions
+	return r
+}
+
+func (r *RuleBuilder) build() (datafileEntities.Experiment, error) {
+	rule := datafileEntities.Experiment{
+		ID:               r.id,
+		Key:              r.key,
+		LayerID:          r.flag.rolloutID,
+		Status:           string(entities.ExperimentStatusRunning),
+		ForcedVariations: map[string]string{},
+	}
+
+	datafileVariation, err := r.flag.buildVariation(r.variation)
+	if err != nil {
+		return rule, err
+	}
+	rule.Variations = []datafileEntities.Variation{datafileVariation}
+
+	if rule.TrafficAllocation, err = allocate([]string{r.variation.id}, []int{1}, r.percentage); err != nil {
+		return rule, fmt.Errorf("rule %q of flag %q: %w", r.key, r.flag.key, err)
+	}
+	if rule.AudienceIds, rule.AudienceConditions, err = r.flag.builder.targeting(r.audienceConditions); err != nil {
+		return rule, fmt.Errorf("rule %q of flag %q: %w", r.key, r.flag.key, err)
+	}
+	return rule, nil
+}
+
+func (g *GroupBuilder) build(experiments []datafileEntities.Experiment) (datafileEntities.Group, error) {
+	group := datafileEntities.Group{
+		ID:          g.id,
+		Policy:      "random",
+		Experiments: experiments,
+	}
+
+	entityIDs := []string{}
+	percentages := []float64{}
+	total := 0.0
+	for _, e := range g.experiments {
+		entityIDs = append(entityIDs, e.id)
+		percentages = append(percentages, e.groupPercentage)
+		total += e.groupPercentage
+	}
+	if total > 100 {
+		return group, fmt.Errorf("experiments of group %q get %v percent of its traffic", g.key, total)
+	}
+
+	group.TrafficAllocation = []datafileEntities.TrafficAllocation{}
+	sum := 0.0
+	for i, entityID := range entityIDs {
+		sum += percentages[i]
+		group.TrafficAllocation = append(group.TrafficAllocation, datafileEntities.TrafficAllocation{
+			EntityID:   entityID,
+			EndOfRange: percentageToRange(sum),
+		})
+	}
+	return group, nil
+}
+
+// targeting returns the audience IDs and the audience conditions of an experiment or rule
+func (b *DatafileBuilder) targeting(conditions interface{}) (audienceIDs []string, audienceConditions interface{}, err error) {
+	audienceIDs = []string{}
+	if conditions == nil {
+		return audienceIDs, []interface{}{}, nil
+	}
+	if audienceConditions, err = b.audienceConditions(conditions); err != nil {
+		return nil, nil, err
+	}
+	collectAudienceIDs(audienceConditions, &audienceIDs)
+	return audienceIDs, audienceConditions, nil
+}
+
+func collectAudienceIDs(conditions interface{}, audienceIDs *[]string) {
+	switch value := conditions.(type) {
+	case string:
+		if !isOperator(value) {
+			*audienceIDs = append(*audienceIDs, value)
+		}
+	case []interface{}:
+		for _, node := range value {
+			collectAudienceIDs(node, audienceIDs)
+		}
+	}
+}
+
+func audiencesCondition(names []string) interface{} {
+	if len(names) == 0 {
+		return nil
+	}
+	conditions := []interface{}{}
+	for _, name := range names {
+		conditions = append(conditions, name)
+	}
+	return Or(conditions...)
+}
+
+// allocate splits the given percentage of the bucketing range between the entities by their weights
+func allocate(entityIDs []string, weights []int, percentage float64) ([]datafileEntities.TrafficAllocation, error) {
+	if percentage < 0 || percentage > 100 {
+		return nil, fmt.Errorf("percentage %v is not between 0 and 100", percentage)
+	}
+	totalWeight := 0
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("weight %d is negative", weight)
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("weights add up to 0")
+	}
+
+	end := percentageToRange(percentage)
+	allocations := []datafileEntities.TrafficAllocation{}
+	cumulativeWeight := 0
+	for i, entityID := range entityIDs {
+		cumulativeWeight += weights[i]
+		allocations = append(allocations, datafileEntities.TrafficAllocation{
+			EntityID:   entityID,
+			EndOfRange: end * cumulativeWeight / totalWeight,
+		})
+	}
+	return allocations, nil
+}
+
+func percentageToRange(percentage float64) int {
+	return int(math.Round(percentage * maxTrafficAllocation / 100))
+}
+
+// formatValue returns the datafile representation of a variable value
+func formatValue(value interface{}, variableType entities.VariableType) (string, error) {
+	switch variableType {
+	case entities.String:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case entities.Boolean:
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case entities.Integer:
+		switch v := value.(type) {
+		case int:
+			return strconv.Itoa(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case int32:
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+	case entities.Double:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case float32:
+			return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+		case int:
+			return strconv.Itoa(v), nil
+		}
+	case entities.JSON:
+		if s, ok := value.(string); ok {
+			if !json.Valid([]byte(s)) {
+				return "", fmt.Errorf("invalid JSON %q", s)
+			}
+			return s, nil
+		}
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	default:
+		return "", fmt.Errorf("unsupported variable type %q", variableType)
+	}
+	return "", fmt.Errorf("value %v is not a valid %s", value, variableType)
+}
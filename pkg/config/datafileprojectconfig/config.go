@@ -47,6 +47,7 @@ type DatafileProjectConfig struct {
 	featureMap           map[string]entities.Feature
 	groupMap             map[string]entities.Group
 	rollouts             []entities.Rollout
+	holdouts             []entities.Holdout
 	integrations         []entities.Integration
 	segments             []string
 	rolloutMap           map[string]entities.Rollout
@@ -205,6 +206,11 @@ func (c DatafileProjectConfig) GetRolloutList() (rolloutList []entities.Rollout)
 	return c.rollouts
 }
 
+// GetHoldoutList returns an array of all the holdouts
+func (c DatafileProjectConfig) GetHoldoutList() (holdoutList []entities.Holdout) {
+	return c.holdouts
+}
+
 // GetAudienceList returns an array of all the audiences
 func (c DatafileProjectConfig) GetAudienceList() (audienceList []entities.Audience) {
 	for _, audience := range c.audienceMap {
@@ -297,6 +303,7 @@ func NewDatafileProjectConfig(jsonDatafile []byte, logger logging.OptimizelyLogP
 	}
 	eventMap := mappers.MapEvents(datafile.Events)
 	featureMap := mappers.MapFeatures(datafile.FeatureFlags, rolloutMap, experimentIDMap)
+	holdouts := mappers.MapHoldouts(datafile.Holdouts, featureMap)
 	audienceMap, audienceSegmentList := mappers.MapAudiences(append(datafile.TypedAudiences, datafile.Audiences...))
 	flagVariationsMap := mappers.MapFlagVariations(featureMap)
 
@@ -320,6 +327,7 @@ func NewDatafileProjectConfig(jsonDatafile []byte, logger logging.OptimizelyLogP
 		projectID:            datafile.ProjectID,
 		revision:             datafile.Revision,
 		rollouts:             rollouts,
+		holdouts:             holdouts,
 		integrations:         integrations,
 		segments:             audienceSegmentList,
 		rolloutMap:           rolloutMap,
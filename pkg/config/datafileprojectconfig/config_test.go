@@ -409,6 +409,27 @@ func TestGetRolloutList(t *testing.T) {
 	assert.Equal(t, config.rollouts, config.GetRolloutList())
 }
 
+func TestGetHoldoutList(t *testing.T) {
+	config := &DatafileProjectConfig{
+		holdouts: []entities.Holdout{{ID: "6", Key: "holdout_6"}},
+	}
+	assert.Equal(t, config.holdouts, config.GetHoldoutList())
+}
+
+func TestNewDatafileProjectConfigWithHoldouts(t *testing.T) {
+	jsonDatafileStr := `{"version": "4", "featureFlags": [{"id": "21111", "key": "feature_1"}], "holdouts": [{"id": "31111", "key": "global_holdout", "status": "Running"}]}`
+	config, err := NewDatafileProjectConfig([]byte(jsonDatafileStr), logging.GetLogger("", ""))
+	assert.NoError(t, err)
+
+	holdouts := config.GetHoldoutList()
+	assert.Len(t, holdouts, 1)
+	assert.Equal(t, "global_holdout", holdouts[0].Key)
+
+	feature, err := config.GetFeatureByKey("feature_1")
+	assert.NoError(t, err)
+	assert.Equal(t, holdouts, feature.Holdouts)
+}
+
 func TestGetIntegrationListODP(t *testing.T) {
 	jsonDatafileStr := `{"version": "4","integrations": [{"publicKey": "1234", "host": "www.1234.com", "key": "non-odp"},{"publicKey": "123", "host": "www.123.com", "key": "odp"},{"randomKey":"123", "publicKey": "123", "host": "www.123.com", "key": "123"}]}`
 	jsonDatafile := []byte(jsonDatafileStr)
@@ -43,6 +43,26 @@ type Experiment struct {
 	AudienceIds        []string            `json:"audienceIds"`
 	ForcedVariations   map[string]string   `json:"forcedVariations"`
 	AudienceConditions interface{}         `json:"audienceConditions"`
+	Cmab               *Cmab               `json:"cmab,omitempty"`
+}
+
+// Cmab represents the contextual multi-armed bandit settings of an Experiment
+type Cmab struct {
+	AttributeIds      []string `json:"attributeIds"`
+	TrafficAllocation int      `json:"trafficAllocation"`
+}
+
+// Holdout represents a Holdout object from the Optimizely datafile
+type Holdout struct {
+	ID                 string              `json:"id"`
+	Key                string              `json:"key"`
+	Status             string              `json:"status"`
+	Variations         []Variation         `json:"variations"`
+	TrafficAllocation  []TrafficAllocation `json:"trafficAllocation"`
+	AudienceIds        []string            `json:"audienceIds"`
+	AudienceConditions interface{}         `json:"audienceConditions"`
+	IncludedFlags      []string            `json:"includedFlags"`
+	ExcludedFlags      []string            `json:"excludedFlags"`
 }
 
 // Group represents an Group object from the Optimizely datafile
@@ -120,6 +140,7 @@ type Datafile struct {
 	FeatureFlags      []FeatureFlag `json:"featureFlags"`
 	Events            []Event       `json:"events"`
 	Rollouts          []Rollout     `json:"rollouts"`
+	Holdouts          []Holdout     `json:"holdouts"`
 	Integrations      []Integration `json:"integrations"`
 	TypedAudiences    []Audience    `json:"typedAudiences"`
 	Variables         []string      `json:"variables"`
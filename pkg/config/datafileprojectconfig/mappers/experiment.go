@@ -55,21 +55,21 @@ func mapVariation(rawVariation datafileEntities.Variation) entities.Variation {
 	return variation
 }
 
-// Maps the raw experiment entity from the datafile into an SDK Experiment entity
-func mapExperiment(rawExperiment datafileEntities.Experiment) entities.Experiment {
+// Maps the raw audience ids and audience conditions into an audience condition tree
+func mapAudienceConditionTree(audienceIds []string, audienceConditions interface{}) *entities.TreeNode {
 	var audienceConditionTree *entities.TreeNode
 	var err error
-	if rawExperiment.AudienceConditions == nil && len(rawExperiment.AudienceIds) > 0 {
-		audienceConditionTree, err = buildAudienceConditionTree(rawExperiment.AudienceIds)
+	if audienceConditions == nil && len(audienceIds) > 0 {
+		audienceConditionTree, err = buildAudienceConditionTree(audienceIds)
 	} else {
-		switch audienceConditions := rawExperiment.AudienceConditions.(type) {
+		switch conditions := audienceConditions.(type) {
 		case []interface{}:
-			if len(audienceConditions) > 0 {
-				audienceConditionTree, err = buildAudienceConditionTree(audienceConditions)
+			if len(conditions) > 0 {
+				audienceConditionTree, err = buildAudienceConditionTree(conditions)
 			}
 		case string:
-			if audienceConditions != "" {
-				audienceConditionTree, err = buildAudienceConditionTree([]string{audienceConditions})
+			if conditions != "" {
+				audienceConditionTree, err = buildAudienceConditionTree([]string{conditions})
 			}
 		default:
 		}
@@ -78,6 +78,12 @@ func mapExperiment(rawExperiment datafileEntities.Experiment) entities.Experimen
 		// @TODO: handle error
 		func() {}() // cheat the linters
 	}
+	return audienceConditionTree
+}
+
+// Maps the raw experiment entity from the datafile into an SDK Experiment entity
+func mapExperiment(rawExperiment datafileEntities.Experiment) entities.Experiment {
+	audienceConditionTree := mapAudienceConditionTree(rawExperiment.AudienceIds, rawExperiment.AudienceConditions)
 
 	experiment := entities.Experiment{
 		AudienceIds:           rawExperiment.AudienceIds,
@@ -91,6 +97,7 @@ func mapExperiment(rawExperiment datafileEntities.Experiment) entities.Experimen
 		AudienceConditionTree: audienceConditionTree,
 		Whitelist:             rawExperiment.ForcedVariations,
 		IsFeatureExperiment:   false,
+		Status:                entities.ExperimentStatus(rawExperiment.Status),
 	}
 
 	for _, variation := range rawExperiment.Variations {
@@ -102,6 +109,13 @@ func mapExperiment(rawExperiment datafileEntities.Experiment) entities.Experimen
 		experiment.TrafficAllocation[i] = entities.Range(allocation)
 	}
 
+	if rawExperiment.Cmab != nil {
+		experiment.Cmab = &entities.Cmab{
+			AttributeIds:      rawExperiment.Cmab.AttributeIds,
+			TrafficAllocation: rawExperiment.Cmab.TrafficAllocation,
+		}
+	}
+
 	return experiment
 }
 
@@ -30,6 +30,7 @@ func TestMapExperiments(t *testing.T) {
 		"audienceIds": ["31111"],
 		"id": "11111",
 		"key": "test_experiment_11111",
+		"status": "Running",
 		"variations": [
 			{
 				"id": "21111",
@@ -74,6 +75,7 @@ func TestMapExperiments(t *testing.T) {
 			ID:          "11111",
 			GroupID:     "15",
 			Key:         "test_experiment_11111",
+			Status:      entities.ExperimentStatusRunning,
 			Variations: map[string]entities.Variation{
 				"21111": {
 					ID:             "21111",
@@ -164,6 +166,36 @@ func TestMapExperimentsWithStringAudienceCondition(t *testing.T) {
 	assert.Equal(t, expectedExperimentKeyMap, experimentKeyMap)
 }
 
+func TestMapExperimentsWithCmab(t *testing.T) {
+	const testExperimentString = `{
+		"id": "11111",
+		"key": "test_cmab_experiment_11111",
+		"status": "Running",
+		"variations": [],
+		"trafficAllocation": [],
+		"cmab": {
+			"attributeIds": ["808797688", "808797689"],
+			"trafficAllocation": 5000
+		}
+	}`
+
+	var rawExperiment datafileEntities.Experiment
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	json.Unmarshal([]byte(testExperimentString), &rawExperiment)
+
+	experimentsIDMap, _ := MapExperiments([]datafileEntities.Experiment{rawExperiment}, map[string]string{})
+	expectedCmab := &entities.Cmab{
+		AttributeIds:      []string{"808797688", "808797689"},
+		TrafficAllocation: 5000,
+	}
+	assert.Equal(t, expectedCmab, experimentsIDMap["11111"].Cmab)
+
+	// Experiments without cmab settings are not CMAB rules
+	rawExperiment.Cmab = nil
+	experimentsIDMap, _ = MapExperiments([]datafileEntities.Experiment{rawExperiment}, map[string]string{})
+	assert.Nil(t, experimentsIDMap["11111"].Cmab)
+}
+
 func TestMergeExperiments(t *testing.T) {
 
 	rawExperiment := datafileEntities.Experiment{
@@ -0,0 +1,96 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package mappers ...
+package mappers
+
+import (
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// MapHoldouts maps the raw datafile holdout entities to SDK Holdout entities and attaches the applicable
+// holdouts to each feature in the given feature map. Global holdouts are attached before flag-level holdouts.
+func MapHoldouts(rawHoldouts []datafileEntities.Holdout, featureMap map[string]entities.Feature) (holdoutList []entities.Holdout) {
+	holdoutList = []entities.Holdout{}
+	globalHoldouts := []entities.Holdout{}
+	includedHoldouts := map[string][]entities.Holdout{}
+	excludedHoldouts := map[string]map[string]bool{}
+
+	for _, rawHoldout := range rawHoldouts {
+		holdout := mapHoldout(rawHoldout)
+		holdoutList = append(holdoutList, holdout)
+
+		if holdout.IsGlobal() {
+			globalHoldouts = append(globalHoldouts, holdout)
+			for _, flagID := range holdout.ExcludedFlags {
+				if excludedHoldouts[flagID] == nil {
+					excludedHoldouts[flagID] = map[string]bool{}
+				}
+				excludedHoldouts[flagID][holdout.ID] = true
+			}
+			continue
+		}
+
+		for _, flagID := range holdout.IncludedFlags {
+			includedHoldouts[flagID] = append(includedHoldouts[flagID], holdout)
+		}
+	}
+
+	for key, feature := range featureMap {
+		holdouts := []entities.Holdout{}
+		for _, holdout := range globalHoldouts {
+			if !excludedHoldouts[feature.ID][holdout.ID] {
+				holdouts = append(holdouts, holdout)
+			}
+		}
+		holdouts = append(holdouts, includedHoldouts[feature.ID]...)
+		if len(holdouts) > 0 {
+			feature.Holdouts = holdouts
+			featureMap[key] = feature
+		}
+	}
+
+	return holdoutList
+}
+
+// Maps the raw holdout entity from the datafile into an SDK Holdout entity
+func mapHoldout(rawHoldout datafileEntities.Holdout) entities.Holdout {
+	holdout := entities.Holdout{
+		ID:                    rawHoldout.ID,
+		Key:                   rawHoldout.Key,
+		Status:                entities.HoldoutStatus(rawHoldout.Status),
+		AudienceIds:           rawHoldout.AudienceIds,
+		AudienceConditions:    rawHoldout.AudienceConditions,
+		AudienceConditionTree: mapAudienceConditionTree(rawHoldout.AudienceIds, rawHoldout.AudienceConditions),
+		Variations:            make(map[string]entities.Variation),
+		VariationKeyToIDMap:   make(map[string]string),
+		TrafficAllocation:     make([]entities.Range, len(rawHoldout.TrafficAllocation)),
+		IncludedFlags:         rawHoldout.IncludedFlags,
+		ExcludedFlags:         rawHoldout.ExcludedFlags,
+	}
+
+	for _, variation := range rawHoldout.Variations {
+		holdout.Variations[variation.ID] = mapVariation(variation)
+		holdout.VariationKeyToIDMap[variation.Key] = variation.ID
+	}
+
+	for i, allocation := range rawHoldout.TrafficAllocation {
+		holdout.TrafficAllocation[i] = entities.Range(allocation)
+	}
+
+	return holdout
+}
@@ -0,0 +1,101 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package mappers
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+
+	datafileEntities "github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+func TestMapHoldouts(t *testing.T) {
+	const testHoldoutsString = `[
+		{
+			"id": "31111",
+			"key": "global_holdout",
+			"status": "Running",
+			"audienceIds": ["41111"],
+			"variations": [{ "id": "31112", "key": "ho_off_key", "featureEnabled": false }],
+			"trafficAllocation": [{ "entityId": "31112", "endOfRange": 500 }],
+			"includedFlags": [],
+			"excludedFlags": ["21112"]
+		},
+		{
+			"id": "32222",
+			"key": "flag_holdout",
+			"status": "Running",
+			"variations": [],
+			"trafficAllocation": [],
+			"includedFlags": ["21112"]
+		}
+	]`
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	var rawHoldouts []datafileEntities.Holdout
+	assert.NoError(t, json.Unmarshal([]byte(testHoldoutsString), &rawHoldouts))
+
+	featureMap := map[string]entities.Feature{
+		"feature_1": {ID: "21111", Key: "feature_1"},
+		"feature_2": {ID: "21112", Key: "feature_2"},
+	}
+	holdoutList := MapHoldouts(rawHoldouts, featureMap)
+
+	expectedGlobalHoldout := entities.Holdout{
+		ID:          "31111",
+		Key:         "global_holdout",
+		Status:      entities.HoldoutStatusRunning,
+		AudienceIds: []string{"41111"},
+		AudienceConditionTree: &entities.TreeNode{
+			Operator: "or",
+			Nodes:    []*entities.TreeNode{{Item: "41111"}},
+		},
+		Variations: map[string]entities.Variation{
+			"31112": {ID: "31112", Key: "ho_off_key", Variables: map[string]entities.VariationVariable{}},
+		},
+		VariationKeyToIDMap: map[string]string{"ho_off_key": "31112"},
+		TrafficAllocation:   []entities.Range{{EntityID: "31112", EndOfRange: 500}},
+		IncludedFlags:       []string{},
+		ExcludedFlags:       []string{"21112"},
+	}
+	expectedFlagHoldout := entities.Holdout{
+		ID:                  "32222",
+		Key:                 "flag_holdout",
+		Status:              entities.HoldoutStatusRunning,
+		Variations:          map[string]entities.Variation{},
+		VariationKeyToIDMap: map[string]string{},
+		TrafficAllocation:   []entities.Range{},
+		IncludedFlags:       []string{"21112"},
+	}
+
+	assert.Equal(t, []entities.Holdout{expectedGlobalHoldout, expectedFlagHoldout}, holdoutList)
+	assert.Equal(t, []entities.Holdout{expectedGlobalHoldout}, featureMap["feature_1"].Holdouts)
+	assert.Equal(t, []entities.Holdout{expectedFlagHoldout}, featureMap["feature_2"].Holdouts)
+}
+
+func TestMapHoldoutsEmpty(t *testing.T) {
+	featureMap := map[string]entities.Feature{
+		"feature_1": {ID: "21111", Key: "feature_1"},
+	}
+	holdoutList := MapHoldouts(nil, featureMap)
+
+	assert.Equal(t, []entities.Holdout{}, holdoutList)
+	assert.Nil(t, featureMap["feature_1"].Holdouts)
+}
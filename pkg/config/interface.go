@@ -44,6 +44,7 @@ type ProjectConfig interface {
 	GetSegmentList() []string
 	GetIntegrationList() []entities.Integration
 	GetRolloutList() (rolloutList []entities.Rollout)
+	GetHoldoutList() (holdoutList []entities.Holdout)
 	GetFeatureList() []entities.Feature
 	GetGroupByID(string) (entities.Group, error)
 	GetProjectID() string
@@ -62,3 +63,10 @@ type ProjectConfigManager interface {
 	RemoveOnProjectConfigUpdate(id int) error
 	OnProjectConfigUpdate(callback func(notification.ProjectConfigUpdateNotification)) (int, error)
 }
+
+// ReadyNotifier is implemented by config managers which can get their first project config after being created
+type ReadyNotifier interface {
+	Ready() <-chan struct{}
+	OnReady(callback func(notification.ReadyNotification)) (int, error)
+	RemoveOnReady(id int) error
+}
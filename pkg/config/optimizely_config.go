@@ -42,6 +42,7 @@ type OptimizelyConfig struct {
 	Attributes  []OptimizelyAttribute        `json:"attributes"`
 	Audiences   []OptimizelyAudience         `json:"audiences"`
 	Events      []OptimizelyEvent            `json:"events"`
+	Holdouts    []OptimizelyHoldout          `json:"holdouts"`
 	datafile    string
 }
 
@@ -54,10 +55,22 @@ func (c OptimizelyConfig) GetDatafile() string {
 type OptimizelyExperiment struct {
 	ID            string                         `json:"id"`
 	Key           string                         `json:"key"`
+	Status        string                         `json:"status"`
 	Audiences     string                         `json:"audiences"`
 	VariationsMap map[string]OptimizelyVariation `json:"variationsMap"`
 }
 
+// OptimizelyHoldout has holdout info
+type OptimizelyHoldout struct {
+	ID            string                         `json:"id"`
+	Key           string                         `json:"key"`
+	Status        string                         `json:"status"`
+	Audiences     string                         `json:"audiences"`
+	VariationsMap map[string]OptimizelyVariation `json:"variationsMap"`
+	IncludedFlags []string                       `json:"includedFlags"`
+	ExcludedFlags []string                       `json:"excludedFlags"`
+}
+
 // OptimizelyAttribute has attribute info
 type OptimizelyAttribute struct {
 	ID  string `json:"id"`
@@ -263,6 +276,7 @@ func getDeliveryRules(variableByIDMap map[string]entities.Variable, audiencesByI
 		optimizelyExpriments = append(optimizelyExpriments, OptimizelyExperiment{
 			ID:            experiment.ID,
 			Key:           experiment.Key,
+			Status:        string(experiment.Status),
 			Audiences:     getExperimentAudiences(experiment, audiencesByID),
 			VariationsMap: getVariationsMap(feature, experiment.Variations, variableByIDMap),
 		})
@@ -314,6 +328,7 @@ func getMappedExperiments(audiencesByID map[string]entities.Audience, experiment
 		mappedExperiments[experiment.ID] = OptimizelyExperiment{
 			ID:            experiment.ID,
 			Key:           experiment.Key,
+			Status:        string(experiment.Status),
 			Audiences:     getExperimentAudiences(experiment, audiencesByID),
 			VariationsMap: variationsMap,
 		}
@@ -365,6 +380,22 @@ func getFeaturesMap(audiencesByID map[string]entities.Audience, mappedExperiment
 	return featuresMap
 }
 
+func getHoldouts(holdouts []entities.Holdout, audiencesByID map[string]entities.Audience) []OptimizelyHoldout {
+	optimizelyHoldouts := []OptimizelyHoldout{}
+	for _, holdout := range holdouts {
+		optimizelyHoldouts = append(optimizelyHoldouts, OptimizelyHoldout{
+			ID:            holdout.ID,
+			Key:           holdout.Key,
+			Status:        string(holdout.Status),
+			Audiences:     getSerializedAudiences(holdout.AudienceConditions, audiencesByID),
+			VariationsMap: getVariationsMap(entities.Feature{}, holdout.Variations, map[string]entities.Variable{}),
+			IncludedFlags: holdout.IncludedFlags,
+			ExcludedFlags: holdout.ExcludedFlags,
+		})
+	}
+	return optimizelyHoldouts
+}
+
 // NewOptimizelyConfig constructs OptimizelyConfig object
 func NewOptimizelyConfig(projConfig ProjectConfig) *OptimizelyConfig {
 
@@ -404,6 +435,7 @@ func NewOptimizelyConfig(projConfig ProjectConfig) *OptimizelyConfig {
 
 	variableByIDMap := getVariableByIDMap(featuresList)
 	optimizelyConfig.FeaturesMap = getFeaturesMap(projConfig.GetAudienceMap(), mappedExperiments, featuresList, rolloutIDMap, variableByIDMap)
+	optimizelyConfig.Holdouts = getHoldouts(projConfig.GetHoldoutList(), projConfig.GetAudienceMap())
 
 	optimizelyConfig.datafile = projConfig.GetDatafile()
 
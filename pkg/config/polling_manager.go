@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
 	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
@@ -68,6 +69,9 @@ type PollingProjectConfigManager struct {
 	err              error
 	projectConfig    ProjectConfig
 	optimizelyConfig *OptimizelyConfig
+
+	ready     chan struct{}
+	readyOnce sync.Once
 }
 
 // OptionFunc is used to provide custom configuration to the PollingProjectConfigManager.
@@ -161,9 +165,10 @@ func (cm *PollingProjectConfigManager) SyncConfig() {
 		return
 	}
 
+	previousConfig := cm.projectConfig
 	var previousRevision string
-	if cm.projectConfig != nil {
-		previousRevision = cm.projectConfig.GetRevision()
+	if previousConfig != nil {
+		previousRevision = previousConfig.GetRevision()
 	}
 	if projectConfig.GetRevision() == previousRevision {
 		cm.logger.Debug(fmt.Sprintf("No datafile updates. Current revision number: %s", cm.projectConfig.GetRevision()))
@@ -174,7 +179,8 @@ func (cm *PollingProjectConfigManager) SyncConfig() {
 	closeMutex(err)
 	if err == nil {
 		cm.logger.Debug(fmt.Sprintf("New datafile set with revision: %s. Old revision: %s", projectConfig.GetRevision(), previousRevision))
-		cm.sendConfigUpdateNotification()
+		cm.sendConfigUpdateNotification(previousConfig, projectConfig)
+		cm.sendReadyNotification(projectConfig.GetRevision())
 	}
 }
 
@@ -295,6 +301,50 @@ func (cm *PollingProjectConfigManager) OnProjectConfigUpdate(callback func(notif
 	return id, nil
 }
 
+// Ready returns a channel which is closed once the first project config is set
+func (cm *PollingProjectConfigManager) Ready() <-chan struct{} {
+	cm.configLock.Lock()
+	defer cm.configLock.Unlock()
+	cm.initReady()
+	return cm.ready
+}
+
+// OnReady registers a handler which is called once, when the first project config is set.
+// The handler is called right away if the config manager is already ready.
+func (cm *PollingProjectConfigManager) OnReady(callback func(notification.ReadyNotification)) (int, error) {
+	var once sync.Once
+	handler := func(payload interface{}) {
+		if readyNotification, ok := payload.(notification.ReadyNotification); ok {
+			once.Do(func() { callback(readyNotification) })
+		} else {
+			cm.logger.Warning(fmt.Sprintf("Unable to convert notification payload %v into ReadyNotification", payload))
+		}
+	}
+	id, err := cm.notificationCenter.AddHandler(notification.Ready, handler)
+	if err != nil {
+		cm.logger.Warning("Problem with adding notification handler")
+		return 0, err
+	}
+
+	select {
+	case <-cm.Ready():
+		if projectConfig, err := cm.GetConfig(); err == nil {
+			handler(notification.ReadyNotification{Type: notification.Ready, Revision: projectConfig.GetRevision()})
+		}
+	default:
+	}
+	return id, nil
+}
+
+// RemoveOnReady removes handler for Ready notification with given id
+func (cm *PollingProjectConfigManager) RemoveOnReady(id int) error {
+	if err := cm.notificationCenter.RemoveHandler(id, notification.Ready); err != nil {
+		cm.logger.Warning("Problem with removing notification handler")
+		return err
+	}
+	return nil
+}
+
 // RemoveOnProjectConfigUpdate removes handler for ProjectConfigUpdate notification with given id
 func (cm *PollingProjectConfigManager) RemoveOnProjectConfigUpdate(id int) error {
 	if err := cm.notificationCenter.RemoveHandler(id, notification.ProjectConfigUpdate); err != nil {
@@ -312,29 +362,65 @@ func (cm *PollingProjectConfigManager) setConfig(projectConfig ProjectConfig) er
 	if cm.optimizelyConfig != nil {
 		cm.optimizelyConfig = NewOptimizelyConfig(projectConfig)
 	}
+	cm.initReady()
+	select {
+	case <-cm.ready:
+	default:
+		close(cm.ready)
+	}
 	return nil
 }
 
+// initReady creates the ready channel, it must be called with configLock held
+func (cm *PollingProjectConfigManager) initReady() {
+	if cm.ready != nil {
+		return
+	}
+	cm.ready = make(chan struct{})
+	if cm.projectConfig != nil {
+		close(cm.ready)
+	}
+}
+
 func (cm *PollingProjectConfigManager) setInitialDatafile(datafile []byte) {
 	if len(datafile) != 0 {
 		cm.configLock.Lock()
-		defer cm.configLock.Unlock()
 		projectConfig, err := datafileprojectconfig.NewDatafileProjectConfig(datafile, logging.GetLogger(cm.sdkKey, "DatafileProjectConfig"))
 		if projectConfig != nil {
 			err = cm.setConfig(projectConfig)
 		}
 		cm.err = err
+		cm.configLock.Unlock()
+
+		if err == nil {
+			cm.sendReadyNotification(projectConfig.GetRevision())
+		}
 	}
 }
 
-func (cm *PollingProjectConfigManager) sendConfigUpdateNotification() {
+func (cm *PollingProjectConfigManager) sendConfigUpdateNotification(previousConfig, projectConfig ProjectConfig) {
 	if cm.notificationCenter != nil {
 		projectConfigUpdateNotification := notification.ProjectConfigUpdateNotification{
 			Type:     notification.ProjectConfigUpdate,
-			Revision: cm.projectConfig.GetRevision(),
+			Revision: projectConfig.GetRevision(),
+		}
+		if previousConfig != nil {
+			projectConfigUpdateNotification.Diff = configdiff.Compare(previousConfig, projectConfig)
 		}
 		if err := cm.notificationCenter.Send(notification.ProjectConfigUpdate, projectConfigUpdateNotification); err != nil {
 			cm.logger.Warning("Problem with sending notification")
 		}
 	}
 }
+
+// sendReadyNotification sends the Ready notification the first time it is called
+func (cm *PollingProjectConfigManager) sendReadyNotification(revision string) {
+	cm.readyOnce.Do(func() {
+		if cm.notificationCenter != nil {
+			readyNotification := notification.ReadyNotification{Type: notification.Ready, Revision: revision}
+			if err := cm.notificationCenter.Send(notification.Ready, readyNotification); err != nil {
+				cm.logger.Warning("Problem with sending notification")
+			}
+		}
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
 	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
@@ -311,6 +312,28 @@ func TestNewPollingProjectConfigManagerWithDifferentDatafileRevisions(t *testing
 	assert.Equal(t, uint64(1), atomic.LoadUint64(&numberOfCalls))
 }
 
+func TestPollingProjectConfigManagerConfigUpdateDiff(t *testing.T) {
+	mockDatafile1 := []byte(`{"revision":"42","version": "4"}`)
+	mockDatafile2 := []byte(`{"revision":"43","version": "4","typedAudiences":[{"id":"1","name":"adults","conditions":["and",{"type":"custom_attribute","name":"age","match":"ge","value":18}]}]}`)
+	mockRequester := new(MockRequester)
+	mockRequester.On("Get", []utils.Header(nil)).Return(mockDatafile2, http.Header{}, http.StatusOK, nil)
+
+	configManager := NewPollingProjectConfigManager("test_sdk_key", WithRequester(mockRequester), WithInitialDatafile(mockDatafile1))
+
+	var updates []notification.ProjectConfigUpdateNotification
+	_, err := configManager.OnProjectConfigUpdate(func(notification notification.ProjectConfigUpdateNotification) {
+		updates = append(updates, notification)
+	})
+	assert.NoError(t, err)
+
+	configManager.SyncConfig()
+	if assert.Len(t, updates, 1) && assert.NotNil(t, updates[0].Diff) {
+		assert.Equal(t, "42", updates[0].Diff.OldRevision)
+		assert.Equal(t, "43", updates[0].Diff.NewRevision)
+		assert.Equal(t, []configdiff.Change{{Type: configdiff.Added, Entity: configdiff.Audience, Key: "1"}}, updates[0].Diff.Changes)
+	}
+}
+
 func TestNewAsyncPollingProjectConfigManagerWithDifferentDatafileRevisions(t *testing.T) {
 	// Test newer datafile should replace the older one if revisions are different
 	mockDatafile1 := []byte(`{"revision":"42","botFiltering":true,"version": "4"}`)
@@ -474,6 +497,69 @@ func TestNewAsyncPollingProjectConfigManagerOnDecision(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAsyncPollingProjectConfigManagerReady(t *testing.T) {
+	mockDatafile1 := []byte(`{"revision":"42","version": "4"}`)
+	mockDatafile2 := []byte(`{"revision":"43","version": "4"}`)
+	mockRequester := new(MockRequester)
+	mockRequester.On("Get", []utils.Header(nil)).Return(mockDatafile1, http.Header{}, http.StatusOK, nil).Once()
+	mockRequester.On("Get", []utils.Header(nil)).Return(mockDatafile2, http.Header{}, http.StatusOK, nil).Once()
+
+	asyncConfigManager := NewAsyncPollingProjectConfigManager("ready_sdk_key", WithRequester(mockRequester))
+
+	var revisions []string
+	callback := func(readyNotification notification.ReadyNotification) {
+		assert.Equal(t, notification.Ready, readyNotification.Type)
+		revisions = append(revisions, readyNotification.Revision)
+	}
+	id, err := asyncConfigManager.OnReady(callback)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, id)
+
+	ready := asyncConfigManager.Ready()
+	select {
+	case <-ready:
+		assert.Fail(t, "config manager should not be ready before the first sync")
+	default:
+	}
+	assert.Len(t, revisions, 0)
+
+	asyncConfigManager.SyncConfig()
+	<-ready
+	assert.Equal(t, []string{"42"}, revisions)
+
+	// the notification is only sent for the first config
+	asyncConfigManager.SyncConfig()
+	assert.Equal(t, []string{"42"}, revisions)
+	mockRequester.AssertExpectations(t)
+
+	// handlers added once ready are called right away
+	var lateRevision string
+	_, err = asyncConfigManager.OnReady(func(readyNotification notification.ReadyNotification) {
+		lateRevision = readyNotification.Revision
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "43", lateRevision)
+	assert.NoError(t, asyncConfigManager.RemoveOnReady(id))
+}
+
+func TestPollingProjectConfigManagerWithInitialDatafileIsReady(t *testing.T) {
+	mockDatafile := []byte(`{"revision":"42","version": "4"}`)
+	configManager := NewAsyncPollingProjectConfigManager("initial_ready_sdk_key", WithInitialDatafile(mockDatafile))
+
+	select {
+	case <-configManager.Ready():
+	default:
+		assert.Fail(t, "config manager should be ready with an initial datafile")
+	}
+
+	var revision string
+	_, err := configManager.OnReady(func(readyNotification notification.ReadyNotification) {
+		revision = readyNotification.Revision
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", revision)
+}
+
 func TestGetOptimizelyConfigForNewPollingProjectConfigManager(t *testing.T) {
 
 	mockDatafile1 := []byte(`{"revision":"42","botFiltering":true,"version": "4"}`)
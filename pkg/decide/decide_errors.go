@@ -30,6 +30,10 @@ const (
 	FlagKeyInvalid decideMessage = `No flag was found for key "%s".`
 	// VariableValueInvalid when invalid variable value is provided
 	VariableValueInvalid decideMessage = `Variable value for key "%s" is invalid or wrong type.`
+	// ContextDone when the caller's context is canceled or its deadline is exceeded
+	ContextDone decideMessage = "Decision aborted: %s."
+	// CmabFetchFailed when the variation of a CMAB rule can not be fetched
+	CmabFetchFailed decideMessage = `Failed to fetch CMAB decision for flag "%s": %s.`
 )
 
 // GetDecideMessage returns message for decide type
@@ -33,6 +33,10 @@ const (
 	IncludeReasons OptimizelyDecideOptions = "INCLUDE_REASONS"
 	// ExcludeVariables when set, excludes variable values from the decision result.
 	ExcludeVariables OptimizelyDecideOptions = "EXCLUDE_VARIABLES"
+	// IgnoreCMABCache when set, skips the CMAB decision cache and fetches a fresh prediction.
+	IgnoreCMABCache OptimizelyDecideOptions = "IGNORE_CMAB_CACHE"
+	// ResetCMABCache when set, clears the CMAB decision cache before making the decision.
+	ResetCMABCache OptimizelyDecideOptions = "RESET_CMAB_CACHE"
 )
 
 // Options defines options for controlling flag decisions.
@@ -42,6 +46,8 @@ type Options struct {
 	IgnoreUserProfileService bool
 	IncludeReasons           bool
 	ExcludeVariables         bool
+	IgnoreCMABCache          bool
+	ResetCMABCache           bool
 }
 
 // TranslateOptions converts string options array to array of OptimizelyDecideOptions
@@ -59,6 +65,10 @@ func TranslateOptions(options []string) ([]OptimizelyDecideOptions, error) {
 			decideOptions = append(decideOptions, ExcludeVariables)
 		case IncludeReasons:
 			decideOptions = append(decideOptions, IncludeReasons)
+		case IgnoreCMABCache:
+			decideOptions = append(decideOptions, IgnoreCMABCache)
+		case ResetCMABCache:
+			decideOptions = append(decideOptions, ResetCMABCache)
 		default:
 			return []OptimizelyDecideOptions{}, errors.New("invalid option: " + val)
 		}
@@ -51,6 +51,14 @@ func TestTranslateOptionsValidCases(t *testing.T) {
 	assert.Equal(t, IgnoreUserProfileService, translatedOptions[2])
 	assert.Equal(t, ExcludeVariables, translatedOptions[3])
 	assert.Equal(t, IncludeReasons, translatedOptions[4])
+
+	// Checking CMAB cache options
+	options = append(options, "IGNORE_CMAB_CACHE", "RESET_CMAB_CACHE")
+	translatedOptions, err = TranslateOptions(options)
+	assert.NoError(t, err)
+	assert.Len(t, translatedOptions, 7)
+	assert.Equal(t, IgnoreCMABCache, translatedOptions[5])
+	assert.Equal(t, ResetCMABCache, translatedOptions[6])
 }
 
 func TestTranslateOptionsInvalidCases(t *testing.T) {
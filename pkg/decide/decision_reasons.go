@@ -17,6 +17,8 @@
 // Package decide //
 package decide
 
+import "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+
 // DecisionReasons defines the reasons for which the decision was made.
 type DecisionReasons interface {
 	AddError(format string, arguments ...interface{})
@@ -24,3 +26,72 @@ type DecisionReasons interface {
 	Append(reasons DecisionReasons)
 	ToReport() []string
 }
+
+// StructuredDecisionReasons is implemented by the DecisionReasons which also collect structured reasons, like
+// DefaultDecisionReasons. Use AddReason, AddDetail and ToStructuredReport to support the other implementations.
+type StructuredDecisionReasons interface {
+	DecisionReasons
+	// AddReason appends the given structured reason, its message is reported with the other infos
+	AddReason(reason StructuredReason) string
+	// AddErrorReason appends the given structured reason, its message is reported with the other errors
+	AddErrorReason(reason StructuredReason) string
+	// AddDetail appends the given structured reason without reporting its message,
+	// for details which are too fine-grained for the text reasons
+	AddDetail(reason StructuredReason)
+	ToStructuredReport() []StructuredReason
+}
+
+// StructuredReason is a machine-readable reason for which the decision was made.
+type StructuredReason struct {
+	Code       reasons.Reason `json:"code"`
+	RuleKey    string         `json:"ruleKey,omitempty"`
+	AudienceID string         `json:"audienceId,omitempty"`
+	// Result is the result of the evaluation the reason describes, nil if it does not describe one
+	Result  *bool  `json:"result,omitempty"`
+	Message string `json:"message"`
+}
+
+// EvaluationResult returns a pointer to the given evaluation result, for use in StructuredReason.
+func EvaluationResult(result bool) *bool {
+	return &result
+}
+
+// AddReason appends the structured reason to decisionReasons, or only its message when decisionReasons does not
+// implement StructuredDecisionReasons. It returns the message of the reason.
+func AddReason(decisionReasons DecisionReasons, reason StructuredReason) string {
+	if structured, ok := decisionReasons.(StructuredDecisionReasons); ok {
+		return structured.AddReason(reason)
+	}
+	return decisionReasons.AddInfo("%s", reason.Message)
+}
+
+// AddErrorReason appends the structured reason to the errors of decisionReasons, or only its message when
+// decisionReasons does not implement StructuredDecisionReasons. It returns the message of the reason.
+func AddErrorReason(decisionReasons DecisionReasons, reason StructuredReason) string {
+	if structured, ok := decisionReasons.(StructuredDecisionReasons); ok {
+		return structured.AddErrorReason(reason)
+	}
+	decisionReasons.AddError("%s", reason.Message)
+	return reason.Message
+}
+
+// AddDetail appends the structured reason to decisionReasons when it implements StructuredDecisionReasons
+func AddDetail(decisionReasons DecisionReasons, reason StructuredReason) {
+	if structured, ok := decisionReasons.(StructuredDecisionReasons); ok {
+		structured.AddDetail(reason)
+	}
+}
+
+// ToStructuredReport returns the structured reasons of decisionReasons. When it does not implement
+// StructuredDecisionReasons, its reported messages are returned as info reasons.
+func ToStructuredReport(decisionReasons DecisionReasons) []StructuredReason {
+	if structured, ok := decisionReasons.(StructuredDecisionReasons); ok {
+		return structured.ToStructuredReport()
+	}
+	messages := decisionReasons.ToReport()
+	structuredReasons := make([]StructuredReason, 0, len(messages))
+	for _, message := range messages {
+		structuredReasons = append(structuredReasons, StructuredReason{Code: reasons.Info, Message: message})
+	}
+	return structuredReasons
+}
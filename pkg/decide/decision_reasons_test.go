@@ -17,9 +17,12 @@
 package decide
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 )
 
 func TestNewDecisionReasonsWithEmptyOptions(t *testing.T) {
@@ -109,3 +112,84 @@ func TestAppend(t *testing.T) {
 	assert.Equal(t, "info message", reportedReasons[2])
 	assert.Equal(t, "info message: unexpected string", reportedReasons[3])
 }
+
+func TestStructuredReasons(t *testing.T) {
+	reasons := NewDecisionReasons(&Options{IncludeReasons: true})
+	reasons.AddError("error message")
+	reasons.AddInfo("info message")
+	message := reasons.AddReason(StructuredReason{Code: pkgReasons.ExperimentNotRunning, RuleKey: "rule", Message: "rule is not running"})
+	assert.Equal(t, "rule is not running", message)
+	reasons.AddDetail(StructuredReason{Code: pkgReasons.AudienceEvaluated, AudienceID: "1", Result: EvaluationResult(true), Message: "audience 1 is true"})
+
+	assert.Equal(t, []string{"error message", "info message", "rule is not running"}, reasons.ToReport())
+	assert.Equal(t, []StructuredReason{
+		{Code: pkgReasons.Error, Message: "error message"},
+		{Code: pkgReasons.Info, Message: "info message"},
+		{Code: pkgReasons.ExperimentNotRunning, RuleKey: "rule", Message: "rule is not running"},
+		{Code: pkgReasons.AudienceEvaluated, AudienceID: "1", Result: EvaluationResult(true), Message: "audience 1 is true"},
+	}, reasons.ToStructuredReport())
+
+	// details are appended along with the other infos
+	appended := NewDecisionReasons(&Options{IncludeReasons: true})
+	appended.Append(reasons)
+	assert.Equal(t, reasons.ToStructuredReport(), appended.ToStructuredReport())
+	assert.Equal(t, reasons.ToReport(), appended.ToReport())
+
+	// only errors are reported without the include reasons option
+	excluded := NewDecisionReasons(nil)
+	excluded.Append(reasons)
+	excluded.AddDetail(StructuredReason{Code: pkgReasons.AudienceEvaluated, Message: "detail"})
+	assert.Equal(t, []StructuredReason{{Code: pkgReasons.Error, Message: "error message"}}, excluded.ToStructuredReport())
+}
+
+// messageReasons implements DecisionReasons without the structured reasons
+type messageReasons struct {
+	messages []string
+}
+
+func (m *messageReasons) AddError(format string, arguments ...interface{}) {
+	m.messages = append(m.messages, fmt.Sprintf(format, arguments...))
+}
+
+func (m *messageReasons) AddInfo(format string, arguments ...interface{}) string {
+	message := fmt.Sprintf(format, arguments...)
+	m.messages = append(m.messages, message)
+	return message
+}
+
+func (m *messageReasons) Append(reasons DecisionReasons) {
+	m.messages = append(m.messages, reasons.ToReport()...)
+}
+
+func (m *messageReasons) ToReport() []string {
+	return m.messages
+}
+
+func TestAddErrorReason(t *testing.T) {
+	// errors are reported without the include reasons option
+	reasons := NewDecisionReasons(nil)
+	reasons.AddInfo("info message")
+	message := reasons.AddErrorReason(StructuredReason{Code: pkgReasons.UserProfileLookupFailed, RuleKey: "rule", Message: "lookup failed"})
+	assert.Equal(t, "lookup failed", message)
+	assert.Equal(t, []string{"lookup failed"}, reasons.ToReport())
+	assert.Equal(t, []StructuredReason{{Code: pkgReasons.UserProfileLookupFailed, RuleKey: "rule", Message: "lookup failed"}}, reasons.ToStructuredReport())
+
+	messages := &messageReasons{}
+	assert.Equal(t, "lookup failed", AddErrorReason(messages, StructuredReason{Code: pkgReasons.UserProfileLookupFailed, Message: "lookup failed"}))
+	assert.Equal(t, []string{"lookup failed"}, messages.ToReport())
+}
+
+func TestStructuredReasonHelpers(t *testing.T) {
+	var structured StructuredDecisionReasons = NewDecisionReasons(&Options{IncludeReasons: true})
+	assert.Equal(t, "100% rolled out", AddReason(structured, StructuredReason{Code: pkgReasons.ExperimentNotRunning, Message: "100% rolled out"}))
+	AddDetail(structured, StructuredReason{Code: pkgReasons.AudienceEvaluated, Message: "detail"})
+	assert.Equal(t, structured.ToStructuredReport(), ToStructuredReport(structured))
+	assert.Len(t, structured.ToStructuredReport(), 2)
+
+	// other implementations only receive the messages of the reasons
+	messages := &messageReasons{}
+	assert.Equal(t, "100% rolled out", AddReason(messages, StructuredReason{Code: pkgReasons.ExperimentNotRunning, Message: "100% rolled out"}))
+	AddDetail(messages, StructuredReason{Code: pkgReasons.AudienceEvaluated, Message: "detail"})
+	assert.Equal(t, []string{"100% rolled out"}, messages.ToReport())
+	assert.Equal(t, []StructuredReason{{Code: pkgReasons.Info, Message: "100% rolled out"}}, ToStructuredReport(messages))
+}
@@ -19,11 +19,20 @@ package decide
 
 import (
 	"fmt"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 )
 
+type reasonEntry struct {
+	StructuredReason
+	// detail entries are only reported in the structured reasons
+	detail bool
+}
+
 // DefaultDecisionReasons provides the default implementation of DecisionReasons.
 type DefaultDecisionReasons struct {
-	errors, infos  []string
+	errors         []StructuredReason
+	infos          []reasonEntry
 	includeReasons bool
 }
 
@@ -34,25 +43,41 @@ func NewDecisionReasons(options *Options) *DefaultDecisionReasons {
 		includeReasons = options.IncludeReasons
 	}
 	return &DefaultDecisionReasons{
-		errors:         []string{},
-		infos:          []string{},
+		errors:         []StructuredReason{},
+		infos:          []reasonEntry{},
 		includeReasons: includeReasons,
 	}
 }
 
 // AddError appends given message to the error list.
 func (o *DefaultDecisionReasons) AddError(format string, arguments ...interface{}) {
-	o.errors = append(o.errors, fmt.Sprintf(format, arguments...))
+	o.errors = append(o.errors, StructuredReason{Code: reasons.Error, Message: fmt.Sprintf(format, arguments...)})
 }
 
 // AddInfo appends given info message to the info list after formatting.
 func (o *DefaultDecisionReasons) AddInfo(format string, arguments ...interface{}) string {
-	message := fmt.Sprintf(format, arguments...)
-	if !o.includeReasons {
-		return message
+	return o.AddReason(StructuredReason{Code: reasons.Info, Message: fmt.Sprintf(format, arguments...)})
+}
+
+// AddReason appends given structured reason to the info list.
+func (o *DefaultDecisionReasons) AddReason(reason StructuredReason) string {
+	if o.includeReasons {
+		o.infos = append(o.infos, reasonEntry{StructuredReason: reason})
+	}
+	return reason.Message
+}
+
+// AddErrorReason appends given structured reason to the error list.
+func (o *DefaultDecisionReasons) AddErrorReason(reason StructuredReason) string {
+	o.errors = append(o.errors, reason)
+	return reason.Message
+}
+
+// AddDetail appends given structured reason to the info list, it is left out of ToReport.
+func (o *DefaultDecisionReasons) AddDetail(reason StructuredReason) {
+	if o.includeReasons {
+		o.infos = append(o.infos, reasonEntry{StructuredReason: reason, detail: true})
 	}
-	o.infos = append(o.infos, message)
-	return message
 }
 
 // Append appends given reasons.
@@ -67,9 +92,30 @@ func (o *DefaultDecisionReasons) Append(reasons DecisionReasons) {
 
 // ToReport returns reasons to be reported.
 func (o *DefaultDecisionReasons) ToReport() []string {
-	reasons := o.errors
+	reasons := make([]string, 0, len(o.errors)+len(o.infos))
+	for _, reason := range o.errors {
+		reasons = append(reasons, reason.Message)
+	}
+	if !o.includeReasons {
+		return reasons
+	}
+	for _, entry := range o.infos {
+		if !entry.detail {
+			reasons = append(reasons, entry.Message)
+		}
+	}
+	return reasons
+}
+
+// ToStructuredReport returns structured reasons to be reported, including the details left out of ToReport.
+func (o *DefaultDecisionReasons) ToStructuredReport() []StructuredReason {
+	reasons := make([]StructuredReason, 0, len(o.errors)+len(o.infos))
+	reasons = append(reasons, o.errors...)
 	if !o.includeReasons {
 		return reasons
 	}
-	return append(reasons, o.infos...)
+	for _, entry := range o.infos {
+		reasons = append(reasons, entry.StructuredReason)
+	}
+	return reasons
 }
@@ -0,0 +1,164 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package decision //
+package decision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/utils"
+)
+
+// DefaultCmabPredictionEndpoint is the default endpoint template used to fetch CMAB decisions, formatted with the rule ID
+const DefaultCmabPredictionEndpoint = "https://prediction.cmab.optimizely.com/predict/%s"
+
+// DefaultCmabRequestTimeout is the timeout of prediction requests made with the default HTTP client
+const DefaultCmabRequestTimeout = 10 * time.Second
+
+const cmabAttributeType = "custom_attribute"
+
+// CmabClient fetches the variation assigned to a user by a contextual multi-armed bandit rule.
+// The fetch is given up once ctx is done.
+type CmabClient interface {
+	FetchDecision(ctx context.Context, ruleID, userID string, attributes map[string]interface{}, cmabUUID string) (variationID string, err error)
+}
+
+type cmabAttribute struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value"`
+	Type  string      `json:"type"`
+}
+
+type cmabInstance struct {
+	VisitorID    string          `json:"visitorId"`
+	ExperimentID string          `json:"experimentId"`
+	Attributes   []cmabAttribute `json:"attributes"`
+	CmabUUID     string          `json:"cmabUUID"`
+}
+
+type cmabRequest struct {
+	Instances []cmabInstance `json:"instances"`
+}
+
+type cmabPrediction struct {
+	VariationID string `json:"variation_id"`
+}
+
+type cmabResponse struct {
+	Predictions []cmabPrediction `json:"predictions"`
+}
+
+// DefaultCmabClient fetches CMAB decisions from the prediction endpoint over HTTP
+type DefaultCmabClient struct {
+	predictionEndpoint string
+	httpClient         *http.Client
+	logger             logging.OptimizelyLogProducer
+}
+
+// NewDefaultCmabClient returns a new instance of the DefaultCmabClient.
+// predictionEndpoint is formatted with the rule ID; the default endpoint is used when it is empty.
+// A client with a timeout of DefaultCmabRequestTimeout is used when httpClient is nil.
+func NewDefaultCmabClient(sdkKey, predictionEndpoint string, httpClient *http.Client) *DefaultCmabClient {
+	if predictionEndpoint == "" {
+		predictionEndpoint = DefaultCmabPredictionEndpoint
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultCmabRequestTimeout}
+	}
+	return &DefaultCmabClient{
+		predictionEndpoint: predictionEndpoint,
+		httpClient:         httpClient,
+		logger:             logging.GetLogger(sdkKey, "CmabClient"),
+	}
+}
+
+// FetchDecision sends the user's attributes to the prediction endpoint and returns the predicted variation ID
+func (c *DefaultCmabClient) FetchDecision(ctx context.Context, ruleID, userID string, attributes map[string]interface{}, cmabUUID string) (string, error) {
+	// Sort attribute IDs so that requests are deterministic
+	attributeIDs := make([]string, 0, len(attributes))
+	for id := range attributes {
+		attributeIDs = append(attributeIDs, id)
+	}
+	sort.Strings(attributeIDs)
+
+	instance := cmabInstance{
+		VisitorID:    userID,
+		ExperimentID: ruleID,
+		Attributes:   make([]cmabAttribute, 0, len(attributeIDs)),
+		CmabUUID:     cmabUUID,
+	}
+	for _, id := range attributeIDs {
+		instance.Attributes = append(instance.Attributes, cmabAttribute{ID: id, Value: attributes[id], Type: cmabAttributeType})
+	}
+
+	response, err := c.post(ctx, fmt.Sprintf(c.predictionEndpoint, ruleID), cmabRequest{Instances: []cmabInstance{instance}})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch CMAB decision for rule %q: %w", ruleID, err)
+	}
+
+	if len(response.Predictions) == 0 || response.Predictions[0].VariationID == "" {
+		return "", errors.New("invalid CMAB prediction response")
+	}
+	return response.Predictions[0].VariationID, nil
+}
+
+func (c *DefaultCmabClient) post(ctx context.Context, url string, body cmabRequest) (*cmabResponse, error) {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(utils.HeaderContentType, utils.ContentTypeJSON)
+	request.Header.Set(utils.HeaderAccept, utils.ContentTypeJSON)
+
+	c.logger.Debug(fmt.Sprintf("request %s", url))
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			c.logger.Warning(fmt.Sprintf("can't close body for %s request, %s", url, e))
+		}
+	}()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, errors.New(resp.Status)
+	}
+
+	var response cmabResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
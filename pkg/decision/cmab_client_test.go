@@ -0,0 +1,110 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package decision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmabClientFetchDecision(t *testing.T) {
+	var requestPath string
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &requestBody)
+		fmt.Fprint(w, `{"predictions":[{"variation_id":"2222"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewDefaultCmabClient("sdkKey", server.URL+"/predict/%s", nil)
+	attributes := map[string]interface{}{"a2": "us", "a1": 30}
+	variationID, err := client.FetchDecision(context.Background(), "1111", "test_user_1", attributes, "uuid-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2222", variationID)
+	assert.Equal(t, "/predict/1111", requestPath)
+
+	expectedBody := map[string]interface{}{
+		"instances": []interface{}{
+			map[string]interface{}{
+				"visitorId":    "test_user_1",
+				"experimentId": "1111",
+				"cmabUUID":     "uuid-1",
+				"attributes": []interface{}{
+					map[string]interface{}{"id": "a1", "value": float64(30), "type": "custom_attribute"},
+					map[string]interface{}{"id": "a2", "value": "us", "type": "custom_attribute"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedBody, requestBody)
+}
+
+func TestCmabClientFetchDecisionErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewDefaultCmabClient("sdkKey", server.URL+"/predict/%s", nil)
+	variationID, err := client.FetchDecision(context.Background(), "1111", "test_user_1", map[string]interface{}{}, "uuid-1")
+	assert.Error(t, err)
+	assert.Equal(t, "", variationID)
+}
+
+func TestCmabClientFetchDecisionInvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"predictions":[]}`)
+	}))
+	defer server.Close()
+
+	client := NewDefaultCmabClient("sdkKey", server.URL+"/predict/%s", nil)
+	variationID, err := client.FetchDecision(context.Background(), "1111", "test_user_1", map[string]interface{}{}, "uuid-1")
+	assert.EqualError(t, err, "invalid CMAB prediction response")
+	assert.Equal(t, "", variationID)
+}
+
+func TestNewDefaultCmabClientDefaults(t *testing.T) {
+	client := NewDefaultCmabClient("sdkKey", "", nil)
+	assert.Equal(t, DefaultCmabPredictionEndpoint, client.predictionEndpoint)
+	assert.Equal(t, DefaultCmabRequestTimeout, client.httpClient.Timeout)
+}
+
+func TestCmabClientFetchDecisionContextDone(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewDefaultCmabClient("sdkKey", server.URL+"/predict/%s", nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	variationID, err := client.FetchDecision(ctx, "1111", "test_user_1", map[string]interface{}{}, "uuid-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "", variationID)
+}
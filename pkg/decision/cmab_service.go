@@ -0,0 +1,290 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package decision //
+package decision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	guuid "github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/twmb/murmur3"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/bucketer"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/odp/cache"
+)
+
+const (
+	// DefaultCmabCacheSize is the default maximum number of cached CMAB decisions
+	DefaultCmabCacheSize = 10000
+	// DefaultCmabCacheTimeout is the default time to live of a cached CMAB decision
+	DefaultCmabCacheTimeout = 30 * time.Minute
+
+	// cmabTrafficEntityID is the placeholder entity used to bucket users into the traffic allocation of a CMAB rule
+	cmabTrafficEntityID = "$"
+)
+
+// CmabServiceOptionFunc is used to assign optional configuration options
+type CmabServiceOptionFunc func(*CmabService)
+
+// WithCmabClient sets the client used to fetch CMAB decisions
+func WithCmabClient(client CmabClient) CmabServiceOptionFunc {
+	return func(s *CmabService) {
+		s.client = client
+	}
+}
+
+// WithCmabPredictionEndpoint sets the prediction endpoint template used by the default CMAB client.
+// The endpoint is formatted with the rule ID, e.g. "https://example.com/predict/%s".
+func WithCmabPredictionEndpoint(predictionEndpoint string) CmabServiceOptionFunc {
+	return func(s *CmabService) {
+		s.predictionEndpoint = predictionEndpoint
+	}
+}
+
+// WithCmabCache sets the cache used to store CMAB decisions
+func WithCmabCache(cmabCache cache.Cache) CmabServiceOptionFunc {
+	return func(s *CmabService) {
+		s.cache = cmabCache
+	}
+}
+
+// WithCmabCacheSize sets the maximum number of cached CMAB decisions
+// default value is 10000
+func WithCmabCacheSize(cacheSize int) CmabServiceOptionFunc {
+	return func(s *CmabService) {
+		s.cacheSize = cacheSize
+	}
+}
+
+// WithCmabCacheTimeout sets the time to live of a cached CMAB decision
+// default value is 30m
+func WithCmabCacheTimeout(cacheTimeout time.Duration) CmabServiceOptionFunc {
+	return func(s *CmabService) {
+		s.cacheTimeout = cacheTimeout
+	}
+}
+
+// ErrCmabFetchFailed is returned, wrapped, when the variation of a CMAB rule can not be fetched. The remaining rules
+// of the flag are not evaluated, so that the users of the rule do not fall through to a delivery rule.
+var ErrCmabFetchFailed = errors.New("failed to fetch CMAB decision")
+
+type cmabCacheValue struct {
+	attributesHash string
+	variationID    string
+	cmabUUID       string
+}
+
+// CmabService makes decisions for contextual multi-armed bandit (CMAB) rules by
+// sending the configured user attributes to a prediction endpoint
+type CmabService struct {
+	client                CmabClient
+	predictionEndpoint    string
+	cache                 cache.Cache
+	cacheSize             int
+	cacheTimeout          time.Duration
+	audienceTreeEvaluator evaluator.TreeEvaluator
+	bucketer              bucketer.Bucketer
+	logger                logging.OptimizelyLogProducer
+}
+
+// NewCmabService returns a new instance of the CmabService
+func NewCmabService(sdkKey string, options ...CmabServiceOptionFunc) *CmabService {
+	logger := logging.GetLogger(sdkKey, "CmabService")
+	cmabService := &CmabService{
+		cacheSize:             DefaultCmabCacheSize,
+		cacheTimeout:          DefaultCmabCacheTimeout,
+		audienceTreeEvaluator: evaluator.NewMixedTreeEvaluator(logger),
+		bucketer:              bucketer.NewMurmurhashBucketer(logger, bucketer.DefaultHashSeed),
+		logger:                logger,
+	}
+
+	for _, opt := range options {
+		opt(cmabService)
+	}
+
+	if cmabService.cache == nil {
+		cmabService.cache = cache.NewLRUCache(cmabService.cacheSize, cmabService.cacheTimeout)
+	}
+
+	if cmabService.client == nil {
+		cmabService.client = NewDefaultCmabClient(sdkKey, cmabService.predictionEndpoint, nil)
+	}
+	return cmabService
+}
+
+// GetDecision returns the variation predicted for the user by a CMAB rule.
+// Experiments which are not CMAB rules are left to the other experiment services.
+func (s CmabService) GetDecision(decisionContext ExperimentDecisionContext, userContext entities.UserContext, options *decide.Options) (ExperimentDecision, decide.DecisionReasons, error) {
+	experimentDecision := ExperimentDecision{}
+	experiment := decisionContext.Experiment
+	reasons := decide.NewDecisionReasons(options)
+
+	if experiment.Cmab == nil {
+		return experimentDecision, reasons, nil
+	}
+
+	// Determine if user can be part of the experiment
+	if experiment.AudienceConditionTree != nil {
+		condTreeParams := entities.NewTreeParameters(&userContext, decisionContext.ProjectConfig.GetAudienceMap())
+		s.logger.Debug(fmt.Sprintf(logging.EvaluatingAudiencesForExperiment.String(), experiment.Key))
+		evalResult, _, decisionReasons := s.audienceTreeEvaluator.Evaluate(experiment.AudienceConditionTree, condTreeParams, options)
+		reasons.Append(decisionReasons)
+		logMessage := reasons.AddInfo(logging.ExperimentAudiencesEvaluatedTo.String(), experiment.Key, evalResult)
+		s.logger.Debug(logMessage)
+		if !evalResult {
+			logMessage := reasons.AddInfo(logging.UserNotInExperiment.String(), userContext.ID, experiment.Key)
+			s.logger.Debug(logMessage)
+			experimentDecision.Reason = pkgReasons.FailedAudienceTargeting
+			return experimentDecision, reasons, nil
+		}
+	}
+
+	bucketingID, err := userContext.GetBucketingID()
+	if err != nil {
+		errorMessage := reasons.AddInfo(`Error computing bucketing ID for experiment %q: %q`, experiment.Key, err.Error())
+		s.logger.Debug(errorMessage)
+	}
+
+	if !s.isInTrafficAllocation(bucketingID, *experiment, decisionContext) {
+		logMessage := reasons.AddInfo(logging.UserNotInCmabTrafficAllocation.String(), userContext.ID, experiment.Key)
+		s.logger.Debug(logMessage)
+		experimentDecision.Reason = pkgReasons.NotBucketedIntoVariation
+		return experimentDecision, reasons, nil
+	}
+
+	variationID, cmabUUID, err := s.getVariationID(decisionContext, userContext, options, reasons)
+	if err != nil {
+		reasons.AddError(logging.CmabFetchFailed.String(), userContext.ID, experiment.Key)
+		s.logger.Error(fmt.Sprintf(logging.CmabFetchFailed.String(), userContext.ID, experiment.Key), err)
+		experimentDecision.Reason = pkgReasons.CmabFetchFailed
+		return experimentDecision, reasons, fmt.Errorf("%w for rule %q: %w", ErrCmabFetchFailed, experiment.Key, err)
+	}
+
+	variation, ok := experiment.Variations[variationID]
+	if !ok {
+		experimentDecision.Reason = pkgReasons.BucketedVariationNotFound
+		return experimentDecision, reasons, nil
+	}
+
+	experimentDecision.Reason = pkgReasons.CmabVariationAssigned
+	experimentDecision.Variation = &variation
+	experimentDecision.CmabUUID = cmabUUID
+	return experimentDecision, reasons, nil
+}
+
+// ResetCache clears all cached CMAB decisions
+func (s CmabService) ResetCache() {
+	s.cache.Reset()
+}
+
+func (s CmabService) isInTrafficAllocation(bucketingID string, experiment entities.Experiment, decisionContext ExperimentDecisionContext) bool {
+	if experiment.GroupID != "" {
+		// @TODO: figure out what to do if group is not found
+		group, _ := decisionContext.ProjectConfig.GetGroupByID(experiment.GroupID)
+		if group.Policy == "random" && s.bucketer.BucketToEntity(bucketingID+group.ID, group.TrafficAllocation) != experiment.ID {
+			return false
+		}
+	}
+
+	trafficAllocation := []entities.Range{{EntityID: cmabTrafficEntityID, EndOfRange: experiment.Cmab.TrafficAllocation}}
+	return s.bucketer.BucketToEntity(bucketingID+experiment.ID, trafficAllocation) == cmabTrafficEntityID
+}
+
+// getVariationID returns the variation predicted for the user and the ID of the prediction, which is reused with the
+// cached variation so that impressions of the same prediction can be joined
+func (s CmabService) getVariationID(decisionContext ExperimentDecisionContext, userContext entities.UserContext, options *decide.Options, reasons decide.DecisionReasons) (variationID, cmabUUID string, err error) {
+	experiment := decisionContext.Experiment
+	if options.ResetCMABCache {
+		s.cache.Reset()
+	}
+
+	attributes := s.filterAttributes(decisionContext, userContext)
+	attributesHash := hashCmabAttributes(attributes)
+	cacheKey := getCmabCacheKey(userContext.ID, experiment.ID)
+
+	if !options.IgnoreCMABCache {
+		if cached, ok := s.cache.Lookup(cacheKey).(cmabCacheValue); ok && cached.attributesHash == attributesHash {
+			logMessage := reasons.AddInfo(logging.CmabDecisionFromCache.String(), userContext.ID, experiment.Key)
+			s.logger.Debug(logMessage)
+			return cached.variationID, cached.cmabUUID, nil
+		}
+	}
+
+	ctx := decisionContext.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmabUUID = guuid.New().String()
+	variationID, err = s.client.FetchDecision(ctx, experiment.ID, userContext.ID, attributes, cmabUUID)
+	if err != nil {
+		return "", "", err
+	}
+	logMessage := reasons.AddInfo(logging.CmabDecisionFetched.String(), userContext.ID, experiment.Key, variationID)
+	s.logger.Debug(logMessage)
+
+	if !options.IgnoreCMABCache {
+		s.cache.Save(cacheKey, cmabCacheValue{
+			attributesHash: attributesHash,
+			variationID:    variationID,
+			cmabUUID:       cmabUUID,
+		})
+	}
+	return variationID, cmabUUID, nil
+}
+
+// filterAttributes returns the user attributes used by the CMAB rule, keyed by attribute ID
+func (s CmabService) filterAttributes(decisionContext ExperimentDecisionContext, userContext entities.UserContext) map[string]interface{} {
+	attributeKeys := map[string]string{}
+	for _, attribute := range decisionContext.ProjectConfig.GetAttributes() {
+		attributeKeys[attribute.ID] = attribute.Key
+	}
+
+	filteredAttributes := map[string]interface{}{}
+	for _, attributeID := range decisionContext.Experiment.Cmab.AttributeIds {
+		key, ok := attributeKeys[attributeID]
+		if !ok {
+			continue
+		}
+		if value, ok := userContext.Attributes[key]; ok {
+			filteredAttributes[attributeID] = value
+		}
+	}
+	return filteredAttributes
+}
+
+func getCmabCacheKey(userID, ruleID string) string {
+	return strconv.Itoa(len(userID)) + "-" + userID + "-" + ruleID
+}
+
+func hashCmabAttributes(attributes map[string]interface{}) string {
+	// map keys are sorted when marshalled, so equal attributes produce equal hashes
+	data, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(attributes)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(murmur3.Sum32(data)), 16)
+}
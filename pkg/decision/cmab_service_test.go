@@ -0,0 +1,267 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package decision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/bucketer"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/odp/cache"
+)
+
+type MockCmabClient struct {
+	mock.Mock
+}
+
+func (m *MockCmabClient) FetchDecision(ctx context.Context, ruleID, userID string, attributes map[string]interface{}, cmabUUID string) (string, error) {
+	args := m.Called(ruleID, userID, attributes, cmabUUID)
+	return args.String(0), args.Error(1)
+}
+
+type CmabServiceTestSuite struct {
+	suite.Suite
+	mockConfig          *mockProjectConfig
+	mockCmabClient      *MockCmabClient
+	cmabService         *CmabService
+	testCmabExperiment  entities.Experiment
+	testDecisionContext ExperimentDecisionContext
+	testUserContext     entities.UserContext
+	options             *decide.Options
+}
+
+func (s *CmabServiceTestSuite) SetupTest() {
+	s.mockConfig = new(mockProjectConfig)
+	s.mockConfig.On("GetAttributes").Return([]entities.Attribute{
+		{ID: "a1", Key: "age"},
+		{ID: "a2", Key: "country"},
+	})
+	s.mockCmabClient = new(MockCmabClient)
+	s.cmabService = NewCmabService("sdkKey", WithCmabClient(s.mockCmabClient))
+
+	s.testCmabExperiment = testExp1111
+	s.testCmabExperiment.Cmab = &entities.Cmab{AttributeIds: []string{"a1"}, TrafficAllocation: 10000}
+	s.testDecisionContext = ExperimentDecisionContext{
+		Experiment:    &s.testCmabExperiment,
+		ProjectConfig: s.mockConfig,
+	}
+	s.testUserContext = entities.UserContext{
+		ID:         "test_user_1",
+		Attributes: map[string]interface{}{"age": 30, "country": "us"},
+	}
+	s.options = &decide.Options{}
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionIgnoresNonCmabExperiment() {
+	testDecisionContext := ExperimentDecisionContext{
+		Experiment:    &testExp1111,
+		ProjectConfig: s.mockConfig,
+	}
+	decision, _, err := s.cmabService.GetDecision(testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(ExperimentDecision{}, decision)
+	s.mockCmabClient.AssertNotCalled(s.T(), "FetchDecision", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionFetchesAndCaches() {
+	expectedAttributes := map[string]interface{}{"a1": 30}
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", expectedAttributes, mock.Anything).Return("2222", nil).Once()
+
+	s.options.IncludeReasons = true
+	decision, rsons, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(&testExp1111Var2222, decision.Variation)
+	s.Equal(reasons.CmabVariationAssigned, decision.Reason)
+	s.Equal([]string{`Fetched CMAB decision for user "test_user_1" and rule "test_experiment_1111": variation "2222".`}, rsons.ToReport())
+	// the decision carries the ID the prediction was requested with
+	cmabUUID := s.mockCmabClient.Calls[0].Arguments.String(3)
+	s.NotEmpty(cmabUUID)
+	s.Equal(cmabUUID, decision.CmabUUID)
+
+	// Attributes which are not used by the rule do not invalidate the cache
+	s.testUserContext.Attributes["country"] = "ca"
+	decision, rsons, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(&testExp1111Var2222, decision.Variation)
+	s.Equal([]string{`Using cached CMAB decision for user "test_user_1" and rule "test_experiment_1111".`}, rsons.ToReport())
+	s.Equal(cmabUUID, decision.CmabUUID)
+	s.mockCmabClient.AssertExpectations(s.T())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionRefetchesWhenAttributesChange() {
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", map[string]interface{}{"a1": 30}, mock.Anything).Return("2222", nil).Once()
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", map[string]interface{}{"a1": 31}, mock.Anything).Return("2222", nil).Once()
+
+	_, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.testUserContext.Attributes["age"] = 31
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.mockCmabClient.AssertExpectations(s.T())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionCacheExpires() {
+	s.cmabService = NewCmabService("sdkKey", WithCmabClient(s.mockCmabClient), WithCmabCacheTimeout(time.Millisecond))
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", mock.Anything, mock.Anything).Return("2222", nil).Twice()
+
+	_, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.mockCmabClient.AssertExpectations(s.T())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionIgnoreCMABCache() {
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", mock.Anything, mock.Anything).Return("2222", nil).Times(3)
+
+	// a decision made while ignoring the cache is neither read from nor saved to the cache
+	_, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	ignoreOptions := &decide.Options{IgnoreCMABCache: true}
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, ignoreOptions)
+	s.NoError(err)
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, ignoreOptions)
+	s.NoError(err)
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.mockCmabClient.AssertExpectations(s.T())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionResetCMABCache() {
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", mock.Anything, mock.Anything).Return("2222", nil).Twice()
+
+	_, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, &decide.Options{ResetCMABCache: true})
+	s.NoError(err)
+	// the fresh decision is cached again after the reset
+	_, _, err = s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.mockCmabClient.AssertExpectations(s.T())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionNotInTrafficAllocation() {
+	s.testCmabExperiment.Cmab.TrafficAllocation = 0
+	decision, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.NotBucketedIntoVariation, decision.Reason)
+	s.mockCmabClient.AssertNotCalled(s.T(), "FetchDecision", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionFailsTargeting() {
+	mockAudienceTreeEvaluator := new(MockAudienceTreeEvaluator)
+	mockAudienceTreeEvaluator.On("Evaluate", mock.Anything, mock.Anything, mock.Anything).Return(false, true, decide.NewDecisionReasons(s.options))
+	s.cmabService.audienceTreeEvaluator = mockAudienceTreeEvaluator
+	s.mockConfig.On("GetAudienceMap").Return(map[string]entities.Audience{})
+	s.testCmabExperiment.AudienceConditionTree = &entities.TreeNode{Operator: "or"}
+
+	decision, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.FailedAudienceTargeting, decision.Reason)
+	s.mockCmabClient.AssertNotCalled(s.T(), "FetchDecision", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionFetchFails() {
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", mock.Anything, mock.Anything).Return("", errors.New("prediction failed"))
+
+	s.options.IncludeReasons = true
+	decision, rsons, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.ErrorIs(err, ErrCmabFetchFailed)
+	s.EqualError(err, `failed to fetch CMAB decision for rule "test_experiment_1111": prediction failed`)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.CmabFetchFailed, decision.Reason)
+	s.Equal([]string{`Failed to fetch CMAB decision for user "test_user_1" and rule "test_experiment_1111".`}, rsons.ToReport())
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionUnknownVariation() {
+	s.mockCmabClient.On("FetchDecision", "1111", "test_user_1", mock.Anything, mock.Anything).Return("9999", nil)
+
+	decision, _, err := s.cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.BucketedVariationNotFound, decision.Reason)
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionWithPredictionServer() {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		s.Equal("/predict/1111", r.URL.Path)
+		fmt.Fprint(w, `{"predictions":[{"variation_id":"2222"}]}`)
+	}))
+	defer server.Close()
+
+	cmabService := NewCmabService("sdkKey", WithCmabPredictionEndpoint(server.URL+"/predict/%s"))
+	decision, _, err := cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(&testExp1111Var2222, decision.Variation)
+	decision, _, err = cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(&testExp1111Var2222, decision.Variation)
+	s.Equal(1, requests)
+}
+
+func (s *CmabServiceTestSuite) TestGetDecisionWithPredictionServerContextDone() {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// the prediction request is given up once the context of the decision is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.testDecisionContext.Context = ctx
+	cmabService := NewCmabService("sdkKey", WithCmabPredictionEndpoint(server.URL+"/predict/%s"))
+	decision, _, err := cmabService.GetDecision(s.testDecisionContext, s.testUserContext, s.options)
+	s.ErrorIs(err, ErrCmabFetchFailed)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Nil(decision.Variation)
+}
+
+func (s *CmabServiceTestSuite) TestNewCmabServiceWithOptions() {
+	cmabCache := cache.NewLRUCache(1, time.Minute)
+	cmabService := NewCmabService("sdkKey", WithCmabCache(cmabCache), WithCmabCacheSize(5), WithCmabCacheTimeout(time.Second))
+	s.Equal(cmabCache, cmabService.cache)
+	s.Equal(5, cmabService.cacheSize)
+	s.Equal(time.Second, cmabService.cacheTimeout)
+	s.IsType(&DefaultCmabClient{}, cmabService.client)
+	s.IsType(&evaluator.MixedTreeEvaluator{}, cmabService.audienceTreeEvaluator)
+	s.IsType(&bucketer.MurmurhashBucketer{}, cmabService.bucketer)
+	s.Equal(logging.GetLogger("sdkKey", "CmabService"), cmabService.logger)
+}
+
+func TestCmabServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CmabServiceTestSuite))
+}
@@ -19,6 +19,7 @@ package decision
 
 import (
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 )
@@ -40,11 +41,19 @@ func WithOverrideStore(overrideStore ExperimentOverrideStore) CESOptionFunc {
 	}
 }
 
+// WithCmabService sets the experiment service used to decide CMAB rules, without it users are not bucketed into CMAB rules
+func WithCmabService(cmabService ExperimentService) CESOptionFunc {
+	return func(f *CompositeExperimentService) {
+		f.cmabService = cmabService
+	}
+}
+
 // CompositeExperimentService bridges together the various experiment decision services that ship by default with the SDK
 type CompositeExperimentService struct {
 	experimentServices []ExperimentService
 	overrideStore      ExperimentOverrideStore
 	userProfileService UserProfileService
+	cmabService        ExperimentService
 	logger             logging.OptimizelyLogProducer
 }
 
@@ -54,6 +63,7 @@ func NewCompositeExperimentService(sdkKey string, options ...CESOptionFunc) *Com
 	// 1. Overrides (if supplied)
 	// 2. Whitelist
 	// 3. Bucketing (with User profile integration if supplied)
+	// 4. CMAB (contextual multi-armed bandit rules only, if supplied)
 	compositeExperimentService := &CompositeExperimentService{logger: logging.GetLogger(sdkKey, "CompositeExperimentService")}
 	for _, opt := range options {
 		opt(compositeExperimentService)
@@ -75,6 +85,11 @@ func NewCompositeExperimentService(sdkKey string, options ...CESOptionFunc) *Com
 	} else {
 		experimentServices = append(experimentServices, experimentBucketerService)
 	}
+
+	// CMAB rules send user attributes to the prediction endpoint, so they are only decided once a CMAB service is set
+	if compositeExperimentService.cmabService != nil {
+		experimentServices = append(experimentServices, compositeExperimentService.cmabService)
+	}
 	compositeExperimentService.experimentServices = experimentServices
 
 	return compositeExperimentService
@@ -82,8 +97,15 @@ func NewCompositeExperimentService(sdkKey string, options ...CESOptionFunc) *Com
 
 // GetDecision returns a decision for the given experiment and user context
 func (s CompositeExperimentService) GetDecision(decisionContext ExperimentDecisionContext, userContext entities.UserContext, options *decide.Options) (decision ExperimentDecision, reasons decide.DecisionReasons, err error) {
-	// Run through the various decision services until we get a decision
 	reasons = decide.NewDecisionReasons(options)
+	if experiment := decisionContext.Experiment; experiment != nil && !experiment.IsRunning() {
+		logMessage := reasons.AddInfo(logging.ExperimentNotRunning.String(), experiment.Key, experiment.Status)
+		s.logger.Debug(logMessage)
+		decision.Reason = pkgReasons.ExperimentNotRunning
+		return decision, reasons, nil
+	}
+
+	// Run through the various decision services until we get a decision
 	for _, experimentService := range s.experimentServices {
 		var decisionReasons decide.DecisionReasons
 		decision, decisionReasons, err = experimentService.GetDecision(decisionContext, userContext, options)
@@ -96,5 +118,10 @@ func (s CompositeExperimentService) GetDecision(decisionContext ExperimentDecisi
 		}
 	}
 
+	if experiment := decisionContext.Experiment; experiment != nil && experiment.Cmab != nil && s.cmabService == nil {
+		logMessage := reasons.AddInfo(logging.CmabServiceNotConfigured.String(), experiment.Key)
+		s.logger.Debug(logMessage)
+		decision.Reason = pkgReasons.CmabServiceNotConfigured
+	}
 	return decision, reasons, err
 }
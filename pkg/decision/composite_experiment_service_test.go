@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 )
@@ -75,6 +76,31 @@ func (s *CompositeExperimentTestSuite) TestGetDecision() {
 
 }
 
+func (s *CompositeExperimentTestSuite) TestGetDecisionExperimentNotRunning() {
+	// test that no decision service is consulted when the experiment is not running
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+
+	pausedExperiment := testExp1111
+	pausedExperiment.Status = entities.ExperimentStatusPaused
+	decisionContext := ExperimentDecisionContext{
+		Experiment:    &pausedExperiment,
+		ProjectConfig: s.mockConfig,
+	}
+
+	compositeExperimentService := &CompositeExperimentService{
+		experimentServices: []ExperimentService{s.mockExperimentService, s.mockExperimentService2},
+		logger:             logging.GetLogger("sdkKey", "ExperimentService"),
+	}
+	decision, _, err := compositeExperimentService.GetDecision(decisionContext, testUserContext, s.options)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.ExperimentNotRunning, decision.Reason)
+	s.NoError(err)
+	s.mockExperimentService.AssertNotCalled(s.T(), "GetDecision")
+	s.mockExperimentService2.AssertNotCalled(s.T(), "GetDecision")
+}
+
 func (s *CompositeExperimentTestSuite) TestGetDecisionFallthrough() {
 	// test that we move onto the next decision service if no decision is made
 	testUserContext := entities.UserContext{
@@ -166,17 +192,45 @@ func (s *CompositeExperimentTestSuite) TestNewCompositeExperimentService() {
 	s.Equal(2, len(compositeExperimentService.experimentServices))
 	s.IsType(&ExperimentWhitelistService{}, compositeExperimentService.experimentServices[0])
 	s.IsType(&ExperimentBucketerService{}, compositeExperimentService.experimentServices[1])
+	// CMAB rules call out to the prediction endpoint, so they need to be opted into
+	s.Nil(compositeExperimentService.cmabService)
+}
+
+func (s *CompositeExperimentTestSuite) TestGetDecisionCmabRuleWithoutCmabService() {
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+	cmabExperiment := testExp1114
+	cmabExperiment.Cmab = &entities.Cmab{TrafficAllocation: 10000}
+	testDecisionContext := ExperimentDecisionContext{
+		Experiment:    &cmabExperiment,
+		ProjectConfig: s.mockConfig,
+	}
+	s.mockExperimentService.On("GetDecision", testDecisionContext, testUserContext, s.options).Return(ExperimentDecision{}, s.reasons, nil)
+
+	compositeExperimentService := &CompositeExperimentService{
+		experimentServices: []ExperimentService{s.mockExperimentService},
+		logger:             logging.GetLogger("sdkKey", "CompositeExperimentService"),
+	}
+	decision, _, err := compositeExperimentService.GetDecision(testDecisionContext, testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.CmabServiceNotConfigured, decision.Reason)
 }
 
 func (s *CompositeExperimentTestSuite) TestNewCompositeExperimentServiceWithCustomOptions() {
 	mockUserProfileService := new(MockUserProfileService)
 	mockExperimentOverrideStore := new(MapExperimentOverridesStore)
+	mockCmabService := new(MockExperimentDecisionService)
 	compositeExperimentService := NewCompositeExperimentService("",
 		WithUserProfileService(mockUserProfileService),
 		WithOverrideStore(mockExperimentOverrideStore),
+		WithCmabService(mockCmabService),
 	)
 	s.Equal(mockUserProfileService, compositeExperimentService.userProfileService)
 	s.Equal(mockExperimentOverrideStore, compositeExperimentService.overrideStore)
+	s.Equal(mockCmabService, compositeExperimentService.cmabService)
+	s.Equal(mockCmabService, compositeExperimentService.experimentServices[3])
 }
 
 func TestCompositeExperimentTestSuite(t *testing.T) {
@@ -18,6 +18,8 @@
 package decision
 
 import (
+	"errors"
+
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
@@ -30,12 +32,13 @@ type CompositeFeatureService struct {
 }
 
 // NewCompositeFeatureService returns a new instance of the CompositeFeatureService
-func NewCompositeFeatureService(sdkKey string, compositeExperimentService ExperimentService) *CompositeFeatureService {
+func NewCompositeFeatureService(sdkKey string, compositeExperimentService ExperimentService, rolloutOptions ...RSOptionFunc) *CompositeFeatureService {
 	return &CompositeFeatureService{
 		logger: logging.GetLogger(sdkKey, "CompositeFeatureService"),
 		featureServices: []FeatureService{
+			NewHoldoutService(sdkKey),
 			NewFeatureExperimentService(logging.GetLogger(sdkKey, "FeatureExperimentService"), compositeExperimentService),
-			NewRolloutService(sdkKey),
+			NewRolloutService(sdkKey, rolloutOptions...),
 		},
 	}
 }
@@ -52,6 +55,9 @@ func (f CompositeFeatureService) GetDecision(decisionContext FeatureDecisionCont
 		if err != nil {
 			f.logger.Debug(err.Error())
 		}
+		if errors.Is(err, ErrCmabFetchFailed) {
+			return featureDecision, reasons, err
+		}
 
 		if featureDecision.Variation != nil && err == nil {
 			return featureDecision, reasons, err
@@ -18,6 +18,7 @@ package decision
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
@@ -165,13 +166,41 @@ func (s *CompositeFeatureServiceTestSuite) TestGetDecisionReturnsLastDecisionWit
 	s.mockFeatureService2.AssertExpectations(s.T())
 }
 
+func (s *CompositeFeatureServiceTestSuite) TestGetDecisionStopsAtCmabFetchError() {
+	// test that a user who may be in a CMAB rule does not fall through to the next decision service
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+
+	cmabDecision := FeatureDecision{
+		Decision:   Decision{reasons.CmabFetchFailed},
+		Experiment: testExp1113,
+		Source:     FeatureTest,
+	}
+	s.mockFeatureService.On("GetDecision", s.testFeatureDecisionContext, testUserContext, s.options).Return(cmabDecision, s.reasons, fmt.Errorf("%w: timeout", ErrCmabFetchFailed))
+
+	compositeFeatureService := &CompositeFeatureService{
+		featureServices: []FeatureService{
+			s.mockFeatureService,
+			s.mockFeatureService2,
+		},
+		logger: logging.GetLogger("sdkKey", "CompositeFeatureService"),
+	}
+	decision, _, err := compositeFeatureService.GetDecision(s.testFeatureDecisionContext, testUserContext, s.options)
+	s.Equal(cmabDecision, decision)
+	s.ErrorIs(err, ErrCmabFetchFailed)
+	s.mockFeatureService.AssertExpectations(s.T())
+	s.mockFeatureService2.AssertNotCalled(s.T(), "GetDecision")
+}
+
 func (s *CompositeFeatureServiceTestSuite) TestNewCompositeFeatureService() {
 	// Assert that the service is instantiated with the correct child services in the right order
 	compositeExperimentService := NewCompositeExperimentService("")
 	compositeFeatureService := NewCompositeFeatureService("", compositeExperimentService)
-	s.Equal(2, len(compositeFeatureService.featureServices))
-	s.IsType(&FeatureExperimentService{compositeExperimentService: compositeExperimentService}, compositeFeatureService.featureServices[0])
-	s.IsType(&RolloutService{}, compositeFeatureService.featureServices[1])
+	s.Equal(3, len(compositeFeatureService.featureServices))
+	s.IsType(&HoldoutService{}, compositeFeatureService.featureServices[0])
+	s.IsType(&FeatureExperimentService{compositeExperimentService: compositeExperimentService}, compositeFeatureService.featureServices[1])
+	s.IsType(&RolloutService{}, compositeFeatureService.featureServices[2])
 }
 
 func TestCompositeFeatureTestSuite(t *testing.T) {
@@ -31,6 +31,7 @@ import (
 type CompositeService struct {
 	compositeExperimentService ExperimentService
 	compositeFeatureService    FeatureService
+	rolloutOptions             []RSOptionFunc
 	notificationCenter         notification.Center
 	logger                     logging.OptimizelyLogProducer
 }
@@ -45,6 +46,13 @@ func WithCompositeExperimentService(compositeExperimentService ExperimentService
 	}
 }
 
+// WithRolloutServiceOptions sets the options of the rollout service of the CompositeService, e.g. WithStickyRollouts
+func WithRolloutServiceOptions(options ...RSOptionFunc) CSOptionFunc {
+	return func(f *CompositeService) {
+		f.rolloutOptions = append(f.rolloutOptions, options...)
+	}
+}
+
 // NewCompositeService returns a new instance of the CompositeService with the defaults
 func NewCompositeService(sdkKey string, options ...CSOptionFunc) *CompositeService {
 	compositeService := &CompositeService{
@@ -59,7 +67,7 @@ func NewCompositeService(sdkKey string, options ...CSOptionFunc) *CompositeServi
 	if compositeService.compositeExperimentService == nil {
 		compositeService.compositeExperimentService = NewCompositeExperimentService(sdkKey)
 	}
-	compositeService.compositeFeatureService = NewCompositeFeatureService(sdkKey, compositeService.compositeExperimentService)
+	compositeService.compositeFeatureService = NewCompositeFeatureService(sdkKey, compositeService.compositeExperimentService, compositeService.rolloutOptions...)
 
 	return compositeService
 }
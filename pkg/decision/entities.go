@@ -18,6 +18,8 @@
 package decision
 
 import (
+	"context"
+
 	"github.com/optimizely/go-sdk/v2/pkg/config"
 	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
@@ -25,16 +27,24 @@ import (
 
 // ExperimentDecisionContext contains the information needed to be able to make a decision for a given experiment
 type ExperimentDecisionContext struct {
+	// Context carries the caller's deadline and cancellation; it may be nil
+	Context       context.Context
 	Experiment    *entities.Experiment
 	ProjectConfig config.ProjectConfig
+	// UserProfileTracker shares one user profile lookup and save between the decisions of a call; it may be nil
+	UserProfileTracker *UserProfileTracker
 }
 
 // FeatureDecisionContext contains the information needed to be able to make a decision for a given feature
 type FeatureDecisionContext struct {
+	// Context carries the caller's deadline and cancellation; it may be nil
+	Context               context.Context
 	Feature               *entities.Feature
 	ProjectConfig         config.ProjectConfig
 	Variable              entities.Variable
 	ForcedDecisionService *ForcedDecisionService
+	// UserProfileTracker shares one user profile lookup and save between the decisions of a call; it may be nil
+	UserProfileTracker *UserProfileTracker
 }
 
 // UnsafeFeatureDecisionInfo represents response for GetDetailedFeatureDecisionUnsafe api
@@ -53,6 +63,8 @@ const (
 	Rollout Source = "rollout"
 	// FeatureTest - the decision came from a feature test
 	FeatureTest Source = "feature-test"
+	// Holdout - the decision came from a holdout
+	Holdout Source = "holdout"
 )
 
 // Decision contains base information about a decision
@@ -66,12 +78,14 @@ type FeatureDecision struct {
 	Source     Source
 	Experiment entities.Experiment
 	Variation  *entities.Variation
+	CmabUUID   string // the ID of the CMAB prediction the variation comes from, empty for other rules
 }
 
 // ExperimentDecision contains the decision information about an experiment
 type ExperimentDecision struct {
 	Decision
 	Variation *entities.Variation
+	CmabUUID  string // the ID of the CMAB prediction the variation comes from, empty for other rules
 }
 
 // UserDecisionKey is used to access the saved decisions in a user profile
@@ -23,6 +23,7 @@ import (
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator/matchers"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 )
@@ -100,6 +101,12 @@ func (c AudienceConditionEvaluator) Evaluate(audienceID string, condTreeParams *
 		}
 		logMessage := fmt.Sprintf(logging.AudienceEvaluatedTo.String(), audienceID, retValue)
 		c.logger.Debug(logMessage)
+		reasons.AddDetail(decide.StructuredReason{
+			Code:       pkgReasons.AudienceEvaluated,
+			AudienceID: audienceID,
+			Result:     decide.EvaluationResult(retValue),
+			Message:    logMessage,
+		})
 		return retValue, reasons, nil
 	}
 
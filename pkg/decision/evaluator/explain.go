@@ -0,0 +1,168 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package evaluator //
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator/matchers"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// ExplainedNode is a node of a condition tree along with the result of its evaluation for a user
+type ExplainedNode struct {
+	// Operator is the and/or/not operator combining Nodes, empty for leaves
+	Operator string `json:"operator,omitempty"`
+
+	// AudienceID and AudienceName are set for audience leaves, Nodes then holds the audience conditions
+	AudienceID   string `json:"audienceId,omitempty"`
+	AudienceName string `json:"audienceName,omitempty"`
+
+	// ConditionName, ConditionType, Matcher and ConditionValue are set for condition leaves
+	ConditionName  string      `json:"conditionName,omitempty"`
+	ConditionType  string      `json:"conditionType,omitempty"`
+	Matcher        string      `json:"matcher,omitempty"`
+	ConditionValue interface{} `json:"conditionValue,omitempty"`
+	// AttributeValue is the user's value for the condition, nil if the user does not have the attribute.
+	// The qualified segments of the user are used for "qualified" conditions.
+	AttributeValue interface{} `json:"attributeValue,omitempty"`
+
+	// Result is the result of the node, nil if it could not be evaluated (null)
+	Result *bool `json:"result"`
+	// Error tells why the node could not be evaluated
+	Error string `json:"error,omitempty"`
+	// Skipped is true if the evaluator short-circuits before reaching this node
+	Skipped bool `json:"skipped,omitempty"`
+
+	Nodes []*ExplainedNode `json:"nodes,omitempty"`
+}
+
+// Explain evaluates every node of the given tree, unlike Evaluate it does not stop at the first node deciding the result.
+// The result of each node is the one Evaluate would return for it.
+func (c MixedTreeEvaluator) Explain(node *entities.TreeNode, condTreeParams *entities.TreeParameters) *ExplainedNode {
+	if node.Operator != "" {
+		explained := &ExplainedNode{Operator: node.Operator}
+		for _, child := range node.Nodes {
+			explained.Nodes = append(explained.Nodes, c.Explain(child, condTreeParams))
+		}
+		explained.Result = c.combine(node.Operator, explained.Nodes)
+		return explained
+	}
+
+	switch item := node.Item.(type) {
+	case entities.Condition:
+		return c.explainCondition(item, condTreeParams)
+	case string:
+		return c.explainAudience(item, condTreeParams)
+	default:
+		return &ExplainedNode{Error: fmt.Sprintf("unknown condition tree item of type %T", item)}
+	}
+}
+
+func (c MixedTreeEvaluator) explainCondition(condition entities.Condition, condTreeParams *entities.TreeParameters) *ExplainedNode {
+	explained := &ExplainedNode{
+		ConditionName:  condition.Name,
+		ConditionType:  condition.Type,
+		Matcher:        condition.Match,
+		ConditionValue: condition.Value,
+	}
+	if explained.Matcher == "" {
+		explained.Matcher = matchers.ExactMatchType
+	}
+
+	user := condTreeParams.User
+	if explained.Matcher == matchers.QualifiedMatchType {
+		explained.AttributeValue = user.QualifiedSegments
+	} else if value, ok := user.Attributes[condition.Name]; ok {
+		explained.AttributeValue = value
+	}
+
+	result, _, err := NewCustomAttributeConditionEvaluator(c.logger).Evaluate(condition, condTreeParams, &decide.Options{})
+	if err != nil {
+		explained.Error = err.Error()
+		return explained
+	}
+	explained.Result = &result
+	return explained
+}
+
+func (c MixedTreeEvaluator) explainAudience(audienceID string, condTreeParams *entities.TreeParameters) *ExplainedNode {
+	explained := &ExplainedNode{AudienceID: audienceID}
+	audience, ok := condTreeParams.AudienceMap[audienceID]
+	if !ok {
+		explained.Error = fmt.Sprintf(`audience ID "%s" is not in the datafile`, audienceID)
+		return explained
+	}
+	explained.AudienceName = audience.Name
+	if audience.ConditionTree == nil {
+		explained.Error = fmt.Sprintf(`audience ID "%s" has no conditions`, audienceID)
+		return explained
+	}
+
+	conditions := c.Explain(audience.ConditionTree, condTreeParams)
+	explained.Result = conditions.Result
+	if conditions.Result == nil {
+		explained.Error = fmt.Sprintf(`an error occurred while evaluating nested tree for audience ID "%s"`, audienceID)
+	}
+	explained.Nodes = []*ExplainedNode{conditions}
+	return explained
+}
+
+// combine mirrors evaluateAnd, evaluateNot and evaluateOr, marking the nodes they would not have reached as skipped
+func (c MixedTreeEvaluator) combine(operator string, nodes []*ExplainedNode) *bool {
+	skipAfter := func(index int) {
+		for _, node := range nodes[index+1:] {
+			node.Skipped = true
+		}
+	}
+
+	switch operator {
+	case andOperator:
+		for index, node := range nodes {
+			if node.Result == nil || !*node.Result {
+				skipAfter(index)
+				return node.Result
+			}
+		}
+		return decide.EvaluationResult(true)
+	case notOperator:
+		if len(nodes) == 0 {
+			return nil
+		}
+		skipAfter(0)
+		if nodes[0].Result == nil {
+			return nil
+		}
+		return decide.EvaluationResult(!*nodes[0].Result)
+	default: // orOperator
+		sawInvalid := false
+		for index, node := range nodes {
+			if node.Result == nil {
+				sawInvalid = true
+			} else if *node.Result {
+				skipAfter(index)
+				return node.Result
+			}
+		}
+		if sawInvalid {
+			return nil
+		}
+		return decide.EvaluationResult(false)
+	}
+}
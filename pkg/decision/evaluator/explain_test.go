@@ -0,0 +1,126 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	e "github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+func TestExplainAudienceTree(t *testing.T) {
+	audienceMap := map[string]e.Audience{
+		"11111": {
+			ID:   "11111",
+			Name: "foo and 42",
+			ConditionTree: &e.TreeNode{
+				Operator: "and",
+				Nodes:    []*e.TreeNode{{Item: stringFooCondition}, {Item: int42Condition}},
+			},
+		},
+		"11112": {
+			ID:   "11112",
+			Name: "bool true",
+			ConditionTree: &e.TreeNode{
+				Operator: "or",
+				Nodes:    []*e.TreeNode{{Item: boolTrueCondition}},
+			},
+		},
+	}
+	audienceTree := &e.TreeNode{
+		Operator: "or",
+		Nodes:    []*e.TreeNode{{Item: "11111"}, {Item: "11112"}, {Item: "11113"}},
+	}
+	user := e.UserContext{Attributes: map[string]interface{}{"string_foo": "foo", "bool_true": true}}
+	treeParams := e.NewTreeParameters(&user, audienceMap)
+	treeEvaluator := NewMixedTreeEvaluator(logging.GetLogger("", "MixedTreeEvaluator"))
+
+	explained := treeEvaluator.Explain(audienceTree, treeParams)
+	result, isValid, _ := treeEvaluator.Evaluate(audienceTree, treeParams, &decide.Options{})
+	assert.True(t, isValid)
+	assert.Equal(t, decide.EvaluationResult(result), explained.Result)
+	assert.Equal(t, "or", explained.Operator)
+	assert.Len(t, explained.Nodes, 3)
+
+	// the missing int_42 attribute evaluates to null, which makes the whole audience null
+	fooAnd42 := explained.Nodes[0]
+	assert.Equal(t, "11111", fooAnd42.AudienceID)
+	assert.Equal(t, "foo and 42", fooAnd42.AudienceName)
+	assert.Nil(t, fooAnd42.Result)
+	conditions := fooAnd42.Nodes[0]
+	assert.Equal(t, "and", conditions.Operator)
+	assert.Equal(t, &ExplainedNode{
+		ConditionName:  "string_foo",
+		ConditionType:  "custom_attribute",
+		Matcher:        "exact",
+		ConditionValue: "foo",
+		AttributeValue: "foo",
+		Result:         decide.EvaluationResult(true),
+	}, conditions.Nodes[0])
+	assert.Nil(t, conditions.Nodes[1].Result)
+	assert.Nil(t, conditions.Nodes[1].AttributeValue)
+	assert.NotEmpty(t, conditions.Nodes[1].Error)
+
+	boolTrue := explained.Nodes[1]
+	assert.Equal(t, decide.EvaluationResult(true), boolTrue.Result)
+	assert.False(t, boolTrue.Skipped)
+	assert.Equal(t, true, boolTrue.Nodes[0].Nodes[0].AttributeValue)
+
+	// the unknown audience is explained even though the evaluator never reaches it
+	unknown := explained.Nodes[2]
+	assert.True(t, unknown.Skipped)
+	assert.Nil(t, unknown.Result)
+	assert.Equal(t, `audience ID "11113" is not in the datafile`, unknown.Error)
+}
+
+func TestExplainMirrorsEvaluate(t *testing.T) {
+	trueNode := &e.TreeNode{Item: stringFooCondition}
+	falseNode := &e.TreeNode{Item: boolTrueCondition}
+	nullNode := &e.TreeNode{Item: int42Condition}
+	user := e.UserContext{Attributes: map[string]interface{}{"string_foo": "foo", "bool_true": false}}
+	treeParams := e.NewTreeParameters(&user, map[string]e.Audience{})
+	treeEvaluator := NewMixedTreeEvaluator(logging.GetLogger("", "MixedTreeEvaluator"))
+
+	trees := []*e.TreeNode{
+		{Operator: "and", Nodes: []*e.TreeNode{trueNode, falseNode, nullNode}},
+		{Operator: "and", Nodes: []*e.TreeNode{nullNode, falseNode}},
+		{Operator: "and", Nodes: []*e.TreeNode{trueNode, trueNode}},
+		{Operator: "or", Nodes: []*e.TreeNode{nullNode, falseNode}},
+		{Operator: "or", Nodes: []*e.TreeNode{falseNode, nullNode, trueNode}},
+		{Operator: "or", Nodes: []*e.TreeNode{falseNode, falseNode}},
+		{Operator: "not", Nodes: []*e.TreeNode{falseNode}},
+		{Operator: "not", Nodes: []*e.TreeNode{nullNode}},
+		{Operator: "not"},
+	}
+	for _, tree := range trees {
+		result, isValid, _ := treeEvaluator.Evaluate(tree, treeParams, &decide.Options{})
+		explained := treeEvaluator.Explain(tree, treeParams)
+		if isValid {
+			assert.Equal(t, decide.EvaluationResult(result), explained.Result)
+		} else {
+			assert.Nil(t, explained.Result)
+		}
+	}
+
+	explained := treeEvaluator.Explain(trees[0], treeParams)
+	assert.False(t, explained.Nodes[1].Skipped)
+	assert.True(t, explained.Nodes[2].Skipped)
+}
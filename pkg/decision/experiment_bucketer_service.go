@@ -51,22 +51,52 @@ func (s ExperimentBucketerService) GetDecision(decisionContext ExperimentDecisio
 	experiment := decisionContext.Experiment
 	reasons := decide.NewDecisionReasons(options)
 
+	if !experiment.IsRunning() {
+		logMessage := reasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.ExperimentNotRunning,
+			RuleKey: experiment.Key,
+			Message: fmt.Sprintf(logging.ExperimentNotRunning.String(), experiment.Key, experiment.Status),
+		})
+		s.logger.Debug(logMessage)
+		experimentDecision.Reason = pkgReasons.ExperimentNotRunning
+		return experimentDecision, reasons, nil
+	}
+
+	// CMAB rules are decided by the CmabService
+	if experiment.Cmab != nil {
+		return experimentDecision, reasons, nil
+	}
+
 	// Determine if user can be part of the experiment
 	if experiment.AudienceConditionTree != nil {
 		condTreeParams := entities.NewTreeParameters(&userContext, decisionContext.ProjectConfig.GetAudienceMap())
 		s.logger.Debug(fmt.Sprintf(logging.EvaluatingAudiencesForExperiment.String(), experiment.Key))
 		evalResult, _, decisionReasons := s.audienceTreeEvaluator.Evaluate(experiment.AudienceConditionTree, condTreeParams, options)
 		reasons.Append(decisionReasons)
-		logMessage := reasons.AddInfo(logging.ExperimentAudiencesEvaluatedTo.String(), experiment.Key, evalResult)
+		logMessage := reasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.AudiencesEvaluated,
+			RuleKey: experiment.Key,
+			Result:  decide.EvaluationResult(evalResult),
+			Message: fmt.Sprintf(logging.ExperimentAudiencesEvaluatedTo.String(), experiment.Key, evalResult),
+		})
 		s.logger.Debug(logMessage)
 		if !evalResult {
-			logMessage := reasons.AddInfo(logging.UserNotInExperiment.String(), userContext.ID, experiment.Key)
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.FailedAudienceTargeting,
+				RuleKey: experiment.Key,
+				Message: fmt.Sprintf(logging.UserNotInExperiment.String(), userContext.ID, experiment.Key),
+			})
 			s.logger.Debug(logMessage)
 			experimentDecision.Reason = pkgReasons.FailedAudienceTargeting
 			return experimentDecision, reasons, nil
 		}
 	} else {
-		logMessage := reasons.AddInfo(logging.ExperimentAudiencesEvaluatedTo.String(), experiment.Key, true)
+		logMessage := reasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.AudiencesEvaluated,
+			RuleKey: experiment.Key,
+			Result:  decide.EvaluationResult(true),
+			Message: fmt.Sprintf(logging.ExperimentAudiencesEvaluatedTo.String(), experiment.Key, true),
+		})
 		s.logger.Debug(logMessage)
 	}
 
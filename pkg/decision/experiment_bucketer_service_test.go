@@ -177,6 +177,12 @@ func (s *ExperimentBucketerTestSuite) TestGetDecisionWithTargetingFails() {
 	s.Len(messages, 2)
 	s.Equal(`Audiences for experiment test_targeted_experiment_1116 collectively evaluated to false.`, messages[0])
 	s.Equal(`User "test_user_1" does not meet conditions to be in experiment "test_targeted_experiment_1116".`, messages[1])
+	structuredReasons := decide.ToStructuredReport(rsons)
+	s.Len(structuredReasons, 2)
+	s.Equal(reasons.AudiencesEvaluated, structuredReasons[0].Code)
+	s.Equal("test_targeted_experiment_1116", structuredReasons[0].RuleKey)
+	s.Equal(decide.EvaluationResult(false), structuredReasons[0].Result)
+	s.Equal(reasons.FailedAudienceTargeting, structuredReasons[1].Code)
 	s.Equal(expectedDecision, decision)
 	s.NoError(err)
 	s.mockBucketer.AssertNotCalled(s.T(), "Bucket")
@@ -184,6 +190,59 @@ func (s *ExperimentBucketerTestSuite) TestGetDecisionWithTargetingFails() {
 
 }
 
+func (s *ExperimentBucketerTestSuite) TestGetDecisionExperimentNotRunning() {
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+
+	pausedExperiment := testExp1111
+	pausedExperiment.Status = entities.ExperimentStatusPaused
+	expectedDecision := ExperimentDecision{
+		Decision: Decision{
+			Reason: reasons.ExperimentNotRunning,
+		},
+	}
+	s.mockLogger.On("Debug", fmt.Sprintf(logging.ExperimentNotRunning.String(), "test_experiment_1111", entities.ExperimentStatusPaused))
+	experimentBucketerService := ExperimentBucketerService{
+		bucketer: s.mockBucketer,
+		logger:   s.mockLogger,
+	}
+	testDecisionContext := ExperimentDecisionContext{
+		Experiment:    &pausedExperiment,
+		ProjectConfig: s.mockConfig,
+	}
+	s.options.IncludeReasons = true
+	decision, rsons, err := experimentBucketerService.GetDecision(testDecisionContext, testUserContext, s.options)
+	messages := rsons.ToReport()
+	s.Len(messages, 1)
+	s.Equal(`Experiment "test_experiment_1111" is not running (status "Paused").`, messages[0])
+	s.Equal(expectedDecision, decision)
+	s.NoError(err)
+	s.mockBucketer.AssertNotCalled(s.T(), "Bucket")
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+func (s *ExperimentBucketerTestSuite) TestGetDecisionSkipsCmabExperiment() {
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+
+	cmabExperiment := testExp1111
+	cmabExperiment.Cmab = &entities.Cmab{TrafficAllocation: 10000}
+	experimentBucketerService := ExperimentBucketerService{
+		bucketer: s.mockBucketer,
+		logger:   s.mockLogger,
+	}
+	testDecisionContext := ExperimentDecisionContext{
+		Experiment:    &cmabExperiment,
+		ProjectConfig: s.mockConfig,
+	}
+	decision, _, err := experimentBucketerService.GetDecision(testDecisionContext, testUserContext, s.options)
+	s.Equal(ExperimentDecision{}, decision)
+	s.NoError(err)
+	s.mockBucketer.AssertNotCalled(s.T(), "Bucket")
+}
+
 func TestExperimentBucketerTestSuite(t *testing.T) {
 	suite.Run(t, new(ExperimentBucketerTestSuite))
 }
@@ -19,6 +19,7 @@ package decision
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
@@ -53,12 +54,20 @@ func (s ExperimentWhitelistService) GetDecision(decisionContext ExperimentDecisi
 		if variation, ok := decisionContext.Experiment.Variations[id]; ok {
 			decision.Reason = pkgReasons.WhitelistVariationAssignmentFound
 			decision.Variation = &variation
-			reasons.AddInfo(`User "%s" is whitelisted into variation "%s" of experiment "%s".`, userContext.ID, variationKey, decisionContext.Experiment.Key)
+			reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.WhitelistVariationAssignmentFound,
+				RuleKey: decisionContext.Experiment.Key,
+				Message: fmt.Sprintf(`User "%s" is whitelisted into variation "%s" of experiment "%s".`, userContext.ID, variationKey, decisionContext.Experiment.Key),
+			})
 			return decision, reasons, nil
 		}
 	}
 
 	decision.Reason = pkgReasons.InvalidWhitelistVariationAssignment
-	reasons.AddInfo(`User "%s" is whitelisted into variation "%s", which is not in the datafile.`, userContext.ID, variationKey)
+	reasons.AddReason(decide.StructuredReason{
+		Code:    pkgReasons.InvalidWhitelistVariationAssignment,
+		RuleKey: decisionContext.Experiment.Key,
+		Message: fmt.Sprintf(`User "%s" is whitelisted into variation "%s", which is not in the datafile.`, userContext.ID, variationKey),
+	})
 	return decision, reasons, nil
 }
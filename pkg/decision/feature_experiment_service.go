@@ -18,6 +18,7 @@
 package decision
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
@@ -62,8 +63,10 @@ func (f FeatureExperimentService) GetDecision(decisionContext FeatureDecisionCon
 
 		experiment := featureExperiment
 		experimentDecisionContext := ExperimentDecisionContext{
-			Experiment:    &experiment,
-			ProjectConfig: decisionContext.ProjectConfig,
+			Context:            decisionContext.Context,
+			Experiment:         &experiment,
+			ProjectConfig:      decisionContext.ProjectConfig,
+			UserProfileTracker: decisionContext.UserProfileTracker,
 		}
 
 		experimentDecision, decisionReasons, err := f.compositeExperimentService.GetDecision(experimentDecisionContext, userContext, options)
@@ -75,6 +78,11 @@ func (f FeatureExperimentService) GetDecision(decisionContext FeatureDecisionCon
 			experimentDecision.Reason,
 		))
 
+		// the user may be in the CMAB rule, so the later rules must not decide for them
+		if errors.Is(err, ErrCmabFetchFailed) {
+			return FeatureDecision{Experiment: experiment, Decision: experimentDecision.Decision, Source: FeatureTest}, reasons, err
+		}
+
 		// Variation not nil means we got a decision and should return it
 		if experimentDecision.Variation != nil {
 			featureDecision := FeatureDecision{
@@ -82,6 +90,7 @@ func (f FeatureExperimentService) GetDecision(decisionContext FeatureDecisionCon
 				Decision:   experimentDecision.Decision,
 				Variation:  experimentDecision.Variation,
 				Source:     FeatureTest,
+				CmabUUID:   experimentDecision.CmabUUID,
 			}
 
 			return featureDecision, reasons, err
@@ -17,9 +17,11 @@
 package decision
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 
@@ -132,6 +134,31 @@ func (s *FeatureExperimentServiceTestSuite) TestGetDecisionWithForcedDecision()
 	s.mockExperimentService.AssertExpectations(s.T())
 }
 
+func (s *FeatureExperimentServiceTestSuite) TestGetDecisionCmabFetchError() {
+	testUserContext := entities.UserContext{
+		ID: "test_user_1",
+	}
+
+	testExperimentDecisionContext := ExperimentDecisionContext{
+		Experiment:    &testExp1113,
+		ProjectConfig: s.mockConfig,
+	}
+	cmabDecision := ExperimentDecision{Decision: Decision{Reason: reasons.CmabFetchFailed}}
+	s.mockExperimentService.On("GetDecision", testExperimentDecisionContext, testUserContext, s.options).
+		Return(cmabDecision, s.reasons, fmt.Errorf("%w: timeout", ErrCmabFetchFailed))
+
+	featureExperimentService := &FeatureExperimentService{
+		compositeExperimentService: s.mockExperimentService,
+		logger:                     logging.GetLogger("sdkKey", "FeatureExperimentService"),
+	}
+
+	// the second experiment of the feature is not evaluated
+	decision, _, err := featureExperimentService.GetDecision(s.testFeatureDecisionContext, testUserContext, s.options)
+	s.ErrorIs(err, ErrCmabFetchFailed)
+	s.Equal(FeatureDecision{Experiment: testExp1113, Decision: cmabDecision.Decision, Source: FeatureTest}, decision)
+	s.mockExperimentService.AssertNumberOfCalls(s.T(), "GetDecision", 1)
+}
+
 func (s *FeatureExperimentServiceTestSuite) TestGetDecisionMutex() {
 	testUserContext := entities.UserContext{
 		ID: "test_user_1",
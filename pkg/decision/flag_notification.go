@@ -18,12 +18,13 @@
 package decision
 
 import (
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
 )
 
 // FlagNotification constructs default flag notification
-func FlagNotification(flagKey, variationKey, ruleKey string, enabled, decisionEventDispatched bool, userContext entities.UserContext, variables map[string]interface{}, reasons []string) *notification.DecisionNotification {
+func FlagNotification(flagKey, variationKey, ruleKey string, enabled, decisionEventDispatched bool, userContext entities.UserContext, variables map[string]interface{}, reasons []string, structuredReasons []decide.StructuredReason) *notification.DecisionNotification {
 
 	if flagKey == "" {
 		return nil
@@ -36,6 +37,7 @@ func FlagNotification(flagKey, variationKey, ruleKey string, enabled, decisionEv
 		"variationKey":            variationKey,
 		"ruleKey":                 ruleKey,
 		"reasons":                 reasons,
+		"structuredReasons":       structuredReasons,
 		"decisionEventDispatched": decisionEventDispatched,
 	}
 
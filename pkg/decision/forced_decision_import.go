@@ -0,0 +1,171 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package decision //
+package decision
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+)
+
+// ForcedDecisionMapping maps a flag, and optionally one of its rules, to a variation. It is the JSON representation
+// of the forced decisions exported and imported by the ForcedDecisionService.
+type ForcedDecisionMapping struct {
+	FlagKey      string `json:"flagKey"`
+	RuleKey      string `json:"ruleKey,omitempty"`
+	VariationKey string `json:"variationKey"`
+}
+
+// String returns the mapping in the "flag=variation" or "flag:rule=variation" form read by ParseForcedDecision
+func (m ForcedDecisionMapping) String() string {
+	if m.RuleKey == "" {
+		return m.FlagKey + "=" + m.VariationKey
+	}
+	return m.FlagKey + ":" + m.RuleKey + "=" + m.VariationKey
+}
+
+// InvalidForcedDecision reports a forced decision which could not be parsed or imported
+type InvalidForcedDecision struct {
+	// Input is the entry which could not be parsed, or the string form of the mapping which was not imported
+	Input string
+	Err   error
+}
+
+func (i InvalidForcedDecision) Error() string {
+	return fmt.Sprintf("invalid forced decision %q: %s", i.Input, i.Err)
+}
+
+func (i InvalidForcedDecision) Unwrap() error {
+	return i.Err
+}
+
+// ParseForcedDecision parses a "flag=variation" or "flag:rule=variation" entry, e.g. from a query string. The error
+// is an InvalidForcedDecision.
+func ParseForcedDecision(entry string) (ForcedDecisionMapping, error) {
+	keys, variationKey, found := strings.Cut(strings.TrimSpace(entry), "=")
+	flagKey, ruleKey, _ := strings.Cut(keys, ":")
+	mapping := ForcedDecisionMapping{
+		FlagKey:      strings.TrimSpace(flagKey),
+		RuleKey:      strings.TrimSpace(ruleKey),
+		VariationKey: strings.TrimSpace(variationKey),
+	}
+	if !found || mapping.FlagKey == "" || mapping.VariationKey == "" {
+		return ForcedDecisionMapping{}, InvalidForcedDecision{Input: entry, Err: errors.New(`expected "flag=variation" or "flag:rule=variation"`)}
+	}
+	return mapping, nil
+}
+
+// ParseForcedDecisions parses the comma separated entries of every value, e.g. the values of a query parameter.
+// Empty entries are skipped and the entries which cannot be parsed are reported.
+func ParseForcedDecisions(values ...string) (mappings []ForcedDecisionMapping, invalid []InvalidForcedDecision) {
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+			mapping, err := ParseForcedDecision(entry)
+			if err != nil {
+				invalid = append(invalid, err.(InvalidForcedDecision))
+				continue
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings, invalid
+}
+
+// Mappings returns the forced decisions of the service sorted by flag and rule
+func (f *ForcedDecisionService) Mappings() []ForcedDecisionMapping {
+	mappings := []ForcedDecisionMapping{}
+	for context, forcedDecision := range f.GetForcedDecisions() {
+		mappings = append(mappings, ForcedDecisionMapping{FlagKey: context.FlagKey, RuleKey: context.RuleKey, VariationKey: forcedDecision.VariationKey})
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].FlagKey != mappings[j].FlagKey {
+			return mappings[i].FlagKey < mappings[j].FlagKey
+		}
+		return mappings[i].RuleKey < mappings[j].RuleKey
+	})
+	return mappings
+}
+
+// Export returns the forced decisions of the service as a JSON array of mappings, which ImportJSON reads back
+func (f *ForcedDecisionService) Export() ([]byte, error) {
+	return json.MarshalIndent(f.Mappings(), "", "  ")
+}
+
+// Import sets the forced decisions of the mappings which are valid for the project config and reports the others.
+// A mapping is valid when FindValidatedForcedDecision accepts its variation and its rule, if any, is a rule of the flag.
+func (f *ForcedDecisionService) Import(projectConfig config.ProjectConfig, mappings []ForcedDecisionMapping) (invalid []InvalidForcedDecision) {
+	for _, mapping := range mappings {
+		if err := f.validate(projectConfig, mapping); err != nil {
+			invalid = append(invalid, InvalidForcedDecision{Input: mapping.String(), Err: err})
+			continue
+		}
+		f.SetForcedDecision(OptimizelyDecisionContext{FlagKey: mapping.FlagKey, RuleKey: mapping.RuleKey}, OptimizelyForcedDecision{VariationKey: mapping.VariationKey})
+	}
+	return invalid
+}
+
+// ImportJSON is like Import for a JSON array of mappings, e.g. one written by Export
+func (f *ForcedDecisionService) ImportJSON(projectConfig config.ProjectConfig, data []byte) ([]InvalidForcedDecision, error) {
+	var mappings []ForcedDecisionMapping
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, err
+	}
+	return f.Import(projectConfig, mappings), nil
+}
+
+func (f *ForcedDecisionService) validate(projectConfig config.ProjectConfig, mapping ForcedDecisionMapping) error {
+	if mapping.FlagKey == "" || mapping.VariationKey == "" {
+		return errors.New("flag and variation keys are required")
+	}
+	if projectConfig == nil {
+		return errors.New("project config is not available")
+	}
+	feature, err := projectConfig.GetFeatureByKey(mapping.FlagKey)
+	if err != nil {
+		return fmt.Errorf("flag %q is not in the datafile", mapping.FlagKey)
+	}
+	if mapping.RuleKey != "" && !hasRule(feature.FeatureExperiments, mapping.RuleKey) && !hasRule(feature.Rollout.Experiments, mapping.RuleKey) {
+		return fmt.Errorf("rule %q is not a rule of flag %q", mapping.RuleKey, mapping.FlagKey)
+	}
+
+	context := OptimizelyDecisionContext{FlagKey: mapping.FlagKey, RuleKey: mapping.RuleKey}
+	candidate := NewForcedDecisionService(f.UserID)
+	candidate.SetForcedDecision(context, OptimizelyForcedDecision{VariationKey: mapping.VariationKey})
+	if _, _, err = candidate.FindValidatedForcedDecision(projectConfig, context, &decide.Options{}); err != nil {
+		return fmt.Errorf("variation %q is not a variation of flag %q", mapping.VariationKey, mapping.FlagKey)
+	}
+	return nil
+}
+
+func hasRule(rules []entities.Experiment, ruleKey string) bool {
+	for _, rule := range rules {
+		if rule.Key == ruleKey {
+			return true
+		}
+	}
+	return false
+}
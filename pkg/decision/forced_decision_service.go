@@ -19,10 +19,12 @@ package decision
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/optimizely/go-sdk/v2/pkg/config"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 )
 
@@ -102,6 +104,19 @@ func (f *ForcedDecisionService) RemoveAllForcedDecisions() bool {
 	return true
 }
 
+// GetForcedDecisions returns a copy of the forced decisions bound to this user context, without the removed ones.
+func (f *ForcedDecisionService) GetForcedDecisions() map[OptimizelyDecisionContext]OptimizelyForcedDecision {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	forcedDecisions := map[OptimizelyDecisionContext]OptimizelyForcedDecision{}
+	for k, v := range f.forcedDecisions {
+		if v.VariationKey != "" {
+			forcedDecisions[k] = v
+		}
+	}
+	return forcedDecisions
+}
+
 // FindValidatedForcedDecision returns validated forced decision.
 func (f *ForcedDecisionService) FindValidatedForcedDecision(projectConfig config.ProjectConfig, context OptimizelyDecisionContext, options *decide.Options) (variation *entities.Variation, reasons decide.DecisionReasons, err error) {
 	decisionReasons := decide.NewDecisionReasons(options)
@@ -117,10 +132,18 @@ func (f *ForcedDecisionService) FindValidatedForcedDecision(projectConfig config
 	}
 
 	if err != nil {
-		decisionReasons.AddInfo("Invalid variation is mapped to %s and user (%s) in the forced decision map.", target, f.UserID)
+		decisionReasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.InvalidForcedDecision,
+			RuleKey: context.RuleKey,
+			Message: fmt.Sprintf("Invalid variation is mapped to %s and user (%s) in the forced decision map.", target, f.UserID),
+		})
 		return nil, decisionReasons, err
 	}
-	decisionReasons.AddInfo("Variation (%s) is mapped to %s and user (%s) in the forced decision map.", forcedDecision.VariationKey, target, f.UserID)
+	decisionReasons.AddReason(decide.StructuredReason{
+		Code:    pkgReasons.ForcedDecisionFound,
+		RuleKey: context.RuleKey,
+		Message: fmt.Sprintf("Variation (%s) is mapped to %s and user (%s) in the forced decision map.", forcedDecision.VariationKey, target, f.UserID),
+	})
 	return _variation, decisionReasons, nil
 }
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/optimizely/go-sdk/v2/pkg/config"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -142,6 +143,65 @@ func (s *ForcedDecisionServiceTestSuite) TestRemoveForcedDecision() {
 	s.NoError(err)
 }
 
+func (s *ForcedDecisionServiceTestSuite) TestGetForcedDecisions() {
+	s.Len(s.forcedDecisionService.GetForcedDecisions(), 0)
+	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "1", RuleKey: "a"}, OptimizelyForcedDecision{VariationKey: "b"}))
+	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "2", RuleKey: ""}, OptimizelyForcedDecision{VariationKey: "c"}))
+	s.True(s.forcedDecisionService.RemoveForcedDecision(OptimizelyDecisionContext{FlagKey: "1", RuleKey: "a"}))
+
+	forcedDecisions := s.forcedDecisionService.GetForcedDecisions()
+	s.Equal(map[OptimizelyDecisionContext]OptimizelyForcedDecision{
+		{FlagKey: "2", RuleKey: ""}: {VariationKey: "c"},
+	}, forcedDecisions)
+
+	// Mutating the copy must not affect the service
+	delete(forcedDecisions, OptimizelyDecisionContext{FlagKey: "2"})
+	s.Len(s.forcedDecisionService.GetForcedDecisions(), 1)
+}
+
+func (s *ForcedDecisionServiceTestSuite) TestExportImport() {
+	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "feature_2"}, OptimizelyForcedDecision{VariationKey: "variation_no_traffic"}))
+	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "feature_1", RuleKey: "exp_with_audience"}, OptimizelyForcedDecision{VariationKey: "b"}))
+	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "feature_1", RuleKey: "removed"}, OptimizelyForcedDecision{VariationKey: "a"}))
+	s.True(s.forcedDecisionService.RemoveForcedDecision(OptimizelyDecisionContext{FlagKey: "feature_1", RuleKey: "removed"}))
+
+	data, err := s.forcedDecisionService.Export()
+	s.NoError(err)
+	s.JSONEq(`[
+		{"flagKey": "feature_1", "ruleKey": "exp_with_audience", "variationKey": "b"},
+		{"flagKey": "feature_2", "variationKey": "variation_no_traffic"}
+	]`, string(data))
+
+	imported := NewForcedDecisionService("abc")
+	invalid, err := imported.ImportJSON(s.projectConfig, data)
+	s.NoError(err)
+	s.Empty(invalid)
+	s.Equal(s.forcedDecisionService.GetForcedDecisions(), imported.GetForcedDecisions())
+
+	_, err = imported.ImportJSON(s.projectConfig, []byte("{"))
+	s.Error(err)
+}
+
+func (s *ForcedDecisionServiceTestSuite) TestImportReportsInvalidMappings() {
+	invalid := s.forcedDecisionService.Import(s.projectConfig, []ForcedDecisionMapping{
+		{FlagKey: "feature_1", VariationKey: "a"},
+		{FlagKey: "feature_1", RuleKey: "3332020515", VariationKey: "3324490633"},
+		{FlagKey: "unknown", VariationKey: "a"},
+		{FlagKey: "feature_1", RuleKey: "unknown", VariationKey: "a"},
+		{FlagKey: "feature_1", VariationKey: "unknown"},
+		{FlagKey: "feature_1"},
+	})
+	s.Len(s.forcedDecisionService.GetForcedDecisions(), 2)
+	s.Len(invalid, 4)
+	s.Equal(`invalid forced decision "unknown=a": flag "unknown" is not in the datafile`, invalid[0].Error())
+	s.Equal(`invalid forced decision "feature_1:unknown=a": rule "unknown" is not a rule of flag "feature_1"`, invalid[1].Error())
+	s.Equal(`invalid forced decision "feature_1=unknown": variation "unknown" is not a variation of flag "feature_1"`, invalid[2].Error())
+	s.Equal("feature_1=", invalid[3].Input)
+
+	invalid = NewForcedDecisionService("abc").Import(nil, []ForcedDecisionMapping{{FlagKey: "feature_1", VariationKey: "a"}})
+	s.Len(invalid, 1)
+}
+
 func (s *ForcedDecisionServiceTestSuite) TestRemoveAllForcedDecision() {
 	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "1", RuleKey: "a"}, OptimizelyForcedDecision{VariationKey: "b"}))
 	s.True(s.forcedDecisionService.SetForcedDecision(OptimizelyDecisionContext{FlagKey: "2", RuleKey: ""}, OptimizelyForcedDecision{VariationKey: "b"}))
@@ -246,6 +306,35 @@ func (s *ForcedDecisionServiceTestSuite) TestAsyncBehaviour() {
 	s.Len(s.forcedDecisionService.forcedDecisions, 0)
 }
 
+func TestParseForcedDecision(t *testing.T) {
+	mapping, err := ParseForcedDecision(" flag:rule=variation ")
+	assert.NoError(t, err)
+	assert.Equal(t, ForcedDecisionMapping{FlagKey: "flag", RuleKey: "rule", VariationKey: "variation"}, mapping)
+	assert.Equal(t, "flag:rule=variation", mapping.String())
+
+	mapping, err = ParseForcedDecision("flag=variation")
+	assert.NoError(t, err)
+	assert.Equal(t, "flag=variation", mapping.String())
+
+	for _, entry := range []string{"flag", "=variation", "flag=", ":rule=variation"} {
+		_, err = ParseForcedDecision(entry)
+		var invalid InvalidForcedDecision
+		assert.ErrorAs(t, err, &invalid, entry)
+		assert.Equal(t, entry, invalid.Input)
+	}
+}
+
+func TestParseForcedDecisions(t *testing.T) {
+	mappings, invalid := ParseForcedDecisions("a=on, b:rule=off,,bad", "c=on")
+	assert.Equal(t, []ForcedDecisionMapping{
+		{FlagKey: "a", VariationKey: "on"},
+		{FlagKey: "b", RuleKey: "rule", VariationKey: "off"},
+		{FlagKey: "c", VariationKey: "on"},
+	}, mappings)
+	assert.Len(t, invalid, 1)
+	assert.Equal(t, "bad", invalid[0].Input)
+}
+
 func TestForcedDecisionServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ForcedDecisionServiceTestSuite))
 }
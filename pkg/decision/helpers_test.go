@@ -22,6 +22,8 @@
 package decision
 
 import (
+	"context"
+
 	"github.com/optimizely/go-sdk/v2/pkg/config"
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
@@ -54,6 +56,11 @@ func (c *mockProjectConfig) GetAudienceMap() map[string]entities.Audience {
 	return args.Get(0).(map[string]entities.Audience)
 }
 
+func (c *mockProjectConfig) GetAttributes() []entities.Attribute {
+	args := c.Called()
+	return args.Get(0).([]entities.Attribute)
+}
+
 func (c *mockProjectConfig) GetFlagVariationsMap() map[string][]entities.Variation {
 	args := c.Called()
 	return args.Get(0).(map[string][]entities.Variation)
@@ -95,6 +102,35 @@ func (m *MockUserProfileService) Save(userProfile UserProfile) {
 	m.Called(userProfile)
 }
 
+// MockUserProfileServiceWithV2 is a UserProfileService which also implements UserProfileServiceV2
+type MockUserProfileServiceWithV2 struct {
+	MockUserProfileService
+}
+
+func (m *MockUserProfileServiceWithV2) LookupProfile(ctx context.Context, userID string) (UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileServiceWithV2) SaveProfile(ctx context.Context, userProfile UserProfile) error {
+	args := m.Called(ctx, userProfile)
+	return args.Error(0)
+}
+
+type MockUserProfileServiceV2 struct {
+	mock.Mock
+}
+
+func (m *MockUserProfileServiceV2) LookupProfile(ctx context.Context, userID string) (UserProfile, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(UserProfile), args.Error(1)
+}
+
+func (m *MockUserProfileServiceV2) SaveProfile(ctx context.Context, userProfile UserProfile) error {
+	args := m.Called(ctx, userProfile)
+	return args.Error(0)
+}
+
 func (m *MockAudienceTreeEvaluator) Evaluate(node *entities.TreeNode, condTreeParams *entities.TreeParameters, options *decide.Options) (evalResult, isValid bool, reasons decide.DecisionReasons) {
 	args := m.Called(node, condTreeParams, options)
 	return args.Bool(0), args.Bool(1), args.Get(2).(decide.DecisionReasons)
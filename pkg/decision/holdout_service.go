@@ -0,0 +1,124 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package decision //
+package decision
+
+import (
+	"fmt"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/bucketer"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/evaluator"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// HoldoutService makes a feature decision for the holdouts applicable to a feature
+type HoldoutService struct {
+	audienceTreeEvaluator evaluator.TreeEvaluator
+	bucketer              bucketer.ExperimentBucketer
+	logger                logging.OptimizelyLogProducer
+}
+
+// NewHoldoutService returns a new instance of the HoldoutService
+func NewHoldoutService(sdkKey string) *HoldoutService {
+	logger := logging.GetLogger(sdkKey, "HoldoutService")
+	return &HoldoutService{
+		logger:                logger,
+		audienceTreeEvaluator: evaluator.NewMixedTreeEvaluator(logger),
+		bucketer:              *bucketer.NewMurmurhashExperimentBucketer(logger, bucketer.DefaultHashSeed),
+	}
+}
+
+// GetDecision returns a decision for the first holdout of the feature the user is bucketed into
+func (h HoldoutService) GetDecision(decisionContext FeatureDecisionContext, userContext entities.UserContext, options *decide.Options) (FeatureDecision, decide.DecisionReasons, error) {
+	featureDecision := FeatureDecision{}
+	feature := decisionContext.Feature
+	reasons := decide.NewDecisionReasons(options)
+
+	for _, holdout := range feature.Holdouts {
+		if !holdout.IsRunning() {
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.HoldoutNotRunning,
+				RuleKey: holdout.Key,
+				Message: fmt.Sprintf(logging.HoldoutNotRunning.String(), holdout.Key),
+			})
+			h.logger.Debug(logMessage)
+			featureDecision.Reason = pkgReasons.HoldoutNotRunning
+			continue
+		}
+
+		experiment := holdout.ToExperiment()
+		if experiment.AudienceConditionTree != nil {
+			condTreeParams := entities.NewTreeParameters(&userContext, decisionContext.ProjectConfig.GetAudienceMap())
+			h.logger.Debug(fmt.Sprintf(logging.EvaluatingAudiencesForHoldout.String(), holdout.Key))
+			evalResult, _, decisionReasons := h.audienceTreeEvaluator.Evaluate(experiment.AudienceConditionTree, condTreeParams, options)
+			reasons.Append(decisionReasons)
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.AudiencesEvaluated,
+				RuleKey: holdout.Key,
+				Result:  decide.EvaluationResult(evalResult),
+				Message: fmt.Sprintf(logging.HoldoutAudiencesEvaluatedTo.String(), holdout.Key, evalResult),
+			})
+			h.logger.Debug(logMessage)
+			if !evalResult {
+				logMessage := reasons.AddReason(decide.StructuredReason{
+					Code:    pkgReasons.FailedAudienceTargeting,
+					RuleKey: holdout.Key,
+					Message: fmt.Sprintf(logging.UserNotInHoldout.String(), userContext.ID, holdout.Key, feature.Key),
+				})
+				h.logger.Debug(logMessage)
+				featureDecision.Reason = pkgReasons.FailedAudienceTargeting
+				continue
+			}
+		}
+
+		bucketingID, err := userContext.GetBucketingID()
+		if err != nil {
+			errorMessage := reasons.AddInfo(`Error computing bucketing ID for holdout %q: %q`, holdout.Key, err.Error())
+			h.logger.Debug(errorMessage)
+		}
+
+		variation, _, _ := h.bucketer.Bucket(bucketingID, experiment, entities.Group{})
+		if variation == nil {
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.NotBucketedIntoHoldout,
+				RuleKey: holdout.Key,
+				Message: fmt.Sprintf(logging.UserNotInHoldout.String(), userContext.ID, holdout.Key, feature.Key),
+			})
+			h.logger.Debug(logMessage)
+			featureDecision.Reason = pkgReasons.NotBucketedIntoHoldout
+			continue
+		}
+
+		logMessage := reasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.BucketedIntoHoldout,
+			RuleKey: holdout.Key,
+			Message: fmt.Sprintf(logging.UserInHoldout.String(), userContext.ID, holdout.Key, feature.Key),
+		})
+		h.logger.Info(logMessage)
+		return FeatureDecision{
+			Decision:   Decision{Reason: pkgReasons.BucketedIntoHoldout},
+			Source:     Holdout,
+			Experiment: experiment,
+			Variation:  variation,
+		}, reasons, nil
+	}
+
+	return featureDecision, reasons, nil
+}
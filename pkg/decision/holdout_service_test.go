@@ -0,0 +1,142 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package decision
+
+import (
+	"testing"
+
+	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
+	"github.com/optimizely/go-sdk/v2/pkg/entities"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var testHoldoutVar3001 = entities.Variation{ID: "3001", Key: "ho_off_key", FeatureEnabled: false}
+var testHoldout3000 = entities.Holdout{
+	ID:     "3000",
+	Key:    "test_holdout_3000",
+	Status: entities.HoldoutStatusRunning,
+	Variations: map[string]entities.Variation{
+		"3001": testHoldoutVar3001,
+	},
+	VariationKeyToIDMap: map[string]string{
+		"ho_off_key": "3001",
+	},
+	TrafficAllocation: []entities.Range{
+		{EntityID: "3001", EndOfRange: 10000},
+	},
+}
+
+type HoldoutServiceTestSuite struct {
+	suite.Suite
+	mockConfig                *mockProjectConfig
+	mockAudienceTreeEvaluator *MockAudienceTreeEvaluator
+	mockBucketer              *MockBucketer
+	testUserContext           entities.UserContext
+	options                   *decide.Options
+	reasons                   decide.DecisionReasons
+	holdoutService            HoldoutService
+}
+
+func (s *HoldoutServiceTestSuite) SetupTest() {
+	s.mockConfig = new(mockProjectConfig)
+	s.mockAudienceTreeEvaluator = new(MockAudienceTreeEvaluator)
+	s.mockBucketer = new(MockBucketer)
+	s.testUserContext = entities.UserContext{ID: "test_user"}
+	s.options = &decide.Options{IncludeReasons: true}
+	s.reasons = decide.NewDecisionReasons(s.options)
+	s.mockConfig.On("GetAudienceMap").Return(map[string]entities.Audience{})
+	s.holdoutService = HoldoutService{
+		audienceTreeEvaluator: s.mockAudienceTreeEvaluator,
+		bucketer:              s.mockBucketer,
+		logger:                logging.GetLogger("", "HoldoutService"),
+	}
+}
+
+func (s *HoldoutServiceTestSuite) getDecisionContext(holdouts ...entities.Holdout) FeatureDecisionContext {
+	feature := testFeat3333
+	feature.Holdouts = holdouts
+	return FeatureDecisionContext{
+		Feature:       &feature,
+		ProjectConfig: s.mockConfig,
+	}
+}
+
+func (s *HoldoutServiceTestSuite) TestGetDecisionBucketedIntoHoldout() {
+	s.mockBucketer.On("Bucket", "test_user", testHoldout3000.ToExperiment(), entities.Group{}).Return(&testHoldoutVar3001, reasons.BucketedIntoVariation, nil)
+
+	decision, rsons, err := s.holdoutService.GetDecision(s.getDecisionContext(testHoldout3000), s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(Holdout, decision.Source)
+	s.Equal(reasons.BucketedIntoHoldout, decision.Reason)
+	s.Equal(testHoldout3000.Key, decision.Experiment.Key)
+	s.Equal(&testHoldoutVar3001, decision.Variation)
+	s.Contains(rsons.ToReport(), `User "test_user" is bucketed into holdout "test_holdout_3000" for feature flag "my_test_feature_3333".`)
+	s.mockBucketer.AssertExpectations(s.T())
+}
+
+func (s *HoldoutServiceTestSuite) TestGetDecisionSkipsHoldoutNotRunning() {
+	holdout := testHoldout3000
+	holdout.Status = entities.HoldoutStatusDraft
+
+	decision, rsons, err := s.holdoutService.GetDecision(s.getDecisionContext(holdout), s.testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.HoldoutNotRunning, decision.Reason)
+	s.Contains(rsons.ToReport(), `Holdout "test_holdout_3000" is not running.`)
+	s.mockBucketer.AssertNotCalled(s.T(), "Bucket", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *HoldoutServiceTestSuite) TestGetDecisionFailsAudienceTargeting() {
+	holdout := testHoldout3000
+	holdout.AudienceConditionTree = &entities.TreeNode{Operator: "or", Nodes: []*entities.TreeNode{{Item: "5555"}}}
+	s.mockAudienceTreeEvaluator.On("Evaluate", holdout.AudienceConditionTree, mock.Anything, s.options).Return(false, true, s.reasons)
+
+	decision, _, err := s.holdoutService.GetDecision(s.getDecisionContext(holdout), s.testUserContext, s.options)
+	s.NoError(err)
+	s.Nil(decision.Variation)
+	s.Equal(reasons.FailedAudienceTargeting, decision.Reason)
+	s.mockAudienceTreeEvaluator.AssertExpectations(s.T())
+	s.mockBucketer.AssertNotCalled(s.T(), "Bucket", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *HoldoutServiceTestSuite) TestGetDecisionFallsThroughToNextHoldout() {
+	nextHoldout := testHoldout3000
+	nextHoldout.ID = "3002"
+	nextHoldout.Key = "test_holdout_3002"
+	s.mockBucketer.On("Bucket", "test_user", testHoldout3000.ToExperiment(), entities.Group{}).Return((*entities.Variation)(nil), reasons.NotBucketedIntoVariation, nil)
+	s.mockBucketer.On("Bucket", "test_user", nextHoldout.ToExperiment(), entities.Group{}).Return(&testHoldoutVar3001, reasons.BucketedIntoVariation, nil)
+
+	decision, _, err := s.holdoutService.GetDecision(s.getDecisionContext(testHoldout3000, nextHoldout), s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(Holdout, decision.Source)
+	s.Equal("test_holdout_3002", decision.Experiment.Key)
+	s.mockBucketer.AssertExpectations(s.T())
+}
+
+func (s *HoldoutServiceTestSuite) TestGetDecisionWithoutHoldouts() {
+	decision, _, err := s.holdoutService.GetDecision(s.getDecisionContext(), s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(FeatureDecision{}, decision)
+}
+
+func TestHoldoutServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldoutServiceTestSuite))
+}
@@ -18,6 +18,8 @@
 package decision
 
 import (
+	"context"
+
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/notification"
@@ -46,3 +48,11 @@ type UserProfileService interface {
 	Lookup(string) UserProfile
 	Save(UserProfile)
 }
+
+// UserProfileServiceV2 is used to save and retrieve past bucketing decisions for users. Unlike UserProfileService,
+// it receives the context of the decide call and reports storage failures. When a UserProfileService also implements
+// UserProfileServiceV2, LookupProfile and SaveProfile are used instead of the other methods.
+type UserProfileServiceV2 interface {
+	LookupProfile(ctx context.Context, userID string) (UserProfile, error)
+	SaveProfile(ctx context.Context, userProfile UserProfile) error
+}
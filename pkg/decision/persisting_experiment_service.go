@@ -18,9 +18,11 @@
 package decision
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	pkgReasons "github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 )
@@ -54,8 +56,9 @@ func (p PersistingExperimentService) GetDecision(decisionContext ExperimentDecis
 
 	var userProfile UserProfile
 	var decisionReasons decide.DecisionReasons
+	var lookupErr error
 	// check to see if there is a saved decision for the user
-	experimentDecision, userProfile, decisionReasons = p.getSavedDecision(decisionContext, userContext, options)
+	experimentDecision, userProfile, decisionReasons, lookupErr = p.getSavedDecision(decisionContext, userContext, options)
 	reasons.Append(decisionReasons)
 	if experimentDecision.Variation != nil {
 		return experimentDecision, reasons, nil
@@ -64,29 +67,47 @@ func (p PersistingExperimentService) GetDecision(decisionContext ExperimentDecis
 	experimentDecision, decisionReasons, err = p.experimentBucketedService.GetDecision(decisionContext, userContext, options)
 	reasons.Append(decisionReasons)
 	if experimentDecision.Variation != nil {
-		// save decision if a user profile service is provided
-		userProfile.ID = userContext.ID
-		p.saveDecision(userProfile, decisionContext.Experiment, experimentDecision)
+		if decisionContext.UserProfileTracker != nil {
+			// the tracker saves the decisions of the whole call at once
+			decisionContext.UserProfileTracker.update(decisionContext.Experiment.ID, experimentDecision.Variation.ID)
+		} else if lookupErr == nil {
+			// save decision if a user profile service is provided, unless the stored profile could not be read
+			userProfile.ID = userContext.ID
+			p.saveDecision(decisionContext.Context, userProfile, decisionContext.Experiment, experimentDecision)
+		}
 	}
 
 	return experimentDecision, reasons, err
 }
 
-func (p PersistingExperimentService) getSavedDecision(decisionContext ExperimentDecisionContext, userContext entities.UserContext, options *decide.Options) (ExperimentDecision, UserProfile, decide.DecisionReasons) {
+func (p PersistingExperimentService) getSavedDecision(decisionContext ExperimentDecisionContext, userContext entities.UserContext, options *decide.Options) (ExperimentDecision, UserProfile, decide.DecisionReasons, error) {
 	reasons := decide.NewDecisionReasons(options)
 	experimentDecision := ExperimentDecision{}
-	userProfile := p.userProfileService.Lookup(userContext.ID)
+	userProfile, err := p.lookup(decisionContext, userContext.ID)
+	if err != nil {
+		warningMessage := reasons.AddErrorReason(decide.StructuredReason{
+			Code:    pkgReasons.UserProfileLookupFailed,
+			RuleKey: decisionContext.Experiment.Key,
+			Message: fmt.Sprintf(`Unable to look up the user profile of user "%s": %s`, userContext.ID, err),
+		})
+		p.logger.Warning(warningMessage)
+		return experimentDecision, userProfile, reasons, err
+	}
 
 	// look up experiment decision from user profile
 	decisionKey := NewUserDecisionKey(decisionContext.Experiment.ID)
 	if userProfile.ExperimentBucketMap == nil {
-		return experimentDecision, userProfile, reasons
+		return experimentDecision, userProfile, reasons, nil
 	}
 
 	if savedVariationID, ok := userProfile.ExperimentBucketMap[decisionKey]; ok {
 		if variation, ok := decisionContext.Experiment.Variations[savedVariationID]; ok {
 			experimentDecision.Variation = &variation
-			infoMessage := reasons.AddInfo(`User "%s" was previously bucketed into variation "%s" of experiment "%s".`, userContext.ID, variation.Key, decisionContext.Experiment.Key)
+			infoMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.SavedDecisionFound,
+				RuleKey: decisionContext.Experiment.Key,
+				Message: fmt.Sprintf(`User "%s" was previously bucketed into variation "%s" of experiment "%s".`, userContext.ID, variation.Key, decisionContext.Experiment.Key),
+			})
 			p.logger.Debug(infoMessage)
 		} else {
 			warningMessage := reasons.AddInfo(`User "%s" was previously bucketed into variation with ID "%s" for experiment "%s", but no matching variation was found.`, userContext.ID, savedVariationID, decisionContext.Experiment.Key)
@@ -94,17 +115,27 @@ func (p PersistingExperimentService) getSavedDecision(decisionContext Experiment
 		}
 	}
 
-	return experimentDecision, userProfile, reasons
+	return experimentDecision, userProfile, reasons, nil
 }
 
-func (p PersistingExperimentService) saveDecision(userProfile UserProfile, experiment *entities.Experiment, decision ExperimentDecision) {
+func (p PersistingExperimentService) saveDecision(ctx context.Context, userProfile UserProfile, experiment *entities.Experiment, decision ExperimentDecision) {
 	if p.userProfileService != nil {
 		decisionKey := NewUserDecisionKey(experiment.ID)
 		if userProfile.ExperimentBucketMap == nil {
 			userProfile.ExperimentBucketMap = map[UserDecisionKey]string{}
 		}
 		userProfile.ExperimentBucketMap[decisionKey] = decision.Variation.ID
-		p.userProfileService.Save(userProfile)
+		if err := saveUserProfile(ctx, p.userProfileService, userProfile); err != nil {
+			p.logger.Warning(fmt.Sprintf(`Unable to save the user profile of user %q: %s`, userProfile.ID, err))
+			return
+		}
 		p.logger.Debug(fmt.Sprintf(`Decision saved for user %q.`, userProfile.ID))
 	}
 }
+
+func (p PersistingExperimentService) lookup(decisionContext ExperimentDecisionContext, userID string) (UserProfile, error) {
+	if decisionContext.UserProfileTracker != nil {
+		return decisionContext.UserProfileTracker.lookup(decisionContext.Context, p.userProfileService, userID)
+	}
+	return lookupUserProfile(decisionContext.Context, p.userProfileService, userID)
+}
@@ -18,9 +18,12 @@
 package decision
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/optimizely/go-sdk/v2/pkg/decide"
+	"github.com/optimizely/go-sdk/v2/pkg/decision/reasons"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 	"github.com/optimizely/go-sdk/v2/pkg/logging"
 
@@ -111,6 +114,109 @@ func (s *PersistingExperimentServiceTestSuite) TestNoSavedVariation() {
 	s.mockUserProfileService.AssertExpectations(s.T())
 }
 
+func (s *PersistingExperimentServiceTestSuite) TestUserProfileServiceImplementingV2() {
+	mockUserProfileService := new(MockUserProfileServiceWithV2)
+	decisionKey := NewUserDecisionKey(s.testDecisionContext.Experiment.ID)
+	updatedUserProfile := UserProfile{
+		ID:                  testUserContext.ID,
+		ExperimentBucketMap: map[UserDecisionKey]string{decisionKey: s.testComputedDecision.Variation.ID},
+	}
+	// a nil decision context is replaced with the background context
+	mockUserProfileService.On("LookupProfile", context.Background(), testUserContext.ID).Return(UserProfile{ID: testUserContext.ID}, nil)
+	mockUserProfileService.On("SaveProfile", context.Background(), updatedUserProfile).Return(nil)
+
+	persistingExperimentService := NewPersistingExperimentService(mockUserProfileService, s.mockExperimentService, logging.GetLogger("", "NewPersistingExperimentService"))
+	decision, _, err := persistingExperimentService.GetDecision(s.testDecisionContext, testUserContext, s.options)
+	s.Equal(s.testComputedDecision, decision)
+	s.NoError(err)
+	mockUserProfileService.AssertExpectations(s.T())
+	mockUserProfileService.AssertNotCalled(s.T(), "Lookup", mock.Anything)
+	mockUserProfileService.AssertNotCalled(s.T(), "Save", mock.Anything)
+}
+
+func (s *PersistingExperimentServiceTestSuite) TestUserProfileServiceV2() {
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	decisionKey := NewUserDecisionKey(s.testDecisionContext.Experiment.ID)
+	updatedUserProfile := UserProfile{
+		ID:                  testUserContext.ID,
+		ExperimentBucketMap: map[UserDecisionKey]string{decisionKey: s.testComputedDecision.Variation.ID},
+	}
+	mockUserProfileService.On("LookupProfile", context.Background(), testUserContext.ID).Return(UserProfile{ID: testUserContext.ID}, nil)
+	mockUserProfileService.On("SaveProfile", context.Background(), updatedUserProfile).Return(errors.New("disk full"))
+
+	persistingExperimentService := NewPersistingExperimentService(UserProfileServiceFromV2(mockUserProfileService), s.mockExperimentService, logging.GetLogger("", "NewPersistingExperimentService"))
+	decision, _, err := persistingExperimentService.GetDecision(s.testDecisionContext, testUserContext, s.options)
+	// a failed save does not fail the decision
+	s.Equal(s.testComputedDecision, decision)
+	s.NoError(err)
+	mockUserProfileService.AssertExpectations(s.T())
+}
+
+func (s *PersistingExperimentServiceTestSuite) TestUserProfileServiceV2LookupError() {
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	mockUserProfileService.On("LookupProfile", context.Background(), testUserContext.ID).Return(UserProfile{}, errors.New("connection refused"))
+
+	persistingExperimentService := NewPersistingExperimentService(UserProfileServiceFromV2(mockUserProfileService), s.mockExperimentService, logging.GetLogger("", "NewPersistingExperimentService"))
+	// the failure is reported without the include reasons option
+	decision, rsons, err := persistingExperimentService.GetDecision(s.testDecisionContext, testUserContext, s.options)
+	s.Equal([]string{`Unable to look up the user profile of user "test_user_1": connection refused`}, rsons.ToReport())
+	s.Equal(reasons.UserProfileLookupFailed, decide.ToStructuredReport(rsons)[0].Code)
+	s.Equal(s.testComputedDecision, decision)
+	s.NoError(err)
+	// the stored profile is not overwritten when it could not be read
+	mockUserProfileService.AssertNotCalled(s.T(), "SaveProfile", mock.Anything, mock.Anything)
+}
+
+func (s *PersistingExperimentServiceTestSuite) TestUserProfileTracker() {
+	otherExperiment := testExp1111
+	otherDecisionContext := ExperimentDecisionContext{Experiment: &otherExperiment, ProjectConfig: s.mockProjectConfig}
+	otherVariation := testExp1111.Variations["2222"]
+
+	tracker := NewUserProfileTracker()
+	s.testDecisionContext.UserProfileTracker = tracker
+	otherDecisionContext.UserProfileTracker = tracker
+	s.mockExperimentService.On("GetDecision", s.testDecisionContext, testUserContext, s.options).Return(s.testComputedDecision, s.reasons, nil)
+	s.mockExperimentService.On("GetDecision", otherDecisionContext, testUserContext, s.options).Return(ExperimentDecision{Variation: &otherVariation}, s.reasons, nil)
+
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	mockUserProfileService.On("LookupProfile", context.Background(), testUserContext.ID).Return(UserProfile{ID: testUserContext.ID}, nil).Once()
+	mockUserProfileService.On("SaveProfile", context.Background(), UserProfile{
+		ID: testUserContext.ID,
+		ExperimentBucketMap: map[UserDecisionKey]string{
+			NewUserDecisionKey(testExp1113.ID): s.testComputedDecision.Variation.ID,
+			NewUserDecisionKey(testExp1111.ID): otherVariation.ID,
+		},
+	}).Return(nil).Once()
+
+	persistingExperimentService := NewPersistingExperimentService(UserProfileServiceFromV2(mockUserProfileService), s.mockExperimentService, logging.GetLogger("", "NewPersistingExperimentService"))
+	decision, _, err := persistingExperimentService.GetDecision(s.testDecisionContext, testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(s.testComputedDecision, decision)
+	_, _, err = persistingExperimentService.GetDecision(otherDecisionContext, testUserContext, s.options)
+	s.NoError(err)
+	mockUserProfileService.AssertNotCalled(s.T(), "SaveProfile", mock.Anything, mock.Anything)
+
+	s.NoError(tracker.Save(context.Background()))
+	// nothing is left to save
+	s.NoError(tracker.Save(context.Background()))
+	mockUserProfileService.AssertExpectations(s.T())
+}
+
+func (s *PersistingExperimentServiceTestSuite) TestUserProfileTrackerLookupError() {
+	tracker := NewUserProfileTracker()
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	mockUserProfileService.On("LookupProfile", context.Background(), testUserContext.ID).Return(UserProfile{}, errors.New("timeout")).Once()
+
+	_, err := tracker.lookup(context.Background(), UserProfileServiceFromV2(mockUserProfileService), testUserContext.ID)
+	s.Error(err)
+	_, err = tracker.lookup(context.Background(), UserProfileServiceFromV2(mockUserProfileService), testUserContext.ID)
+	s.Error(err)
+	tracker.update(testExp1113.ID, "2223")
+	s.NoError(tracker.Save(context.Background()))
+	mockUserProfileService.AssertExpectations(s.T())
+	mockUserProfileService.AssertNotCalled(s.T(), "SaveProfile", mock.Anything, mock.Anything)
+}
+
 func (s *PersistingExperimentServiceTestSuite) TestSavedVariationNoLongerValid() {
 	decisionKey := NewUserDecisionKey(s.testDecisionContext.Experiment.ID)
 	savedUserProfile := UserProfile{
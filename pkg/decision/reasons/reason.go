@@ -21,6 +21,20 @@ package reasons
 type Reason string
 
 const (
+	// Info - an informational reason which has no more specific code
+	Info Reason = "Info"
+	// Error - an error which occurred while making the decision
+	Error Reason = "Error"
+	// AudienceEvaluated - a single audience was evaluated for the user
+	AudienceEvaluated Reason = "Audience evaluated"
+	// AudiencesEvaluated - the audience conditions of a rule were evaluated for the user
+	AudiencesEvaluated Reason = "Audiences evaluated"
+	// SavedDecisionFound - a decision saved in the user profile was found for the user
+	SavedDecisionFound Reason = "Saved decision found"
+	// UserProfileLookupFailed - the user profile of the user could not be looked up
+	UserProfileLookupFailed Reason = "User profile lookup failed"
+	// InvalidForcedDecision - a forced decision was found for the user, but its variation is not in the datafile
+	InvalidForcedDecision Reason = "Invalid forced decision"
 	// AttributeFormatInvalid - invalid format for attributes
 	AttributeFormatInvalid Reason = "Provided attributes are in an invalid format."
 	// BucketedVariationNotFound - the bucketed variation ID is not in the config
@@ -35,8 +49,22 @@ const (
 	FailedRolloutBucketing Reason = "Not bucketed into rollout"
 	// FailedRolloutTargeting - the user does not meet the rollout targeting rules
 	FailedRolloutTargeting Reason = "Does not meet rollout targeting rule"
+	// ExperimentNotRunning - the experiment is not running
+	ExperimentNotRunning Reason = "Experiment is not running"
 	// FailedAudienceTargeting - the user failed the audience targeting conditions
 	FailedAudienceTargeting Reason = "Does not meet audience targeting conditions"
+	// BucketedIntoHoldout - the user is bucketed into a variation for the given holdout
+	BucketedIntoHoldout Reason = "Bucketed into holdout"
+	// NotBucketedIntoHoldout - the user is not bucketed into any of the holdouts for the given feature
+	NotBucketedIntoHoldout Reason = "Not bucketed into holdout"
+	// HoldoutNotRunning - the holdout is not running
+	HoldoutNotRunning Reason = "Holdout is not running"
+	// CmabVariationAssigned - the user is assigned a variation by the CMAB prediction endpoint
+	CmabVariationAssigned Reason = "Assigned variation by CMAB prediction"
+	// CmabFetchFailed - the CMAB decision could not be fetched from the prediction endpoint
+	CmabFetchFailed Reason = "Failed to fetch CMAB decision"
+	// CmabServiceNotConfigured - the CMAB rule is skipped because no CMAB service is configured
+	CmabServiceNotConfigured Reason = "No CMAB service configured"
 	// NoRolloutForFeature - there is no rollout for the given feature
 	NoRolloutForFeature Reason = "No rollout for feature"
 	// RolloutHasNoExperiments - the rollout has no assigned experiments
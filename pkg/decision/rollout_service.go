@@ -32,17 +32,45 @@ import (
 type RolloutService struct {
 	audienceTreeEvaluator     evaluator.TreeEvaluator
 	experimentBucketerService ExperimentService
+	stickyBucketerService     ExperimentService
+	stickyFlags               map[string]bool
+	userProfileService        UserProfileService
 	logger                    logging.OptimizelyLogProducer
 }
 
+// RSOptionFunc is used to assign optional configuration options to the RolloutService
+type RSOptionFunc func(*RolloutService)
+
+// WithStickyRollouts saves the rollout rule decisions of the given flags in the user profile and honors them
+// afterwards, so that lowering the traffic of a rule does not move the users who were already bucketed into it.
+// A saved decision is only used when the user still passes the audience conditions of the rule. The decisions are
+// stored under the rule ID, like the experiment decisions, and are skipped with IgnoreUserProfileService.
+func WithStickyRollouts(userProfileService UserProfileService, flagKeys ...string) RSOptionFunc {
+	return func(r *RolloutService) {
+		r.userProfileService = userProfileService
+		r.stickyFlags = map[string]bool{}
+		for _, flagKey := range flagKeys {
+			r.stickyFlags[flagKey] = true
+		}
+	}
+}
+
 // NewRolloutService returns a new instance of the Rollout service
-func NewRolloutService(sdkKey string) *RolloutService {
+func NewRolloutService(sdkKey string, options ...RSOptionFunc) *RolloutService {
 	logger := logging.GetLogger(sdkKey, "RolloutService")
-	return &RolloutService{
+	rolloutService := &RolloutService{
 		logger:                    logger,
 		audienceTreeEvaluator:     evaluator.NewMixedTreeEvaluator(logger),
 		experimentBucketerService: NewExperimentBucketerService(logging.GetLogger(sdkKey, "ExperimentBucketerService")),
 	}
+	for _, opt := range options {
+		opt(rolloutService)
+	}
+	if rolloutService.userProfileService != nil {
+		rolloutService.stickyBucketerService = NewPersistingExperimentService(rolloutService.userProfileService,
+			rolloutService.experimentBucketerService, logging.GetLogger(sdkKey, "PersistingExperimentService"))
+	}
+	return rolloutService
 }
 
 // GetDecision returns a decision for the given feature and user context
@@ -53,6 +81,10 @@ func (r RolloutService) GetDecision(decisionContext FeatureDecisionContext, user
 	feature := decisionContext.Feature
 	rollout := feature.Rollout
 	reasons := decide.NewDecisionReasons(options)
+	bucketerService := r.experimentBucketerService
+	if r.stickyBucketerService != nil && r.stickyFlags[feature.Key] {
+		bucketerService = r.stickyBucketerService
+	}
 
 	evaluateConditionTree := func(experiment *entities.Experiment, loggingKey string) bool {
 		condTreeParams := entities.NewTreeParameters(&userContext, decisionContext.ProjectConfig.GetAudienceMap())
@@ -67,8 +99,10 @@ func (r RolloutService) GetDecision(decisionContext FeatureDecisionContext, user
 
 	getExperimentDecisionContext := func(experiment *entities.Experiment) ExperimentDecisionContext {
 		return ExperimentDecisionContext{
-			Experiment:    experiment,
-			ProjectConfig: decisionContext.ProjectConfig,
+			Context:            decisionContext.Context,
+			Experiment:         experiment,
+			ProjectConfig:      decisionContext.ProjectConfig,
+			UserProfileTracker: decisionContext.UserProfileTracker,
 		}
 	}
 
@@ -107,19 +141,36 @@ func (r RolloutService) GetDecision(decisionContext FeatureDecisionContext, user
 			return *forcedDecision, reasons, nil
 		}
 
+		// Skip rules which are not running
+		if !experiment.IsRunning() {
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.ExperimentNotRunning,
+				RuleKey: experiment.Key,
+				Message: fmt.Sprintf(logging.ExperimentNotRunning.String(), experiment.Key, experiment.Status),
+			})
+			r.logger.Debug(logMessage)
+			featureDecision.Reason = pkgReasons.ExperimentNotRunning
+			continue
+		}
+
 		experimentDecisionContext := getExperimentDecisionContext(experiment)
 		// Move to next evaluation if condition tree is available and evaluation fails
 
 		evaluationResult := experiment.AudienceConditionTree == nil || evaluateConditionTree(experiment, loggingKey)
 		r.logger.Debug(fmt.Sprintf(logging.RolloutAudiencesEvaluatedTo.String(), loggingKey, evaluationResult))
 		if !evaluationResult {
-			logMessage := reasons.AddInfo(logging.UserNotInRollout.String(), userContext.ID, loggingKey)
+			logMessage := reasons.AddReason(decide.StructuredReason{
+				Code:    pkgReasons.FailedRolloutTargeting,
+				RuleKey: experiment.Key,
+				Result:  decide.EvaluationResult(false),
+				Message: fmt.Sprintf(logging.UserNotInRollout.String(), userContext.ID, loggingKey),
+			})
 			r.logger.Debug(logMessage)
 			// Evaluate this user for the next rule
 			continue
 		}
 
-		decision, decisionReasons, _ := r.experimentBucketerService.GetDecision(experimentDecisionContext, userContext, options)
+		decision, decisionReasons, _ := bucketerService.GetDecision(experimentDecisionContext, userContext, options)
 		reasons.Append(decisionReasons)
 		if decision.Variation == nil {
 			// Evaluate fall back rule / last rule now
@@ -137,13 +188,24 @@ func (r RolloutService) GetDecision(decisionContext FeatureDecisionContext, user
 		return *forcedDecision, reasons, nil
 	}
 
+	if !experiment.IsRunning() {
+		logMessage := reasons.AddReason(decide.StructuredReason{
+			Code:    pkgReasons.ExperimentNotRunning,
+			RuleKey: experiment.Key,
+			Message: fmt.Sprintf(logging.ExperimentNotRunning.String(), experiment.Key, experiment.Status),
+		})
+		r.logger.Debug(logMessage)
+		featureDecision.Reason = pkgReasons.ExperimentNotRunning
+		return featureDecision, reasons, nil
+	}
+
 	experimentDecisionContext := getExperimentDecisionContext(experiment)
 	// Move to bucketing if conditionTree is unavailable or evaluation passes
 	evaluationResult := experiment.AudienceConditionTree == nil || evaluateConditionTree(experiment, "Everyone Else")
 	r.logger.Debug(fmt.Sprintf(logging.RolloutAudiencesEvaluatedTo.String(), "Everyone Else", evaluationResult))
 
 	if evaluationResult {
-		decision, decisionReasons, err := r.experimentBucketerService.GetDecision(experimentDecisionContext, userContext, options)
+		decision, decisionReasons, err := bucketerService.GetDecision(experimentDecisionContext, userContext, options)
 		reasons.Append(decisionReasons)
 		if err == nil {
 			logMessage := reasons.AddInfo(logging.UserInEveryoneElse.String(), userContext.ID)
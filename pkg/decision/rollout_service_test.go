@@ -351,6 +351,50 @@ func (s *RolloutServiceTestSuite) TestEvaluatesNextIfPreviousTargetingFails() {
 	s.mockLogger.AssertExpectations(s.T())
 }
 
+func (s *RolloutServiceTestSuite) TestSkipsRuleWhichIsNotRunning() {
+	pausedExperiment := testExp1112
+	pausedExperiment.Status = entities.ExperimentStatusPaused
+	feature := testFeatRollout3334
+	feature.Rollout.Experiments = []entities.Experiment{pausedExperiment, testExp1117, testExp1118}
+	featureDecisionContext := FeatureDecisionContext{
+		Feature:               &feature,
+		ProjectConfig:         s.mockConfig,
+		ForcedDecisionService: NewForcedDecisionService("test_user"),
+	}
+
+	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1117.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(true, true, s.reasons)
+	experiment1117DecisionContext := ExperimentDecisionContext{
+		Experiment:    &testExp1117,
+		ProjectConfig: s.mockConfig,
+	}
+	testExperimentBucketerDecision := ExperimentDecision{
+		Variation: &testExp1117Var2223,
+		Decision:  Decision{Reason: reasons.BucketedIntoVariation},
+	}
+	s.mockExperimentService.On("GetDecision", experiment1117DecisionContext, s.testUserContext, s.options, mock.Anything).Return(testExperimentBucketerDecision, s.reasons, nil)
+
+	testRolloutService := RolloutService{
+		audienceTreeEvaluator:     s.mockAudienceTreeEvaluator,
+		experimentBucketerService: s.mockExperimentService,
+		logger:                    s.mockLogger,
+	}
+	expectedFeatureDecision := FeatureDecision{
+		Experiment: testExp1117,
+		Variation:  &testExp1117Var2223,
+		Source:     Rollout,
+		Decision:   Decision{Reason: reasons.BucketedIntoRollout},
+	}
+	s.mockLogger.On("Debug", fmt.Sprintf(logging.ExperimentNotRunning.String(), testExp1112.Key, entities.ExperimentStatusPaused))
+	s.mockLogger.On("Debug", fmt.Sprintf(logging.EvaluatingAudiencesForRollout.String(), "2"))
+	s.mockLogger.On("Debug", fmt.Sprintf(logging.RolloutAudiencesEvaluatedTo.String(), "2", true))
+	s.mockLogger.On("Debug", `Decision made for user "test_user" for feature rollout with key "test_feature_rollout_3334_key": Bucketed into feature rollout.`)
+	decision, _, _ := testRolloutService.GetDecision(featureDecisionContext, s.testUserContext, s.options)
+	s.Equal(expectedFeatureDecision, decision)
+	s.mockAudienceTreeEvaluator.AssertNotCalled(s.T(), "Evaluate", testExp1112.AudienceConditionTree, s.testConditionTreeParams, mock.Anything)
+	s.mockExperimentService.AssertExpectations(s.T())
+	s.mockLogger.AssertExpectations(s.T())
+}
+
 func (s *RolloutServiceTestSuite) TestGetDecisionFailsTargeting() {
 	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1112.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(false, true, s.reasons)
 	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1117.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(false, true, s.reasons)
@@ -381,10 +425,104 @@ func (s *RolloutServiceTestSuite) TestGetDecisionFailsTargeting() {
 	s.mockLogger.AssertExpectations(s.T())
 }
 
+func (s *RolloutServiceTestSuite) TestStickyRollouts() {
+	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1112.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(true, true, s.reasons)
+	s.mockLogger.On("Debug", mock.Anything)
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	savedUserProfile := UserProfile{
+		ID:                  "test_user",
+		ExperimentBucketMap: map[UserDecisionKey]string{NewUserDecisionKey(testExp1112.ID): testExp1112Var2222.ID},
+	}
+	mockUserProfileService.On("LookupProfile", mock.Anything, "test_user").Return(savedUserProfile, nil)
+
+	testRolloutService := RolloutService{
+		audienceTreeEvaluator:     s.mockAudienceTreeEvaluator,
+		experimentBucketerService: s.mockExperimentService,
+		logger:                    s.mockLogger,
+	}
+	WithStickyRollouts(UserProfileServiceFromV2(mockUserProfileService), testFeatRollout3334Key)(&testRolloutService)
+	testRolloutService.stickyBucketerService = NewPersistingExperimentService(testRolloutService.userProfileService, s.mockExperimentService, s.mockLogger)
+
+	// the saved decision is honored although the rule no longer buckets the user
+	decision, _, err := testRolloutService.GetDecision(s.testFeatureDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(testExp1112, decision.Experiment)
+	s.Equal(testExp1112Var2222.ID, decision.Variation.ID)
+	s.mockExperimentService.AssertNotCalled(s.T(), "GetDecision", mock.Anything, mock.Anything, mock.Anything)
+	mockUserProfileService.AssertExpectations(s.T())
+}
+
+func (s *RolloutServiceTestSuite) TestStickyRolloutsSaveDecision() {
+	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1112.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(true, true, s.reasons)
+	s.mockExperimentService.On("GetDecision", s.testExperiment1112DecisionContext, s.testUserContext, s.options).Return(ExperimentDecision{
+		Variation: &testExp1112Var2222,
+		Decision:  Decision{Reason: reasons.BucketedIntoVariation},
+	}, s.reasons, nil)
+	s.mockLogger.On("Debug", mock.Anything)
+	mockUserProfileService := new(MockUserProfileServiceV2)
+	mockUserProfileService.On("LookupProfile", mock.Anything, "test_user").Return(UserProfile{ID: "test_user"}, nil)
+	mockUserProfileService.On("SaveProfile", mock.Anything, UserProfile{
+		ID:                  "test_user",
+		ExperimentBucketMap: map[UserDecisionKey]string{NewUserDecisionKey(testExp1112.ID): testExp1112Var2222.ID},
+	}).Return(nil)
+
+	testRolloutService := RolloutService{
+		audienceTreeEvaluator:     s.mockAudienceTreeEvaluator,
+		experimentBucketerService: s.mockExperimentService,
+		logger:                    s.mockLogger,
+	}
+	WithStickyRollouts(UserProfileServiceFromV2(mockUserProfileService), testFeatRollout3334Key)(&testRolloutService)
+	testRolloutService.stickyBucketerService = NewPersistingExperimentService(testRolloutService.userProfileService, s.mockExperimentService, s.mockLogger)
+
+	decision, _, err := testRolloutService.GetDecision(s.testFeatureDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(reasons.BucketedIntoRollout, decision.Reason)
+	mockUserProfileService.AssertExpectations(s.T())
+
+	// IgnoreUserProfileService bypasses the user profile
+	options := &decide.Options{IgnoreUserProfileService: true}
+	s.mockExperimentService.On("GetDecision", s.testExperiment1112DecisionContext, s.testUserContext, options).Return(ExperimentDecision{
+		Variation: &testExp1112Var2222,
+		Decision:  Decision{Reason: reasons.BucketedIntoVariation},
+	}, s.reasons, nil)
+	_, _, err = testRolloutService.GetDecision(s.testFeatureDecisionContext, s.testUserContext, options)
+	s.NoError(err)
+	mockUserProfileService.AssertNumberOfCalls(s.T(), "LookupProfile", 1)
+	mockUserProfileService.AssertNumberOfCalls(s.T(), "SaveProfile", 1)
+}
+
+func (s *RolloutServiceTestSuite) TestStickyRolloutsOtherFlag() {
+	s.mockAudienceTreeEvaluator.On("Evaluate", testExp1112.AudienceConditionTree, s.testConditionTreeParams, mock.Anything).Return(true, true, s.reasons)
+	s.mockExperimentService.On("GetDecision", s.testExperiment1112DecisionContext, s.testUserContext, s.options).Return(ExperimentDecision{
+		Variation: &testExp1112Var2222,
+		Decision:  Decision{Reason: reasons.BucketedIntoVariation},
+	}, s.reasons, nil)
+	s.mockLogger.On("Debug", mock.Anything)
+	mockUserProfileService := new(MockUserProfileServiceV2)
+
+	testRolloutService := RolloutService{
+		audienceTreeEvaluator:     s.mockAudienceTreeEvaluator,
+		experimentBucketerService: s.mockExperimentService,
+		logger:                    s.mockLogger,
+	}
+	WithStickyRollouts(UserProfileServiceFromV2(mockUserProfileService), "other_flag")(&testRolloutService)
+	testRolloutService.stickyBucketerService = NewPersistingExperimentService(testRolloutService.userProfileService, s.mockExperimentService, s.mockLogger)
+
+	decision, _, err := testRolloutService.GetDecision(s.testFeatureDecisionContext, s.testUserContext, s.options)
+	s.NoError(err)
+	s.Equal(reasons.BucketedIntoRollout, decision.Reason)
+	mockUserProfileService.AssertNotCalled(s.T(), "LookupProfile", mock.Anything, mock.Anything)
+}
+
 func TestNewRolloutService(t *testing.T) {
 	rolloutService := NewRolloutService("")
 	assert.IsType(t, &evaluator.MixedTreeEvaluator{}, rolloutService.audienceTreeEvaluator)
 	assert.IsType(t, &ExperimentBucketerService{logger: logging.GetLogger("sdkKey", "ExperimentBucketerService")}, rolloutService.experimentBucketerService)
+	assert.Nil(t, rolloutService.stickyBucketerService)
+
+	rolloutService = NewRolloutService("", WithStickyRollouts(new(MockUserProfileService), "flag"))
+	assert.IsType(t, &PersistingExperimentService{}, rolloutService.stickyBucketerService)
+	assert.Equal(t, map[string]bool{"flag": true}, rolloutService.stickyFlags)
 }
 
 func TestRolloutServiceTestSuite(t *testing.T) {
@@ -0,0 +1,130 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package decision //
+package decision
+
+import (
+	"context"
+	"sync"
+)
+
+// UserProfileTracker looks up the profile of a user once and collects the decisions made for it, so that deciding
+// several flags for the same user does a single lookup and a single save.
+type UserProfileTracker struct {
+	mutex              sync.Mutex
+	userProfileService UserProfileService
+	userProfile        UserProfile
+	lookupErr          error
+	loaded             bool
+	updated            bool
+}
+
+// NewUserProfileTracker returns a new instance of the UserProfileTracker
+func NewUserProfileTracker() *UserProfileTracker {
+	return &UserProfileTracker{}
+}
+
+// lookup returns a copy of the profile of the user, looking it up with userProfileService on the first call
+func (t *UserProfileTracker) lookup(ctx context.Context, userProfileService UserProfileService, userID string) (UserProfile, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.loaded {
+		t.userProfileService = userProfileService
+		t.userProfile, t.lookupErr = lookupUserProfile(ctx, userProfileService, userID)
+		t.userProfile.ID = userID
+		t.loaded = true
+	}
+	return copyUserProfile(t.userProfile), t.lookupErr
+}
+
+// update records the variation the user was bucketed into, to be saved by Save
+func (t *UserProfileTracker) update(experimentID, variationID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.userProfile.ExperimentBucketMap == nil {
+		t.userProfile.ExperimentBucketMap = map[UserDecisionKey]string{}
+	}
+	t.userProfile.ExperimentBucketMap[NewUserDecisionKey(experimentID)] = variationID
+	t.updated = true
+}
+
+// Save saves the profile of the user if new decisions were recorded. It does nothing when the lookup failed, so that
+// the stored profile is not overwritten with a partial one.
+func (t *UserProfileTracker) Save(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.updated || t.lookupErr != nil {
+		return nil
+	}
+	if err := saveUserProfile(ctx, t.userProfileService, t.userProfile); err != nil {
+		return err
+	}
+	t.updated = false
+	return nil
+}
+
+// UserProfileServiceFromV2 returns a UserProfileService backed by userProfileService, e.g. to pass it to
+// WithUserProfileService. The decision services use LookupProfile and SaveProfile, Lookup and Save ignore the errors.
+func UserProfileServiceFromV2(userProfileService UserProfileServiceV2) UserProfileService {
+	return userProfileServiceV2{userProfileService}
+}
+
+type userProfileServiceV2 struct {
+	UserProfileServiceV2
+}
+
+func (s userProfileServiceV2) Lookup(userID string) UserProfile {
+	userProfile, _ := s.LookupProfile(context.Background(), userID)
+	return userProfile
+}
+
+func (s userProfileServiceV2) Save(userProfile UserProfile) {
+	_ = s.SaveProfile(context.Background(), userProfile)
+}
+
+func lookupUserProfile(ctx context.Context, userProfileService UserProfileService, userID string) (UserProfile, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ups, ok := userProfileService.(UserProfileServiceV2); ok {
+		return ups.LookupProfile(ctx, userID)
+	}
+	return userProfileService.Lookup(userID), nil
+}
+
+func saveUserProfile(ctx context.Context, userProfileService UserProfileService, userProfile UserProfile) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ups, ok := userProfileService.(UserProfileServiceV2); ok {
+		return ups.SaveProfile(ctx, userProfile)
+	}
+	userProfileService.Save(userProfile)
+	return nil
+}
+
+func copyUserProfile(userProfile UserProfile) UserProfile {
+	if userProfile.ExperimentBucketMap == nil {
+		return userProfile
+	}
+	experimentBucketMap := make(map[UserDecisionKey]string, len(userProfile.ExperimentBucketMap))
+	for key, value := range userProfile.ExperimentBucketMap {
+		experimentBucketMap[key] = value
+	}
+	userProfile.ExperimentBucketMap = experimentBucketMap
+	return userProfile
+}
@@ -0,0 +1,416 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package userprofile provides user profile services keeping the bucketing decisions of users between restarts
+package userprofile
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// DefaultCompactionInterval is the interval at which Start compacts the file
+const DefaultCompactionInterval = time.Hour
+
+// minCompactionRecords is the number of records below which the file is only compacted at the compaction interval
+const minCompactionRecords = 1000
+
+// ErrClosed is returned when the FileService is used after being closed
+var ErrClosed = errors.New("user profile service is closed")
+
+// fileRecord is a line of the file, it holds the whole profile of a user as of UpdatedAt.
+// The experiment bucket map has the same layout as in the other Optimizely SDKs.
+type fileRecord struct {
+	UserID              string                       `json:"user_id"`
+	ExperimentBucketMap map[string]map[string]string `json:"experiment_bucket_map,omitempty"`
+	UpdatedAt           int64                        `json:"updated_at"`
+}
+
+type indexEntry struct {
+	userProfile decision.UserProfile
+	updatedAt   time.Time
+}
+
+// FileOptionFunc is used to provide custom configuration to the FileService.
+type FileOptionFunc func(*FileService)
+
+// WithTTL expires the profiles which were not saved for ttl. By default profiles never expire.
+func WithTTL(ttl time.Duration) FileOptionFunc {
+	return func(s *FileService) {
+		s.ttl = ttl
+	}
+}
+
+// WithCompactionInterval sets the interval at which Start compacts the file
+func WithCompactionInterval(interval time.Duration) FileOptionFunc {
+	return func(s *FileService) {
+		s.compactionInterval = interval
+	}
+}
+
+// WithProjectConfigManager prunes, on compaction, the decisions of experiments which are not in the current config
+func WithProjectConfigManager(configManager config.ProjectConfigManager) FileOptionFunc {
+	return func(s *FileService) {
+		s.configManager = configManager
+	}
+}
+
+// WithSync syncs the file to disk after every save, so that decisions survive a crash of the machine and not only
+// a restart of the process
+func WithSync(sync bool) FileOptionFunc {
+	return func(s *FileService) {
+		s.sync = sync
+	}
+}
+
+// FileService is a UserProfileService storing the profiles in a local append-only file. Every save appends the
+// whole profile of the user and an in-memory index holds the latest profile of every user, so lookups do not read
+// the file. Compact rewrites the file with the live profiles only.
+type FileService struct {
+	path               string
+	ttl                time.Duration
+	compactionInterval time.Duration
+	configManager      config.ProjectConfigManager
+	sync               bool
+
+	mutex sync.RWMutex
+	file  *os.File
+	index map[string]indexEntry
+	// records counts the lines of the file, Start compacts the file when it exceeds twice the profiles
+	records              int
+	minCompactionRecords int
+	compactionNeeded     chan struct{}
+	closed               bool
+	now                  func() time.Time
+	logger               logging.OptimizelyLogProducer
+}
+
+// NewFileService opens the file at path, creating it if needed, and loads its profiles
+func NewFileService(path string, options ...FileOptionFunc) (*FileService, error) {
+	s := &FileService{
+		path:                 path,
+		compactionInterval:   DefaultCompactionInterval,
+		index:                map[string]indexEntry{},
+		minCompactionRecords: minCompactionRecords,
+		compactionNeeded:     make(chan struct{}, 1),
+		now:                  time.Now,
+		logger:               logging.GetLogger("", "FileUserProfileService"),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.load(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	s.file = file
+	s.requestCompaction()
+	return s, nil
+}
+
+// Start compacts the file at the compaction interval, and whenever the file holds more than twice as many records
+// as profiles, until ctx is done
+func (s *FileService) Start(ctx context.Context) {
+	var tick <-chan time.Time
+	if s.compactionInterval > 0 {
+		ticker := time.NewTicker(s.compactionInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-tick:
+		case <-s.compactionNeeded:
+		case <-ctx.Done():
+			return
+		}
+		if err := s.Compact(); err != nil {
+			s.logger.Error("Unable to compact the user profile file", err)
+		}
+	}
+}
+
+// LookupProfile returns the profile of the user, which is empty when the user has no profile or it expired
+func (s *FileService) LookupProfile(_ context.Context, userID string) (decision.UserProfile, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.closed {
+		return decision.UserProfile{ID: userID}, ErrClosed
+	}
+	entry, ok := s.index[userID]
+	if !ok || s.isExpired(entry) {
+		return decision.UserProfile{ID: userID}, nil
+	}
+	return copyUserProfile(entry.userProfile), nil
+}
+
+// SaveProfile appends the profile to the file and makes it the current profile of the user
+func (s *FileService) SaveProfile(_ context.Context, userProfile decision.UserProfile) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	entry := indexEntry{userProfile: copyUserProfile(userProfile), updatedAt: s.now()}
+	if err := s.write(s.file, entry); err != nil {
+		return err
+	}
+	if s.sync {
+		if err := s.file.Sync(); err != nil {
+			return err
+		}
+	}
+	s.index[userProfile.ID] = entry
+	s.records++
+	s.requestCompaction()
+	return nil
+}
+
+// Lookup is like LookupProfile, failures are logged and return an empty profile
+func (s *FileService) Lookup(userID string) decision.UserProfile {
+	userProfile, err := s.LookupProfile(context.Background(), userID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Unable to look up the profile of user %q", userID), err)
+	}
+	return userProfile
+}
+
+// Save is like SaveProfile, failures are logged
+func (s *FileService) Save(userProfile decision.UserProfile) {
+	if err := s.SaveProfile(context.Background(), userProfile); err != nil {
+		s.logger.Error(fmt.Sprintf("Unable to save the profile of user %q", userProfile.ID), err)
+	}
+}
+
+// Len returns the number of profiles in the index, including the expired ones which were not compacted yet
+func (s *FileService) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.index)
+}
+
+// Compact drops the expired profiles and the decisions of experiments which are not in the current project config,
+// then replaces the file with one holding a single record per profile. Saves and lookups wait for the compaction.
+func (s *FileService) Compact() error {
+	experimentIDs := s.currentExperimentIDs()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	// the index is only replaced once the file is, so that memory and disk agree when the compaction fails
+	index := make(map[string]indexEntry, len(s.index))
+	for userID, entry := range s.index {
+		if s.isExpired(entry) {
+			continue
+		}
+		if experimentIDs != nil {
+			entry.userProfile = pruneUserProfile(entry.userProfile, experimentIDs)
+			if len(entry.userProfile.ExperimentBucketMap) == 0 {
+				continue
+			}
+		}
+		index[userID] = entry
+	}
+
+	tmpPath := s.path + ".compact"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmpFile)
+	for _, entry := range index {
+		if err = s.write(writer, entry); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, s.path)
+	}
+	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	s.file.Close()
+	s.file = tmpFile
+	s.index = index
+	s.records = len(index)
+	s.logger.Debug(fmt.Sprintf("Compacted the user profile file to %d profiles", s.records))
+	return nil
+}
+
+// Close closes the file, the FileService cannot be used afterwards
+func (s *FileService) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.file.Close()
+}
+
+// load builds the index from the records of the file. Malformed lines, e.g. a line cut by a crash, are skipped.
+func (s *FileService) load(file *os.File) error {
+	reader := bufio.NewReader(file)
+	var last byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			last = line[len(line)-1]
+			s.loadRecord(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// terminate a cut line so the next record starts on its own line
+	if last != 0 && last != '\n' {
+		if _, err := file.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileService) loadRecord(line []byte) {
+	s.records++
+	var record fileRecord
+	if err := json.Unmarshal(line, &record); err != nil || record.UserID == "" {
+		s.logger.Warning(fmt.Sprintf("Skipping a malformed record of the user profile file %s", s.path))
+		return
+	}
+
+	userProfile := decision.UserProfile{ID: record.UserID, ExperimentBucketMap: map[decision.UserDecisionKey]string{}}
+	for experimentID, fields := range record.ExperimentBucketMap {
+		for field, value := range fields {
+			userProfile.ExperimentBucketMap[decision.UserDecisionKey{ExperimentID: experimentID, Field: field}] = value
+		}
+	}
+	s.index[record.UserID] = indexEntry{userProfile: userProfile, updatedAt: time.UnixMilli(record.UpdatedAt)}
+}
+
+func (s *FileService) write(writer io.Writer, entry indexEntry) error {
+	record := fileRecord{
+		UserID:              entry.userProfile.ID,
+		ExperimentBucketMap: map[string]map[string]string{},
+		UpdatedAt:           entry.updatedAt.UnixMilli(),
+	}
+	for key, value := range entry.userProfile.ExperimentBucketMap {
+		if record.ExperimentBucketMap[key.ExperimentID] == nil {
+			record.ExperimentBucketMap[key.ExperimentID] = map[string]string{}
+		}
+		record.ExperimentBucketMap[key.ExperimentID][key.Field] = value
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(line, '\n'))
+	return err
+}
+
+// requestCompaction asks Start to compact the file when it holds more than twice as many records as profiles
+func (s *FileService) requestCompaction() {
+	if s.records < s.minCompactionRecords || s.records <= 2*len(s.index) {
+		return
+	}
+	select {
+	case s.compactionNeeded <- struct{}{}:
+	default:
+	}
+}
+
+func (s *FileService) isExpired(entry indexEntry) bool {
+	return s.ttl > 0 && s.now().Sub(entry.updatedAt) >= s.ttl
+}
+
+// currentExperimentIDs returns the IDs of the experiments and rollout rules of the current config, or nil when
+// there is no config to prune with
+func (s *FileService) currentExperimentIDs() map[string]struct{} {
+	if s.configManager == nil {
+		return nil
+	}
+	projectConfig, err := s.configManager.GetConfig()
+	if err != nil || projectConfig == nil {
+		return nil
+	}
+
+	experimentIDs := map[string]struct{}{}
+	for _, experiment := range projectConfig.GetExperimentList() {
+		experimentIDs[experiment.ID] = struct{}{}
+	}
+	for _, rollout := range projectConfig.GetRolloutList() {
+		for _, experiment := range rollout.Experiments {
+			experimentIDs[experiment.ID] = struct{}{}
+		}
+	}
+	return experimentIDs
+}
+
+// pruneUserProfile returns a copy of the profile without the decisions of the experiments which are not in
+// experimentIDs
+func pruneUserProfile(userProfile decision.UserProfile, experimentIDs map[string]struct{}) decision.UserProfile {
+	experimentBucketMap := map[decision.UserDecisionKey]string{}
+	for key, value := range userProfile.ExperimentBucketMap {
+		if _, ok := experimentIDs[key.ExperimentID]; ok {
+			experimentBucketMap[key] = value
+		}
+	}
+	userProfile.ExperimentBucketMap = experimentBucketMap
+	return userProfile
+}
+
+func copyUserProfile(userProfile decision.UserProfile) decision.UserProfile {
+	experimentBucketMap := make(map[decision.UserDecisionKey]string, len(userProfile.ExperimentBucketMap))
+	for key, value := range userProfile.ExperimentBucketMap {
+		experimentBucketMap[key] = value
+	}
+	userProfile.ExperimentBucketMap = experimentBucketMap
+	return userProfile
+}
@@ -0,0 +1,303 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package userprofile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/optimizelytest"
+)
+
+type FileServiceTestSuite struct {
+	suite.Suite
+	path string
+	now  time.Time
+}
+
+func (s *FileServiceTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "profiles.jsonl")
+	s.now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *FileServiceTestSuite) open(options ...FileOptionFunc) *FileService {
+	service, err := NewFileService(s.path, options...)
+	s.Require().NoError(err)
+	service.now = func() time.Time { return s.now }
+	s.T().Cleanup(func() { service.Close() })
+	return service
+}
+
+func (s *FileServiceTestSuite) lines() []string {
+	content, err := os.ReadFile(s.path)
+	s.Require().NoError(err)
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
+
+func profile(userID string, decisions map[string]string) decision.UserProfile {
+	userProfile := decision.UserProfile{ID: userID, ExperimentBucketMap: map[decision.UserDecisionKey]string{}}
+	for experimentID, variationID := range decisions {
+		userProfile.ExperimentBucketMap[decision.NewUserDecisionKey(experimentID)] = variationID
+	}
+	return userProfile
+}
+
+func (s *FileServiceTestSuite) TestSaveAndReopen() {
+	service := s.open()
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var1"})))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var1", "exp2": "var3"})))
+	service.Save(profile("user2", map[string]string{"exp1": "var2"}))
+	s.NoError(service.Close())
+
+	service = s.open()
+	s.Equal(2, service.Len())
+	userProfile, err := service.LookupProfile(context.Background(), "user1")
+	s.NoError(err)
+	s.Equal(profile("user1", map[string]string{"exp1": "var1", "exp2": "var3"}), userProfile)
+	s.Equal(profile("user2", map[string]string{"exp1": "var2"}), service.Lookup("user2"))
+
+	userProfile, err = service.LookupProfile(context.Background(), "unknown")
+	s.NoError(err)
+	s.Equal(decision.UserProfile{ID: "unknown"}, userProfile)
+}
+
+func (s *FileServiceTestSuite) TestLookupReturnsCopy() {
+	service := s.open()
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var1"})))
+	userProfile := service.Lookup("user1")
+	userProfile.ExperimentBucketMap[decision.NewUserDecisionKey("exp1")] = "changed"
+	s.Equal(profile("user1", map[string]string{"exp1": "var1"}), service.Lookup("user1"))
+}
+
+func (s *FileServiceTestSuite) TestTTL() {
+	service := s.open(WithTTL(time.Hour))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var1"})))
+	s.now = s.now.Add(30 * time.Minute)
+	s.NoError(service.SaveProfile(context.Background(), profile("user2", map[string]string{"exp1": "var2"})))
+
+	s.now = s.now.Add(30 * time.Minute)
+	s.Equal(decision.UserProfile{ID: "user1"}, service.Lookup("user1"))
+	s.Equal(profile("user2", map[string]string{"exp1": "var2"}), service.Lookup("user2"))
+
+	s.NoError(service.Compact())
+	s.Equal(1, service.Len())
+	s.Len(s.lines(), 1)
+}
+
+func (s *FileServiceTestSuite) TestCompact() {
+	service := s.open()
+	for i := 0; i < 10; i++ {
+		s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": fmt.Sprintf("var%d", i)})))
+	}
+	s.NoError(service.SaveProfile(context.Background(), profile("user2", map[string]string{"exp1": "var1"})))
+	s.Len(s.lines(), 11)
+
+	s.NoError(service.Compact())
+	s.Len(s.lines(), 2)
+
+	// saves after the compaction go to the new file
+	s.NoError(service.SaveProfile(context.Background(), profile("user3", map[string]string{"exp1": "var1"})))
+	s.Len(s.lines(), 3)
+	s.NoError(service.Close())
+
+	service = s.open()
+	s.Equal(3, service.Len())
+	s.Equal(profile("user1", map[string]string{"exp1": "var9"}), service.Lookup("user1"))
+	_, err := os.Stat(s.path + ".compact")
+	s.True(os.IsNotExist(err))
+}
+
+func (s *FileServiceTestSuite) TestPruneExperimentsNotInConfig() {
+	b := builder.New()
+	b.Flag("checkout").Experiment("checkout_test").Variation("control", false, nil)
+	b.Flag("search").EveryoneElse()
+	configManager, err := b.ConfigManager()
+	s.Require().NoError(err)
+	projectConfig, err := configManager.GetConfig()
+	s.Require().NoError(err)
+	experiment, err := projectConfig.GetExperimentByKey("checkout_test")
+	s.Require().NoError(err)
+	rolloutRuleID := projectConfig.GetRolloutList()[0].Experiments[0].ID
+
+	service := s.open(WithProjectConfigManager(configManager))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{experiment.ID: "var1", "deleted": "var2"})))
+	s.NoError(service.SaveProfile(context.Background(), profile("user2", map[string]string{"deleted": "var2"})))
+	s.NoError(service.SaveProfile(context.Background(), profile("user3", map[string]string{rolloutRuleID: "var3"})))
+
+	s.NoError(service.Compact())
+	s.Equal(2, service.Len())
+	s.Equal(profile("user1", map[string]string{experiment.ID: "var1"}), service.Lookup("user1"))
+	s.Equal(decision.UserProfile{ID: "user2"}, service.Lookup("user2"))
+	s.Equal(profile("user3", map[string]string{rolloutRuleID: "var3"}), service.Lookup("user3"))
+}
+
+func (s *FileServiceTestSuite) TestFailedCompactionKeepsIndex() {
+	b := builder.New()
+	b.Flag("checkout").Experiment("checkout_test").Variation("control", false, nil)
+	configManager, err := b.ConfigManager()
+	s.Require().NoError(err)
+	service := s.open(WithProjectConfigManager(configManager), WithTTL(time.Hour))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"deleted": "var1"})))
+	s.now = s.now.Add(30 * time.Minute)
+	s.NoError(service.SaveProfile(context.Background(), profile("user2", map[string]string{"deleted": "var2"})))
+	s.now = s.now.Add(30 * time.Minute)
+
+	// the temporary file cannot be created
+	s.Require().NoError(os.Mkdir(s.path+".compact", 0o700))
+	s.Error(service.Compact())
+	s.Equal(2, service.Len())
+	s.Equal(profile("user2", map[string]string{"deleted": "var2"}), service.Lookup("user2"))
+	s.Len(s.lines(), 2)
+}
+
+func (s *FileServiceTestSuite) TestMalformedRecords() {
+	s.Require().NoError(os.WriteFile(s.path, []byte(`{"user_id":"user1","experiment_bucket_map":{"exp1":{"variation_id":"var1"}},"updated_at":1}
+not json
+{"user_id":"user2","experiment_bu`), 0o600))
+
+	service := s.open()
+	s.Equal(1, service.Len())
+	s.Equal(profile("user1", map[string]string{"exp1": "var1"}), service.Lookup("user1"))
+
+	// the cut line is terminated, so the new record can be read back
+	s.NoError(service.SaveProfile(context.Background(), profile("user2", map[string]string{"exp1": "var2"})))
+	s.NoError(service.Close())
+	service = s.open()
+	s.Equal(profile("user2", map[string]string{"exp1": "var2"}), service.Lookup("user2"))
+}
+
+func (s *FileServiceTestSuite) TestConcurrentAccess() {
+	service := s.open()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userID := fmt.Sprintf("user%d", i)
+			for j := 0; j < 20; j++ {
+				s.NoError(service.SaveProfile(context.Background(), profile(userID, map[string]string{"exp1": fmt.Sprintf("var%d", j)})))
+				service.Lookup(userID)
+				if j%10 == 0 {
+					s.NoError(service.Compact())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	s.NoError(service.Close())
+
+	service = s.open()
+	s.Equal(10, service.Len())
+	s.Equal(profile("user3", map[string]string{"exp1": "var19"}), service.Lookup("user3"))
+}
+
+func (s *FileServiceTestSuite) TestClosed() {
+	service := s.open()
+	s.NoError(service.Close())
+	s.NoError(service.Close())
+	s.ErrorIs(service.SaveProfile(context.Background(), profile("user1", nil)), ErrClosed)
+	_, err := service.LookupProfile(context.Background(), "user1")
+	s.ErrorIs(err, ErrClosed)
+	s.ErrorIs(service.Compact(), ErrClosed)
+}
+
+func (s *FileServiceTestSuite) TestStart() {
+	service := s.open(WithCompactionInterval(10 * time.Millisecond))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var1"})))
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var2"})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.Start(ctx)
+		close(done)
+	}()
+	s.Eventually(func() bool {
+		service.mutex.RLock()
+		defer service.mutex.RUnlock()
+		return service.records == 1
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+}
+
+func (s *FileServiceTestSuite) TestStartCompactsWhenRecordsGrow() {
+	service := s.open(WithCompactionInterval(0))
+	service.minCompactionRecords = 4
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.Start(ctx)
+		close(done)
+	}()
+	for i := 0; i < 3; i++ {
+		s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": fmt.Sprintf("var%d", i)})))
+	}
+	s.Len(s.lines(), 3)
+
+	s.NoError(service.SaveProfile(context.Background(), profile("user1", map[string]string{"exp1": "var3"})))
+	s.Eventually(func() bool {
+		service.mutex.RLock()
+		defer service.mutex.RUnlock()
+		return service.records == 1
+	}, time.Second, 10*time.Millisecond)
+	s.Len(s.lines(), 1)
+	cancel()
+	<-done
+}
+
+func (s *FileServiceTestSuite) decide(weights ...int) string {
+	b := builder.New()
+	b.Flag("checkout").Experiment("checkout_test").
+		Variation("control", false, nil).
+		Variation("treatment", true, nil).
+		Weights(weights...)
+	datafile, err := b.JSON()
+	s.Require().NoError(err)
+	kit, err := optimizelytest.NewKit(datafile)
+	s.Require().NoError(err)
+
+	service := s.open()
+	defer service.Close()
+	optimizelyClient, err := kit.Client(client.WithUserProfileService(service))
+	s.Require().NoError(err)
+	defer optimizelyClient.Close()
+	userContext := optimizelyClient.CreateUserContext("user1", nil)
+	return userContext.Decide("checkout", nil).VariationKey
+}
+
+func (s *FileServiceTestSuite) TestStickyDecisionsAcrossRestarts() {
+	s.Equal("treatment", s.decide(0, 1))
+	// the user keeps the saved variation after the traffic allocation changed
+	s.Equal("treatment", s.decide(1, 0))
+}
+
+func TestFileServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FileServiceTestSuite))
+}
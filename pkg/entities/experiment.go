@@ -25,6 +25,22 @@ type Variation struct {
 	FeatureEnabled bool
 }
 
+// ExperimentStatus is the status of an experiment
+type ExperimentStatus string
+
+const (
+	// ExperimentStatusRunning - the experiment is running
+	ExperimentStatusRunning ExperimentStatus = "Running"
+	// ExperimentStatusLaunched - the experiment is launched
+	ExperimentStatusLaunched ExperimentStatus = "Launched"
+	// ExperimentStatusPaused - the experiment is paused
+	ExperimentStatusPaused ExperimentStatus = "Paused"
+	// ExperimentStatusNotStarted - the experiment has not started
+	ExperimentStatusNotStarted ExperimentStatus = "Not started"
+	// ExperimentStatusArchived - the experiment is archived
+	ExperimentStatusArchived ExperimentStatus = "Archived"
+)
+
 // Experiment represents an experiment
 type Experiment struct {
 	AudienceIds           []string
@@ -39,6 +55,25 @@ type Experiment struct {
 	AudienceConditionTree *TreeNode
 	Whitelist             map[string]string
 	IsFeatureExperiment   bool
+	Status                ExperimentStatus
+	Cmab                  *Cmab
+}
+
+// Cmab represents the contextual multi-armed bandit settings of an experiment
+type Cmab struct {
+	AttributeIds      []string
+	TrafficAllocation int
+}
+
+// IsRunning returns true if users can be bucketed into the experiment.
+// An experiment without a status is treated as running.
+func (e Experiment) IsRunning() bool {
+	switch e.Status {
+	case "", ExperimentStatusRunning, ExperimentStatusLaunched:
+		return true
+	default:
+		return false
+	}
 }
 
 // Range represents bucketing range that the specify entityID falls into
@@ -25,6 +25,7 @@ type Feature struct {
 	ExperimentIDs      []string
 	Rollout            Rollout
 	VariableMap        map[string]Variable
+	Holdouts           []Holdout // holdouts applicable to this feature, global holdouts first
 }
 
 // Rollout represents a feature rollout
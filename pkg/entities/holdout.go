@@ -0,0 +1,72 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package entities //
+package entities
+
+// HoldoutStatus is the status of a holdout
+type HoldoutStatus string
+
+const (
+	// HoldoutStatusDraft - the holdout is a draft
+	HoldoutStatusDraft HoldoutStatus = "Draft"
+	// HoldoutStatusRunning - the holdout is running
+	HoldoutStatusRunning HoldoutStatus = "Running"
+	// HoldoutStatusConcluded - the holdout has concluded
+	HoldoutStatusConcluded HoldoutStatus = "Concluded"
+	// HoldoutStatusArchived - the holdout is archived
+	HoldoutStatusArchived HoldoutStatus = "Archived"
+)
+
+// Holdout represents a holdout. A holdout with no included flags is a global holdout
+// and applies to every flag that is not explicitly excluded.
+type Holdout struct {
+	ID                    string
+	Key                   string
+	Status                HoldoutStatus
+	AudienceIds           []string
+	AudienceConditions    interface{}
+	AudienceConditionTree *TreeNode
+	Variations            map[string]Variation // keyed by variation ID
+	VariationKeyToIDMap   map[string]string
+	TrafficAllocation     []Range
+	IncludedFlags         []string // flag IDs
+	ExcludedFlags         []string // flag IDs
+}
+
+// IsGlobal returns true if the holdout applies to all flags that are not excluded
+func (h Holdout) IsGlobal() bool {
+	return len(h.IncludedFlags) == 0
+}
+
+// IsRunning returns true if the holdout is running
+func (h Holdout) IsRunning() bool {
+	return h.Status == HoldoutStatusRunning
+}
+
+// ToExperiment returns an experiment representation of the holdout which can be used for bucketing and impressions
+func (h Holdout) ToExperiment() Experiment {
+	return Experiment{
+		AudienceIds:           h.AudienceIds,
+		AudienceConditions:    h.AudienceConditions,
+		AudienceConditionTree: h.AudienceConditionTree,
+		ID:                    h.ID,
+		Key:                   h.Key,
+		Variations:            h.Variations,
+		VariationKeyToIDMap:   h.VariationKeyToIDMap,
+		TrafficAllocation:     h.TrafficAllocation,
+	}
+}
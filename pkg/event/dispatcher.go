@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/semaphore"
@@ -92,6 +93,9 @@ type QueueEventDispatcher struct {
 	sucessFlushCounter metrics.Counter
 	failFlushCounter   metrics.Counter
 	retryFlushCounter  metrics.Counter
+
+	// number of user events sent with the dispatched log events
+	sentCount int64
 }
 
 // DispatchEvent queues event with callback and calls flush in a go routine.
@@ -106,17 +110,48 @@ func (ed *QueueEventDispatcher) waitForDispatchingEventsOnClose(timeout time.Dur
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	_ = ed.waitForDispatchingEvents(ctx)
+}
+
+// waitForDispatchingEvents waits until all the events are dispatched or ctx is done
+func (ed *QueueEventDispatcher) waitForDispatchingEvents(ctx context.Context) error {
 	for {
+		if ed.eventQueue.Size() == 0 {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
-			return
-		default:
-			if ed.eventQueue.Size() == 0 {
-				return
-			}
-			time.Sleep(CloseEventDispatchWaitTime)
+			return ctx.Err()
+		case <-time.After(CloseEventDispatchWaitTime):
+		}
+	}
+}
+
+// Flush dispatches the queued events and waits until they are sent, or returns the error of ctx once it is done
+func (ed *QueueEventDispatcher) Flush(ctx context.Context) error {
+	go ed.flushEvents()
+	return ed.waitForDispatchingEvents(ctx)
+}
+
+// Report returns the number of user events sent and still queued by the dispatcher
+func (ed *QueueEventDispatcher) Report() FlushReport {
+	return FlushReport{Dispatched: ed.sentEvents(), Pending: ed.pendingEvents()}
+}
+
+// sentEvents returns the number of user events which were sent
+func (ed *QueueEventDispatcher) sentEvents() int {
+	return int(atomic.LoadInt64(&ed.sentCount))
+}
+
+// pendingEvents returns the number of user events which are queued to be sent
+func (ed *QueueEventDispatcher) pendingEvents() int {
+	pending := 0
+	for _, item := range ed.eventQueue.Get(ed.eventQueue.Size()) {
+		if logEvent, ok := item.(LogEvent); ok {
+			pending += len(logEvent.Event.Visitors)
 		}
 	}
+	return pending
 }
 
 // flush the events
@@ -156,6 +191,7 @@ func (ed *QueueEventDispatcher) flushEvents() {
 		if err == nil {
 			if success {
 				ed.logger.Debug("dispatch log event succeeded")
+				atomic.AddInt64(&ed.sentCount, int64(len(event.Event.Visitors)))
 				ed.eventQueue.Remove(1)
 				retryCount = 0
 				ed.sucessFlushCounter.Add(1)
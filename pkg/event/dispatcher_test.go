@@ -18,6 +18,7 @@
 package event
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -234,3 +235,25 @@ func TestQueueEventDispatcher_WaitForDispatchingEventsOnClose(t *testing.T) {
 	// check the queue
 	assert.Equal(t, 0, q.eventQueue.Size())
 }
+
+func TestQueueEventDispatcher_FlushAndReport(t *testing.T) {
+	conversionUserEvent := CreateConversionUserEvent(TestConfig{}, entities.Event{ExperimentIds: []string{"15402980349"}, ID: "15368860886", Key: "sample_conversion"}, userContext, nil)
+	logEvent := createLogEvent(createBatchEvent(conversionUserEvent, createVisitorFromUserEvent(conversionUserEvent)), DefaultEventEndPoint)
+
+	q := NewQueueEventDispatcher("", nil)
+	q.Dispatcher = &MockDispatcher{Events: NewInMemoryQueue(100)}
+	q.eventQueue.Add(logEvent)
+
+	assert.Equal(t, FlushReport{Pending: 1}, q.Report())
+	assert.NoError(t, q.Flush(context.Background()))
+	assert.Equal(t, FlushReport{Dispatched: 1}, q.Report())
+
+	failing := NewQueueEventDispatcher("", nil)
+	failing.Dispatcher = &MockDispatcher{ShouldFail: true, Events: NewInMemoryQueue(100)}
+	failing.eventQueue.Add(logEvent)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, failing.Flush(ctx), context.DeadlineExceeded)
+	assert.Equal(t, FlushReport{Pending: 1}, failing.Report())
+}
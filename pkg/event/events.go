@@ -56,6 +56,7 @@ type DecisionMetadata struct {
 	RuleType     string `json:"rule_type"`
 	VariationKey string `json:"variation_key"`
 	Enabled      bool   `json:"enabled"`
+	CmabUUID     string `json:"cmab_uuid,omitempty"`
 }
 
 // ConversionEvent represents a conversion event
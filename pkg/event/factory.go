@@ -222,6 +222,11 @@ func createVisitor(userEvent UserEvent, attributes []VisitorAttribute,
 	return visitor
 }
 
+// CreateLogEvent creates the log event which sends the given user event on its own to the given end point
+func CreateLogEvent(userEvent UserEvent, eventEndPoint string) LogEvent {
+	return createLogEvent(createBatchEvent(userEvent, createVisitorFromUserEvent(userEvent)), eventEndPoint)
+}
+
 // create a batch event with visitor
 func createBatchEvent(userEvent UserEvent, visitor Visitor) Batch {
 
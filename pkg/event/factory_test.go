@@ -132,6 +132,16 @@ func TestCreateEmptyEvent(t *testing.T) {
 
 }
 
+func TestCreateLogEvent(t *testing.T) {
+	impressionUserEvent := BuildTestImpressionEvent()
+	logEvent := CreateLogEvent(impressionUserEvent, DefaultEventEndPoint)
+
+	assert.Equal(t, DefaultEventEndPoint, logEvent.EndPoint)
+	assert.Equal(t, impressionUserEvent.EventContext.Revision, logEvent.Event.Revision)
+	assert.Len(t, logEvent.Event.Visitors, 1)
+	assert.Equal(t, impressionUserEvent.VisitorID, logEvent.Event.Visitors[0].VisitorID)
+}
+
 func TestCreateAndSendImpressionEvent(t *testing.T) {
 	impressionUserEvent := BuildTestImpressionEvent()
 	assert.Equal(t, userContext.ID, impressionUserEvent.VisitorID)
@@ -198,6 +208,7 @@ func TestCreateImpressionUserEvent(t *testing.T) {
 		{decision.FeatureTest, true},
 		{"experiment", true},
 		{"anything-else", true},
+		{decision.Holdout, true},
 		{decision.Rollout, false},
 	}
 
@@ -241,3 +252,25 @@ func TestCreateImpressionUserEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateHoldoutImpressionUserEvent(t *testing.T) {
+	tc := TestConfig{}
+	holdout := entities.Holdout{
+		ID:     "3000",
+		Key:    "test_holdout",
+		Status: entities.HoldoutStatusRunning,
+	}
+	holdoutVariation := entities.Variation{ID: "3001", Key: "ho_off_key"}
+
+	userEvent, ok := CreateImpressionUserEvent(tc, holdout.ToExperiment(), &holdoutVariation, userContext, "test_flag", holdout.Key, decision.Holdout, false)
+	assert.True(t, ok)
+	assert.Equal(t, holdout.ID, userEvent.Impression.ExperimentID)
+	assert.Equal(t, holdoutVariation.ID, userEvent.Impression.VariationID)
+
+	metaData := userEvent.Impression.Metadata
+	assert.Equal(t, "test_flag", metaData.FlagKey)
+	assert.Equal(t, holdout.Key, metaData.RuleKey)
+	assert.Equal(t, decision.Holdout, metaData.RuleType)
+	assert.Equal(t, holdoutVariation.Key, metaData.VariationKey)
+	assert.False(t, metaData.Enabled)
+}
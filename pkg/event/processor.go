@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/semaphore"
@@ -39,6 +40,11 @@ type Processor interface {
 	RemoveOnEventDispatch(id int) error
 }
 
+// BulkProcessor is implemented by processors which can queue several events in one call
+type BulkProcessor interface {
+	ProcessEvents(events []UserEvent) int
+}
+
 // BatchEventProcessor is used out of the box by the SDK to queue up and batch events to be sent to the Optimizely
 // log endpoint for results processing.
 type BatchEventProcessor struct {
@@ -54,6 +60,17 @@ type BatchEventProcessor struct {
 	processing      *semaphore.Weighted
 	logger          logging.OptimizelyLogProducer
 	metricsRegistry metrics.Registry
+
+	dispatchedCount int64
+	droppedCount    int64
+}
+
+// FlushReport holds the number of events a component has dispatched and dropped since it was created,
+// and the number of events it still holds
+type FlushReport struct {
+	Dispatched int
+	Dropped    int
+	Pending    int
 }
 
 // DefaultBatchSize holds the default value for the batch size
@@ -197,13 +214,37 @@ func (p *BatchEventProcessor) ProcessEvent(event UserEvent) bool {
 
 	if p.Q.Size() >= p.MaxQueueSize {
 		p.logger.Warning("MaxQueueSize has been met. Discarding event")
+		atomic.AddInt64(&p.droppedCount, 1)
 		return false
 	}
 
 	p.Q.Add(event)
+	p.flushIfBatchSizeMet()
+
+	return true
+}
 
+// ProcessEvents queues the events and starts at most one flush for the whole slice.
+// It returns the number of events queued, the rest are discarded once MaxQueueSize is met.
+func (p *BatchEventProcessor) ProcessEvents(events []UserEvent) int {
+	queued := 0
+	for _, event := range events {
+		if p.Q.Size() >= p.MaxQueueSize {
+			p.logger.Warning(fmt.Sprintf("MaxQueueSize has been met. Discarding %d events", len(events)-queued))
+			atomic.AddInt64(&p.droppedCount, int64(len(events)-queued))
+			break
+		}
+		p.Q.Add(event)
+		queued++
+	}
+	p.flushIfBatchSizeMet()
+
+	return queued
+}
+
+func (p *BatchEventProcessor) flushIfBatchSizeMet() {
 	if p.Q.Size() < p.BatchSize {
-		return true
+		return
 	}
 
 	if p.processing.TryAcquire(1) {
@@ -215,8 +256,6 @@ func (p *BatchEventProcessor) ProcessEvent(event UserEvent) bool {
 			p.processing.Release(1)
 		}()
 	}
-
-	return true
 }
 
 // eventsCount returns size of an event queue
@@ -329,6 +368,7 @@ func (p *BatchEventProcessor) flushEvents() {
 			}
 			if success, _ := p.EventDispatcher.DispatchEvent(logEvent); success {
 				p.logger.Debug("Dispatched event successfully")
+				atomic.AddInt64(&p.dispatchedCount, int64(batchEventCount))
 				p.remove(batchEventCount)
 				batchEventCount = 0
 				batchEvent = Batch{}
@@ -340,6 +380,49 @@ func (p *BatchEventProcessor) flushEvents() {
 	}
 }
 
+// Flush dispatches the queued events and waits until the event dispatcher has sent them or ctx is done,
+// in which case the context's error is returned
+func (p *BatchEventProcessor) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		p.flushEvents()
+		if d, ok := p.EventDispatcher.(*QueueEventDispatcher); ok {
+			d.flushEvents()
+		}
+	}()
+
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if d, ok := p.EventDispatcher.(*QueueEventDispatcher); ok {
+		return d.waitForDispatchingEvents(ctx)
+	}
+	return nil
+}
+
+// Report returns the number of events dispatched, dropped and still pending. Events handed to a QueueEventDispatcher
+// only count as dispatched once it has sent them.
+func (p *BatchEventProcessor) Report() FlushReport {
+	report := FlushReport{
+		Dispatched: int(atomic.LoadInt64(&p.dispatchedCount)),
+		Dropped:    int(atomic.LoadInt64(&p.droppedCount)),
+		Pending:    p.eventsCount(),
+	}
+	if d, ok := p.EventDispatcher.(*QueueEventDispatcher); ok {
+		sent, pending := d.sentEvents(), d.pendingEvents()
+		if lost := report.Dispatched - sent - pending; lost > 0 {
+			report.Dropped += lost
+		}
+		report.Dispatched = sent
+		report.Pending += pending
+	}
+	return report
+}
+
 // OnEventDispatch registers a handler for LogEvent notifications
 func (p *BatchEventProcessor) OnEventDispatch(callback func(logEvent LogEvent)) (int, error) {
 	notificationCenter := registry.GetNotificationCenter(p.sdkKey)
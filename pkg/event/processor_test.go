@@ -292,6 +292,76 @@ func TestDefaultEventProcessor_BatchSizeMet(t *testing.T) {
 	assert.Equal(t, 2, dispatcher.Events.Size())
 }
 
+func TestDefaultEventProcessor_ProcessEvents(t *testing.T) {
+	eg := newExecutionContext()
+	dispatcher := NewMockDispatcher(100, false)
+	processor := NewBatchEventProcessor(
+		WithBatchSize(2),
+		WithQueueSize(3),
+		WithFlushInterval(1000*time.Millisecond),
+		WithQueue(NewInMemoryQueue(3)),
+		WithEventDispatcher(dispatcher))
+
+	impression := BuildTestImpressionEvent()
+	queued := processor.ProcessEvents([]UserEvent{impression, impression, impression, impression})
+
+	assert.Equal(t, 3, queued)
+	assert.Equal(t, 3, processor.eventsCount())
+
+	eg.Go(processor.Start)
+	eg.TerminateAndWait()
+
+	assert.Equal(t, 0, processor.eventsCount())
+	assert.Equal(t, 2, dispatcher.Events.Size())
+}
+
+func TestBatchEventProcessor_FlushAndReport(t *testing.T) {
+	dispatcher := NewMockDispatcher(100, false)
+	processor := NewBatchEventProcessor(
+		WithQueue(NewInMemoryQueue(3)),
+		WithEventDispatcher(dispatcher))
+	// keep the batch size above the queue size so that nothing is flushed before Flush is called
+	processor.MaxQueueSize = 3
+
+	impression := BuildTestImpressionEvent()
+	for i := 0; i < 4; i++ {
+		processor.ProcessEvent(impression)
+	}
+	assert.Equal(t, FlushReport{Dispatched: 0, Dropped: 1, Pending: 3}, processor.Report())
+
+	assert.NoError(t, processor.Flush(context.Background()))
+	assert.Equal(t, FlushReport{Dispatched: 3, Dropped: 1, Pending: 0}, processor.Report())
+	assert.Equal(t, 1, dispatcher.Events.Size())
+}
+
+func TestBatchEventProcessor_FlushWithQueueEventDispatcher(t *testing.T) {
+	queueDispatcher := NewQueueEventDispatcher("", nil)
+	sender := &MockDispatcher{Events: NewInMemoryQueue(100)}
+	queueDispatcher.Dispatcher = sender
+	processor := NewBatchEventProcessor(WithEventDispatcher(queueDispatcher))
+
+	impression := BuildTestImpressionEvent()
+	processor.ProcessEvent(impression)
+	processor.ProcessEvent(impression)
+
+	assert.NoError(t, processor.Flush(context.Background()))
+	assert.Equal(t, FlushReport{Dispatched: 2, Dropped: 0, Pending: 0}, processor.Report())
+	assert.Equal(t, 1, sender.Events.Size())
+}
+
+func TestBatchEventProcessor_FlushDeadlineExceeded(t *testing.T) {
+	queueDispatcher := NewQueueEventDispatcher("", nil)
+	queueDispatcher.Dispatcher = &MockDispatcher{ShouldFail: true, Events: NewInMemoryQueue(100)}
+	processor := NewBatchEventProcessor(WithEventDispatcher(queueDispatcher))
+
+	processor.ProcessEvent(BuildTestImpressionEvent())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, processor.Flush(ctx), context.DeadlineExceeded)
+	assert.Equal(t, FlushReport{Dispatched: 0, Dropped: 0, Pending: 1}, processor.Report())
+}
+
 func TestDefaultEventProcessor_BatchSizeLessThanQSize(t *testing.T) {
 	processor := NewBatchEventProcessor(
 		WithQueueSize(2),
@@ -39,6 +39,28 @@ const (
 	EvaluatingAudiencesForExperiment LogMessage = `Evaluating audiences for experiment "%s".`
 	// EvaluatingAudiencesForRollout when audience evaluation is started for a rule
 	EvaluatingAudiencesForRollout LogMessage = `Evaluating audiences for rule %s.".`
+	// ExperimentNotRunning when an experiment is skipped because it is not running
+	ExperimentNotRunning LogMessage = `Experiment "%s" is not running (status %q).`
+	// EvaluatingAudiencesForHoldout when audience evaluation is started for a holdout
+	EvaluatingAudiencesForHoldout LogMessage = `Evaluating audiences for holdout "%s".`
+	// HoldoutAudiencesEvaluatedTo when collective audience evaluation for holdout is completed
+	HoldoutAudiencesEvaluatedTo LogMessage = `Audiences for holdout %s collectively evaluated to %t.`
+	// UserInHoldout when user is bucketed into a holdout
+	UserInHoldout LogMessage = `User "%s" is bucketed into holdout "%s" for feature flag "%s".`
+	// UserNotInHoldout when user does not meet conditions or is not bucketed into a holdout
+	UserNotInHoldout LogMessage = `User "%s" is not bucketed into holdout "%s" for feature flag "%s".`
+	// HoldoutNotRunning when a holdout is skipped because it is not running
+	HoldoutNotRunning LogMessage = `Holdout "%s" is not running.`
+	// UserNotInCmabTrafficAllocation when user is not bucketed into the traffic allocation of a CMAB rule
+	UserNotInCmabTrafficAllocation LogMessage = `User "%s" is not in the traffic allocation of CMAB rule "%s".`
+	// CmabDecisionFromCache when a cached CMAB decision is used
+	CmabDecisionFromCache LogMessage = `Using cached CMAB decision for user "%s" and rule "%s".`
+	// CmabDecisionFetched when a CMAB decision is fetched from the prediction endpoint
+	CmabDecisionFetched LogMessage = `Fetched CMAB decision for user "%s" and rule "%s": variation "%s".`
+	// CmabFetchFailed when a CMAB decision cannot be fetched from the prediction endpoint
+	CmabFetchFailed LogMessage = `Failed to fetch CMAB decision for user "%s" and rule "%s".`
+	// CmabServiceNotConfigured when a CMAB rule is skipped because no CMAB service is configured
+	CmabServiceNotConfigured LogMessage = `Skipping CMAB rule "%s", no CMAB service is configured.`
 	// NullUserAttribute when user attribute is missing or nil
 	NullUserAttribute LogMessage = `Audience condition %s evaluated to UNKNOWN because a null value was passed for user attribute "%s".`
 	// UserInEveryoneElse when user is in last rule
@@ -41,11 +41,13 @@ func NewNotificationCenter() *DefaultCenter {
 	projectConfigUpdateNotificationManager := NewAtomicManager(logging.GetLogger("", "AtomicManager"))
 	processLogEventNotificationManager := NewAtomicManager(logging.GetLogger("", "AtomicManager"))
 	trackNotificationManager := NewAtomicManager(logging.GetLogger("", "AtomicManager"))
+	readyNotificationManager := NewAtomicManager(logging.GetLogger("", "AtomicManager"))
 	managerMap := make(map[Type]Manager)
 	managerMap[Decision] = decisionNotificationManager
 	managerMap[ProjectConfigUpdate] = projectConfigUpdateNotificationManager
 	managerMap[LogEvent] = processLogEventNotificationManager
 	managerMap[Track] = trackNotificationManager
+	managerMap[Ready] = readyNotificationManager
 	return &DefaultCenter{
 		managerMap: managerMap,
 	}
@@ -18,6 +18,9 @@
 package notification
 
 import (
+	"context"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
 	"github.com/optimizely/go-sdk/v2/pkg/entities"
 )
 
@@ -36,6 +39,8 @@ const (
 	ProjectConfigUpdate Type = "project_config_update"
 	// LogEvent notification type
 	LogEvent Type = "log_event_notification"
+	// Ready notification type
+	Ready Type = "ready"
 
 	// ABTest is used when the decision is returned as part of evaluating an ab test
 	ABTest DecisionNotificationType = "ab-test"
@@ -56,6 +61,8 @@ type DecisionNotification struct {
 	Type         DecisionNotificationType
 	UserContext  entities.UserContext
 	DecisionInfo map[string]interface{}
+	// Context is the context of the decide call which triggered the notification, if any
+	Context context.Context
 }
 
 // TrackNotification is a notification triggered when track is called
@@ -64,12 +71,22 @@ type TrackNotification struct {
 	UserContext     entities.UserContext
 	EventTags       map[string]interface{}
 	ConversionEvent interface{}
+	// Context is the context of the track call which triggered the notification, if any
+	Context context.Context
 }
 
 // ProjectConfigUpdateNotification is a notification triggered when a project config is updated
 type ProjectConfigUpdateNotification struct {
 	Type     Type
 	Revision string
+	// Diff holds the changes from the previous project config, it is nil for the first project config
+	Diff *configdiff.Diff
+}
+
+// ReadyNotification is a notification triggered once, when the first project config becomes available
+type ReadyNotification struct {
+	Type     Type
+	Revision string
 }
 
 // LogEventNotification is the notification triggered before log event is dispatched.
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	guuid "github.com/google/uuid"
@@ -60,6 +61,9 @@ type BatchEventManager struct {
 	apiManager    APIManager
 	processing    *semaphore.Weighted
 	logger        logging.OptimizelyLogProducer
+
+	dispatchedCount int64
+	droppedCount    int64
 }
 
 // WithQueueSize sets the queue size as a config option to be passed into the NewBatchEventManager method
@@ -189,6 +193,7 @@ func (bm *BatchEventManager) ProcessEvent(apiKey, apiHost string, odpEvent Event
 	if bm.eventQueue.Size() >= bm.maxQueueSize {
 		err = errors.New("ODP EventQueue is full")
 		bm.logger.Error("maxQueueSize has been met. Discarding event", err)
+		atomic.AddInt64(&bm.droppedCount, 1)
 		return err
 	}
 
@@ -293,14 +298,16 @@ func (bm *BatchEventManager) FlushEvents(apiKey, apiHost string) {
 				// Remove events from queue if dispatch failed and retrying is not suggested
 				if !shouldRetry {
 					bm.eventQueue.Remove(batchEventCount)
-					batchEventCount = 0
-					batchEvent = []Event{}
 					if err == nil {
 						bm.logger.Debug("Dispatched odp event successfully")
+						atomic.AddInt64(&bm.dispatchedCount, int64(batchEventCount))
 						failedToSend = false
 					} else {
 						bm.logger.Warning(err.Error())
+						atomic.AddInt64(&bm.droppedCount, int64(batchEventCount))
 					}
+					batchEventCount = 0
+					batchEvent = []Event{}
 					break
 				}
 				retryCount++
@@ -309,11 +316,38 @@ func (bm *BatchEventManager) FlushEvents(apiKey, apiHost string) {
 	}
 }
 
+// FlushWithContext flushes the queued events like FlushEvents, but returns the context's error
+// if ctx is done before the flush completes
+func (bm *BatchEventManager) FlushWithContext(ctx context.Context, apiKey, apiHost string) error {
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		bm.FlushEvents(apiKey, apiHost)
+	}()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Report returns the number of odp events dispatched, dropped and still pending
+func (bm *BatchEventManager) Report() event.FlushReport {
+	return event.FlushReport{
+		Dispatched: int(atomic.LoadInt64(&bm.dispatchedCount)),
+		Dropped:    int(atomic.LoadInt64(&bm.droppedCount)),
+		Pending:    bm.eventQueue.Size(),
+	}
+}
+
 // IsOdpServiceIntegrated returns true if odp service is integrated
 func (bm *BatchEventManager) IsOdpServiceIntegrated(apiKey, apiHost string) bool {
 	if apiKey == "" || apiHost == "" {
 		// ensure empty queue
-		bm.eventQueue.Remove(bm.eventQueue.Size())
+		removed := bm.eventQueue.Remove(bm.eventQueue.Size())
+		atomic.AddInt64(&bm.droppedCount, int64(len(removed)))
 		return false
 	}
 
@@ -486,6 +486,42 @@ func (e *EventManagerTestSuite) TestFlushEventsAsyncBehaviour() {
 	e.Equal(iterations, len(eventAPIManager.eventsSent))
 }
 
+func (e *EventManagerTestSuite) TestFlushWithContextAndReport() {
+	apiManager := &MockEventAPIManager{shouldNotInformWaitgroup: true}
+	em := NewBatchEventManager(WithAPIManager(apiManager))
+	em.maxQueueSize = 2
+	e.NoError(em.ProcessEvent("a", "b", Event{Action: "123"}))
+	e.NoError(em.ProcessEvent("a", "b", Event{Action: "123"}))
+	e.Error(em.ProcessEvent("a", "b", Event{Action: "123"}))
+	e.Equal(event.FlushReport{Dispatched: 0, Dropped: 1, Pending: 2}, em.Report())
+
+	e.NoError(em.FlushWithContext(context.Background(), "a", "b"))
+	e.Equal(event.FlushReport{Dispatched: 2, Dropped: 1, Pending: 0}, em.Report())
+}
+
+func (e *EventManagerTestSuite) TestReportCountsFailedEventsAsDropped() {
+	apiManager := &MockEventAPIManager{shouldNotInformWaitgroup: true}
+	apiManager.retryResponses = []bool{false}
+	apiManager.errResponses = []error{errors.New("")}
+	em := NewBatchEventManager(WithAPIManager(apiManager))
+	em.eventQueue.Add(Event{Action: "123"})
+
+	e.NoError(em.FlushWithContext(context.Background(), "a", "b"))
+	e.Equal(event.FlushReport{Dispatched: 0, Dropped: 1, Pending: 0}, em.Report())
+}
+
+func (e *EventManagerTestSuite) TestFlushWithContextDeadlineExceeded() {
+	apiManager := &BlockingEventAPIManager{release: make(chan struct{})}
+	defer close(apiManager.release)
+	em := NewBatchEventManager(WithAPIManager(apiManager))
+	em.eventQueue.Add(Event{Action: "123"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	e.ErrorIs(em.FlushWithContext(ctx, "a", "b"), context.DeadlineExceeded)
+	e.Equal(event.FlushReport{Dispatched: 0, Dropped: 0, Pending: 1}, em.Report())
+}
+
 func (e *EventManagerTestSuite) TestAddCommonData() {
 	userEvent := Event{Action: "123"}
 	e.eventManager.addCommonData(&userEvent)
@@ -607,3 +643,12 @@ func (m *MockEventAPIManager) SendOdpEvents(apiKey, apiHost string, events []Eve
 func newExecutionContext() *pkgUtils.ExecGroup {
 	return pkgUtils.NewExecGroup(context.Background(), logging.GetLogger("", "NewExecGroup"))
 }
+
+type BlockingEventAPIManager struct {
+	release chan struct{}
+}
+
+func (m *BlockingEventAPIManager) SendOdpEvents(apiKey, apiHost string, events []Event) (canRetry bool, err error) {
+	<-m.release
+	return false, nil
+}
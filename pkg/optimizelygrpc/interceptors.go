@@ -0,0 +1,87 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelygrpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// UnaryServerInterceptor returns an interceptor adding the user context sent by the caller to the ctx of every
+// unary request, see ServerContext
+func (p *Propagator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(p.incomingContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor adding the user context sent by the caller to the ctx of every
+// stream, see ServerContext
+func (p *Propagator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: p.incomingContext(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor sending the user context held by the ctx of every unary call, see
+// NewContext. Calls without a user context are sent unchanged.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	logger := logging.GetLogger("", "OptimizelyGRPCPropagator")
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx, logger), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns an interceptor sending the user context held by the ctx of every stream, like
+// UnaryClientInterceptor does
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	logger := logging.GetLogger("", "OptimizelyGRPCPropagator")
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx, logger), desc, cc, method, opts...)
+	}
+}
+
+func (p *Propagator) incomingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return p.ServerContext(ctx, md)
+}
+
+func outgoingContext(ctx context.Context, logger logging.OptimizelyLogProducer) context.Context {
+	pairs, err := OutgoingPairs(ctx)
+	if err != nil {
+		if !errors.Is(err, ErrNoUserContext) {
+			logger.Warning(err.Error())
+		}
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// serverStream returns the ctx holding the user context instead of the one of the wrapped stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,97 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelygrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// propagate sends the user context held by ctx through the client interceptors, and returns the ctx the server
+// interceptors pass to the handlers
+func (s *PropagationTestSuite) propagate(p *Propagator, ctx context.Context) (unaryCtx, streamCtx context.Context) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	s.Require().NoError(UnaryClientInterceptor()(ctx, "/test.Service/Unary", nil, nil, nil, invoker))
+
+	var streamOutgoing metadata.MD
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamOutgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+	_, err := StreamClientInterceptor()(ctx, &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	s.Require().NoError(err)
+	s.Equal(outgoing, streamOutgoing)
+
+	incoming := metadata.NewIncomingContext(context.Background(), outgoing)
+	_, err = p.UnaryServerInterceptor()(incoming, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		unaryCtx = ctx
+		return nil, nil
+	})
+	s.Require().NoError(err)
+
+	err = p.StreamServerInterceptor()(nil, &testServerStream{ctx: incoming}, &grpc.StreamServerInfo{}, func(_ any, ss grpc.ServerStream) error {
+		streamCtx = ss.Context()
+		return nil
+	})
+	s.Require().NoError(err)
+	return unaryCtx, streamCtx
+}
+
+func (s *PropagationTestSuite) TestInterceptors() {
+	userContext := s.client.CreateUserContext("user1", map[string]interface{}{"plan": "premium"})
+	unaryCtx, streamCtx := s.propagate(New(s.client), NewContext(context.Background(), &userContext))
+
+	for _, ctx := range []context.Context{unaryCtx, streamCtx} {
+		received, ok := FromContext(ctx)
+		s.Require().True(ok)
+		s.Equal("user1", received.GetUserID())
+		s.Equal(map[string]interface{}{"plan": "premium"}, received.GetUserAttributes())
+		s.Equal("treatment", received.Decide("checkout", nil).VariationKey)
+	}
+}
+
+func (s *PropagationTestSuite) TestInterceptorsWithoutUserContext() {
+	unaryCtx, streamCtx := s.propagate(New(s.client), context.Background())
+
+	for _, ctx := range []context.Context{unaryCtx, streamCtx} {
+		_, ok := FromContext(ctx)
+		s.False(ok)
+	}
+}
+
+func (s *PropagationTestSuite) TestClientInterceptorsUnserializableAttributes() {
+	userContext := s.client.CreateUserContext("user1", map[string]interface{}{"callback": func() {}})
+	unaryCtx, _ := s.propagate(New(s.client), NewContext(context.Background(), &userContext))
+
+	_, ok := FromContext(unaryCtx)
+	s.False(ok)
+}
@@ -0,0 +1,167 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package optimizelygrpc propagates OptimizelyUserContexts between gRPC services through request metadata.
+//
+// The server interceptors of a Propagator create the user context sent by the caller, and handlers read it with
+// FromContext:
+//
+//	propagator := optimizelygrpc.New(optimizelyClient)
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(propagator.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(propagator.StreamServerInterceptor()),
+//	)
+//
+// The client interceptors send the user context held by the ctx of the call, see NewContext:
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(optimizelygrpc.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(optimizelygrpc.StreamClientInterceptor()),
+//	)
+//
+// The package is a separate module, so that the SDK does not depend on grpc.
+package optimizelygrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+)
+
+// MetadataKey is the metadata key holding the user context. The "-bin" suffix makes grpc base64 encode the value, so
+// user IDs and attributes are not restricted to printable ASCII.
+const MetadataKey = "optimizely-user-context-bin"
+
+// ErrNoUserContext is returned when there is no user context to propagate
+var ErrNoUserContext = errors.New("no user context to propagate")
+
+type contextKey struct{}
+
+// Propagator creates the user contexts of incoming requests from their metadata
+type Propagator struct {
+	client       *client.OptimizelyClient
+	forceAllowed func(ctx context.Context) bool
+	forced       bool
+	logger       logging.OptimizelyLogProducer
+}
+
+// OptionFunc is used to provide custom configuration to the Propagator.
+type OptionFunc func(*Propagator)
+
+// WithForcedDecisions applies the forced decisions sent by the caller. Since any caller can send them, allowed should
+// only accept trusted callers, e.g. by checking the peer or the credentials held by the ctx. A nil allowed accepts
+// every caller. By default the forced decisions in the metadata are ignored.
+func WithForcedDecisions(allowed func(ctx context.Context) bool) OptionFunc {
+	return func(p *Propagator) {
+		p.forced = true
+		p.forceAllowed = allowed
+	}
+}
+
+// New returns a Propagator creating user contexts with the given client
+func New(optimizelyClient *client.OptimizelyClient, options ...OptionFunc) *Propagator {
+	p := &Propagator{
+		client: optimizelyClient,
+		logger: logging.GetLogger("", "OptimizelyGRPCPropagator"),
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// ServerContext returns a copy of ctx holding the user context sent in md. The ctx is returned unchanged when md
+// holds no valid user context.
+func (p *Propagator) ServerContext(ctx context.Context, md map[string][]string) context.Context {
+	userContext, ok, err := p.FromMetadata(ctx, md)
+	if err != nil {
+		p.logger.Warning(err.Error())
+	}
+	if !ok {
+		return ctx
+	}
+	return NewContext(ctx, userContext)
+}
+
+// FromMetadata creates the user context sent in md. It returns false when md holds no user context.
+func (p *Propagator) FromMetadata(ctx context.Context, md map[string][]string) (*client.OptimizelyUserContext, bool, error) {
+	values := md[MetadataKey]
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+
+	var snapshot client.UserContextSnapshot
+	if err := json.Unmarshal([]byte(values[len(values)-1]), &snapshot); err != nil {
+		return nil, false, fmt.Errorf("invalid %s metadata: %w", MetadataKey, err)
+	}
+	if len(snapshot.ForcedDecisions) > 0 {
+		if !p.forced {
+			snapshot.ForcedDecisions = nil
+		} else if p.forceAllowed != nil && !p.forceAllowed(ctx) {
+			p.logger.Warning("Ignoring the forced decisions of a caller which is not allowed to force decisions")
+			snapshot.ForcedDecisions = nil
+		}
+	}
+
+	userContext, err := p.client.RestoreUserContext(snapshot)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid %s metadata: %w", MetadataKey, err)
+	}
+	return &userContext, true, nil
+}
+
+// ToMetadata returns the metadata propagating the snapshot of userContext, see OptimizelyUserContext.Snapshot.
+// Attributes must be JSON serializable, numbers are received as float64.
+func ToMetadata(userContext *client.OptimizelyUserContext) (map[string][]string, error) {
+	if userContext == nil {
+		return nil, ErrNoUserContext
+	}
+
+	value, err := json.Marshal(userContext.Snapshot())
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the user context: %w", err)
+	}
+	return map[string][]string{MetadataKey: {string(value)}}, nil
+}
+
+// OutgoingPairs returns the key-value pairs propagating the user context held by ctx, to be passed to
+// metadata.AppendToOutgoingContext.
+func OutgoingPairs(ctx context.Context) ([]string, error) {
+	userContext, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrNoUserContext
+	}
+	md, err := ToMetadata(userContext)
+	if err != nil {
+		return nil, err
+	}
+	return []string{MetadataKey, md[MetadataKey][0]}, nil
+}
+
+// NewContext returns a copy of ctx holding the user context
+func NewContext(ctx context.Context, userContext *client.OptimizelyUserContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, userContext)
+}
+
+// FromContext returns the user context held by ctx, if any
+func FromContext(ctx context.Context) (*client.OptimizelyUserContext, bool) {
+	userContext, ok := ctx.Value(contextKey{}).(*client.OptimizelyUserContext)
+	return userContext, ok && userContext != nil
+}
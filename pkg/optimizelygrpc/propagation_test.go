@@ -0,0 +1,124 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelygrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/optimizelytest"
+)
+
+type PropagationTestSuite struct {
+	suite.Suite
+	client *client.OptimizelyClient
+}
+
+func (s *PropagationTestSuite) SetupTest() {
+	b := builder.New()
+	b.Audience("premium", builder.Exact("plan", "premium"))
+	b.Flag("checkout").Experiment("checkout_test").
+		Audiences("premium").
+		Variation("control", false, nil).
+		Variation("treatment", true, nil).
+		Weights(0, 1)
+	datafile, err := b.JSON()
+	s.Require().NoError(err)
+
+	kit, err := optimizelytest.NewKit(datafile)
+	s.Require().NoError(err)
+	s.client, err = kit.Client()
+	s.Require().NoError(err)
+}
+
+func (s *PropagationTestSuite) TearDownTest() {
+	s.client.Close()
+}
+
+// roundTrip propagates the user context held by ctx to a new incoming context, like a pair of interceptors would
+func (s *PropagationTestSuite) roundTrip(p *Propagator, ctx context.Context) context.Context {
+	pairs, err := OutgoingPairs(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(pairs, 2)
+	return p.ServerContext(context.Background(), map[string][]string{pairs[0]: {pairs[1]}})
+}
+
+func (s *PropagationTestSuite) TestRoundTrip() {
+	userContext := s.client.CreateUserContext("user1", map[string]interface{}{"plan": "premium", "age": 30})
+	userContext.SetQualifiedSegments([]string{"segment1"})
+
+	received, ok := FromContext(s.roundTrip(New(s.client), NewContext(context.Background(), &userContext)))
+	s.Require().True(ok)
+	s.Equal("user1", received.GetUserID())
+	s.Equal(map[string]interface{}{"plan": "premium", "age": float64(30)}, received.GetUserAttributes())
+	s.Equal([]string{"segment1"}, received.GetQualifiedSegments())
+
+	decision := received.Decide("checkout", nil)
+	s.Equal("treatment", decision.VariationKey)
+}
+
+func (s *PropagationTestSuite) TestForcedDecisions() {
+	userContext := s.client.CreateUserContext("user1", nil)
+	userContext.SetForcedDecision(decision.OptimizelyDecisionContext{FlagKey: "checkout", RuleKey: "checkout_test"}, decision.OptimizelyForcedDecision{VariationKey: "control"})
+	ctx := NewContext(context.Background(), &userContext)
+
+	received, ok := FromContext(s.roundTrip(New(s.client), ctx))
+	s.Require().True(ok)
+	s.Empty(received.GetForcedDecisions())
+
+	received, ok = FromContext(s.roundTrip(New(s.client, WithForcedDecisions(nil)), ctx))
+	s.Require().True(ok)
+	s.Equal("control", received.Decide("checkout", nil).VariationKey)
+
+	denied := New(s.client, WithForcedDecisions(func(ctx context.Context) bool { return false }))
+	received, ok = FromContext(s.roundTrip(denied, ctx))
+	s.Require().True(ok)
+	s.Empty(received.GetForcedDecisions())
+}
+
+func (s *PropagationTestSuite) TestMissingOrInvalidMetadata() {
+	p := New(s.client)
+	ctx := context.Background()
+
+	s.Equal(ctx, p.ServerContext(ctx, nil))
+	s.Equal(ctx, p.ServerContext(ctx, map[string][]string{MetadataKey: {"{"}}))
+	s.Equal(ctx, p.ServerContext(ctx, map[string][]string{MetadataKey: {`{"attributes":{"plan":"premium"}}`}}))
+
+	_, ok, err := p.FromMetadata(ctx, map[string][]string{MetadataKey: {`{"userId":""}`}})
+	s.False(ok)
+	s.Error(err)
+
+	_, err = OutgoingPairs(ctx)
+	s.ErrorIs(err, ErrNoUserContext)
+	_, err = ToMetadata(nil)
+	s.ErrorIs(err, ErrNoUserContext)
+}
+
+func (s *PropagationTestSuite) TestUnserializableAttributes() {
+	userContext := s.client.CreateUserContext("user1", map[string]interface{}{"callback": func() {}})
+	_, err := ToMetadata(&userContext)
+	s.Error(err)
+}
+
+func TestPropagationTestSuite(t *testing.T) {
+	suite.Run(t, new(PropagationTestSuite))
+}
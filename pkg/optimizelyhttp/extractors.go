@@ -0,0 +1,133 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelyhttp
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// UserIDExtractor returns the user ID of the request, or false if the request has none
+type UserIDExtractor func(r *http.Request) (string, bool)
+
+// AttributesExtractor returns user attributes read from the request
+type AttributesExtractor func(r *http.Request) map[string]interface{}
+
+// HeaderUserID reads the user ID from the header of the given name
+func HeaderUserID(name string) UserIDExtractor {
+	return func(r *http.Request) (string, bool) {
+		userID := r.Header.Get(name)
+		return userID, userID != ""
+	}
+}
+
+// CookieUserID reads the user ID from the cookie of the given name
+func CookieUserID(name string) UserIDExtractor {
+	return func(r *http.Request) (string, bool) {
+		cookie, err := r.Cookie(name)
+		if err != nil || cookie.Value == "" {
+			return "", false
+		}
+		return cookie.Value, true
+	}
+}
+
+// FirstUserID returns the user ID of the first extractor which finds one
+func FirstUserID(extractors ...UserIDExtractor) UserIDExtractor {
+	return func(r *http.Request) (string, bool) {
+		for _, extractor := range extractors {
+			if userID, ok := extractor(r); ok {
+				return userID, true
+			}
+		}
+		return "", false
+	}
+}
+
+// HeaderAttributes reads the attributes from the headers mapped to attribute keys. Values are kept as strings, wrap
+// the extractor with TypedAttributes to match typed audience conditions.
+func HeaderAttributes(headerToAttribute map[string]string) AttributesExtractor {
+	return func(r *http.Request) map[string]interface{} {
+		attributes := map[string]interface{}{}
+		for header, attribute := range headerToAttribute {
+			if value := r.Header.Get(header); value != "" {
+				attributes[attribute] = value
+			}
+		}
+		return attributes
+	}
+}
+
+// CookieAttributes reads the attributes from the cookies mapped to attribute keys. Values are kept as strings like
+// HeaderAttributes does.
+func CookieAttributes(cookieToAttribute map[string]string) AttributesExtractor {
+	return func(r *http.Request) map[string]interface{} {
+		attributes := map[string]interface{}{}
+		for name, attribute := range cookieToAttribute {
+			if cookie, err := r.Cookie(name); err == nil && cookie.Value != "" {
+				attributes[attribute] = cookie.Value
+			}
+		}
+		return attributes
+	}
+}
+
+// StaticAttributes adds the given attributes to every user, e.g. the name of the service
+func StaticAttributes(attributes map[string]interface{}) AttributesExtractor {
+	return func(r *http.Request) map[string]interface{} {
+		return attributes
+	}
+}
+
+// TypedAttributes converts the string attributes of the extractor which are written as a bool or a decimal number,
+// so that they match the typed audience conditions. Numbers with leading zeros, like "0123", stay strings.
+func TypedAttributes(extractor AttributesExtractor) AttributesExtractor {
+	return func(r *http.Request) map[string]interface{} {
+		attributes := extractor(r)
+		typed := make(map[string]interface{}, len(attributes))
+		for key, value := range attributes {
+			if s, ok := value.(string); ok {
+				typed[key] = parseValue(s)
+				continue
+			}
+			typed[key] = value
+		}
+		return typed
+	}
+}
+
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+func parseValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if !numberPattern.MatchString(value) {
+		return value
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
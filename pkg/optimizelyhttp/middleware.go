@@ -0,0 +1,161 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package optimizelyhttp provides a net/http middleware which creates an OptimizelyUserContext for every request
+package optimizelyhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/decision"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/odp/segment"
+)
+
+// DefaultForceHeader is the header read by WithForcedDecisions when no header is given
+const DefaultForceHeader = "X-Optimizely-Force"
+
+type contextKey struct{}
+
+// Middleware creates a user context for every request with a user ID and puts it into the request context
+type Middleware struct {
+	client         *client.OptimizelyClient
+	userID         UserIDExtractor
+	attributes     []AttributesExtractor
+	fetchSegments  bool
+	segmentOptions []segment.OptimizelySegmentOption
+	forceHeader    string
+	forceAllowed   func(r *http.Request) bool
+	missingUser    http.Handler
+	logger         logging.OptimizelyLogProducer
+}
+
+// OptionFunc is used to provide custom configuration to the Middleware.
+type OptionFunc func(*Middleware)
+
+// WithAttributes sets the extractors of the user attributes. Later extractors override the attributes of earlier ones.
+func WithAttributes(extractors ...AttributesExtractor) OptionFunc {
+	return func(m *Middleware) {
+		m.attributes = append(m.attributes, extractors...)
+	}
+}
+
+// WithSegments fetches the ODP segments of the user before the request is handled
+func WithSegments(options ...segment.OptimizelySegmentOption) OptionFunc {
+	return func(m *Middleware) {
+		m.fetchSegments = true
+		m.segmentOptions = options
+	}
+}
+
+// WithForcedDecisions sets forced decisions from the given header, in the form "flag=variation" or
+// "flag:rule=variation" separated by commas. Since anyone can send the header, allowed should only accept requests
+// from QA, e.g. by checking an internal network or a signed cookie. A nil allowed accepts no request.
+func WithForcedDecisions(header string, allowed func(r *http.Request) bool) OptionFunc {
+	return func(m *Middleware) {
+		if header == "" {
+			header = DefaultForceHeader
+		}
+		m.forceHeader = header
+		m.forceAllowed = allowed
+	}
+}
+
+// WithMissingUserHandler sets the handler of the requests without a user ID. By default these requests are handled
+// by the next handler without a user context.
+func WithMissingUserHandler(handler http.Handler) OptionFunc {
+	return func(m *Middleware) {
+		m.missingUser = handler
+	}
+}
+
+// New returns a Middleware creating user contexts with the given client and user ID extractor
+func New(optimizelyClient *client.OptimizelyClient, userID UserIDExtractor, options ...OptionFunc) *Middleware {
+	m := &Middleware{
+		client: optimizelyClient,
+		userID: userID,
+		logger: logging.GetLogger("", "OptimizelyHTTPMiddleware"),
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+// Handler returns a handler which creates the user context of the request and passes it to next
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := m.userID(r)
+		if !ok {
+			if m.missingUser != nil {
+				m.missingUser.ServeHTTP(w, r)
+			} else {
+				next.ServeHTTP(w, r)
+			}
+			return
+		}
+
+		userContext := m.client.CreateUserContext(userID, m.extractAttributes(r))
+		if m.fetchSegments && !userContext.FetchQualifiedSegmentsWithContext(r.Context(), m.segmentOptions) {
+			m.logger.Warning(fmt.Sprintf("Unable to fetch the segments of user %s", userID))
+		}
+		if m.forceHeader != "" {
+			m.setForcedDecisions(r, &userContext)
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), &userContext)))
+	})
+}
+
+// NewContext returns a copy of ctx holding the user context
+func NewContext(ctx context.Context, userContext *client.OptimizelyUserContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, userContext)
+}
+
+// FromContext returns the user context held by ctx, if any
+func FromContext(ctx context.Context) (*client.OptimizelyUserContext, bool) {
+	userContext, ok := ctx.Value(contextKey{}).(*client.OptimizelyUserContext)
+	return userContext, ok
+}
+
+func (m *Middleware) extractAttributes(r *http.Request) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for _, extractor := range m.attributes {
+		for key, value := range extractor(r) {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
+
+func (m *Middleware) setForcedDecisions(r *http.Request, userContext *client.OptimizelyUserContext) {
+	value := r.Header.Get(m.forceHeader)
+	if value == "" {
+		return
+	}
+	if m.forceAllowed == nil || !m.forceAllowed(r) {
+		m.logger.Warning(fmt.Sprintf("Ignoring the %s header of a request which is not allowed to force decisions", m.forceHeader))
+		return
+	}
+
+	mappings, invalid := decision.ParseForcedDecisions(value)
+	invalid = append(invalid, userContext.ImportForcedDecisions(mappings)...)
+	for _, err := range invalid {
+		m.logger.Warning(err.Error())
+	}
+}
@@ -0,0 +1,177 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelyhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig/builder"
+	"github.com/optimizely/go-sdk/v2/pkg/optimizelytest"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+	kit         *optimizelytest.Kit
+	client      *client.OptimizelyClient
+	userContext *client.OptimizelyUserContext
+	handled     bool
+}
+
+func (s *MiddlewareTestSuite) SetupTest() {
+	b := builder.New()
+	b.Audience("premium", builder.Exact("plan", "premium"))
+	b.Flag("checkout").Experiment("checkout_test").
+		Audiences("premium").
+		Variation("control", false, nil).
+		Variation("treatment", true, nil).
+		Weights(0, 1)
+	datafile, err := b.JSON()
+	s.Require().NoError(err)
+
+	s.kit, err = optimizelytest.NewKit(datafile)
+	s.Require().NoError(err)
+	s.client, err = s.kit.Client()
+	s.Require().NoError(err)
+	s.userContext = nil
+	s.handled = false
+}
+
+func (s *MiddlewareTestSuite) TearDownTest() {
+	s.client.Close()
+}
+
+func (s *MiddlewareTestSuite) serve(m *Middleware, r *http.Request) *httptest.ResponseRecorder {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handled = true
+		s.userContext, _ = FromContext(r.Context())
+	})
+	recorder := httptest.NewRecorder()
+	m.Handler(next).ServeHTTP(recorder, r)
+	return recorder
+}
+
+func (s *MiddlewareTestSuite) TestUserContext() {
+	m := New(s.client, FirstUserID(HeaderUserID("X-User-ID"), CookieUserID("uid")),
+		WithAttributes(
+			HeaderAttributes(map[string]string{"X-Plan": "plan", "X-Zip": "zip"}),
+			TypedAttributes(HeaderAttributes(map[string]string{"X-Age": "age", "X-Beta": "beta"})),
+			StaticAttributes(map[string]interface{}{"service": "checkout"}),
+		))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "uid", Value: "user1"})
+	r.Header.Set("X-Plan", "premium")
+	r.Header.Set("X-Zip", "02134")
+	r.Header.Set("X-Age", "30")
+	r.Header.Set("X-Beta", "true")
+	s.serve(m, r)
+
+	s.True(s.handled)
+	s.Require().NotNil(s.userContext)
+	s.Equal("user1", s.userContext.GetUserID())
+	s.Equal(map[string]interface{}{"plan": "premium", "zip": "02134", "age": int64(30), "beta": true, "service": "checkout"}, s.userContext.GetUserAttributes())
+	s.Equal("treatment", s.userContext.Decide("checkout", nil).VariationKey)
+	s.kit.EventProcessor.AssertImpression(s.T(), "checkout", "treatment")
+}
+
+func (s *MiddlewareTestSuite) TestMissingUser() {
+	m := New(s.client, HeaderUserID("X-User-ID"))
+	s.serve(m, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.True(s.handled)
+	s.Nil(s.userContext)
+
+	m = New(s.client, HeaderUserID("X-User-ID"), WithMissingUserHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})))
+	s.handled = false
+	recorder := s.serve(m, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.False(s.handled)
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *MiddlewareTestSuite) TestSegments() {
+	s.kit.OdpManager.SetQualifiedSegments("user1", "segment1")
+	m := New(s.client, HeaderUserID("X-User-ID"), WithSegments())
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-User-ID", "user1")
+	s.serve(m, r)
+
+	s.Require().NotNil(s.userContext)
+	s.Equal([]string{"segment1"}, s.userContext.GetQualifiedSegments())
+}
+
+func (s *MiddlewareTestSuite) TestForcedDecisions() {
+	allowed := func(r *http.Request) bool {
+		return r.Header.Get("X-QA") == "secret"
+	}
+	m := New(s.client, HeaderUserID("X-User-ID"), WithForcedDecisions("", allowed))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-User-ID", "user1")
+	r.Header.Set(DefaultForceHeader, "checkout=treatment, invalid, other:rule=on")
+	r.Header.Set("X-QA", "secret")
+	s.serve(m, r)
+
+	s.Require().NotNil(s.userContext)
+	// the mapping of the unknown flag is not imported
+	s.Len(s.userContext.GetForcedDecisions(), 1)
+	decision := s.userContext.Decide("checkout", nil)
+	s.Equal("treatment", decision.VariationKey)
+	s.True(decision.Enabled)
+
+	r.Header.Del("X-QA")
+	s.serve(m, r)
+	s.Require().NotNil(s.userContext)
+	s.Equal("off", s.userContext.Decide("checkout", nil).VariationKey)
+
+	// without allowed no request can force decisions
+	m = New(s.client, HeaderUserID("X-User-ID"), WithForcedDecisions("", nil))
+	r.Header.Set("X-QA", "secret")
+	s.serve(m, r)
+	s.Require().NotNil(s.userContext)
+	s.Empty(s.userContext.GetForcedDecisions())
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func TestParseValue(t *testing.T) {
+	assert.Equal(t, true, parseValue("true"))
+	assert.Equal(t, false, parseValue("false"))
+	assert.Equal(t, int64(1), parseValue("1"))
+	assert.Equal(t, int64(-7), parseValue("-7"))
+	assert.Equal(t, 1.5, parseValue("1.5"))
+	assert.Equal(t, 2e3, parseValue("2e3"))
+	assert.Equal(t, "premium", parseValue("premium"))
+	for _, value := range []string{"0123", "+1", "1.", ".5", "NaN", "Inf", "-Infinity", "0x10", "1_000", "1e400", "TRUE"} {
+		assert.Equal(t, value, parseValue(value), value)
+	}
+}
+
+func TestTypedAttributes(t *testing.T) {
+	extractor := TypedAttributes(StaticAttributes(map[string]interface{}{"age": "30", "zip": "02134", "beta": true}))
+	attributes := extractor(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, map[string]interface{}{"age": int64(30), "zip": "02134", "beta": true}, attributes)
+}
@@ -245,6 +245,101 @@ func (suite *OptimizelyJsonTestSuite) TestGetValueValidJsonKeyArraySchema() {
 
 }
 
+func (suite *OptimizelyJsonTestSuite) TestGetPath() {
+
+	value, err := suite.optimizelyJson.Get("field4.inner_field2.2")
+	suite.NoError(err)
+	suite.Equal(3.01, value)
+
+	value, err = suite.optimizelyJson.Get("")
+	suite.NoError(err)
+	suite.Equal(suite.data, value)
+
+	_, err = suite.optimizelyJson.Get("field4.inner_field2.5")
+	suite.ErrorIs(err, ErrValueNotFound)
+
+	_, err = suite.optimizelyJson.Get("field3.inner")
+	suite.ErrorIs(err, ErrValueNotFound)
+
+	_, err = suite.optimizelyJson.Get("field4..inner_field1")
+	suite.EqualError(err, "json key cannot be empty")
+}
+
+func (suite *OptimizelyJsonTestSuite) TestTypedGetters() {
+
+	intValue, err := suite.optimizelyJson.GetInt("field4.inner_field1")
+	suite.NoError(err)
+	suite.Equal(3, intValue)
+
+	floatValue, err := suite.optimizelyJson.GetFloat64("field2")
+	suite.NoError(err)
+	suite.Equal(2.5, floatValue)
+
+	stringValue, err := suite.optimizelyJson.GetString("field3")
+	suite.NoError(err)
+	suite.Equal("three", stringValue)
+
+	boolValue, err := suite.optimizelyJson.GetBool("field5")
+	suite.NoError(err)
+	suite.True(boolValue)
+
+	mapValue, err := suite.optimizelyJson.GetMap("field4")
+	suite.NoError(err)
+	suite.Equal(suite.data["field4"], mapValue)
+}
+
+func (suite *OptimizelyJsonTestSuite) TestTypedGettersTypeMismatch() {
+
+	_, err := suite.optimizelyJson.GetInt("field2")
+	suite.ErrorIs(err, ErrTypeMismatch)
+	suite.EqualError(err, `value for "field2" is float64, expected int`)
+
+	_, err = suite.optimizelyJson.GetString("field1")
+	suite.ErrorIs(err, ErrTypeMismatch)
+
+	_, err = suite.optimizelyJson.GetBool("field6")
+	suite.ErrorIs(err, ErrTypeMismatch)
+	suite.EqualError(err, `value for "field6" is null, expected bool`)
+
+	_, err = suite.optimizelyJson.GetString("field7")
+	var valueErr *ValueError
+	suite.ErrorAs(err, &valueErr)
+	suite.Equal("field7", valueErr.Path)
+	suite.ErrorIs(err, ErrValueNotFound)
+}
+
+func (suite *OptimizelyJsonTestSuite) TestGetAs() {
+
+	type inner struct {
+		Field1 int           `json:"inner_field1"`
+		Field2 []interface{} `json:"inner_field2"`
+	}
+	value, err := GetAs[inner](suite.optimizelyJson, "field4")
+	suite.NoError(err)
+	suite.Equal(inner{Field1: 3, Field2: suite.dynamicList}, value)
+
+	_, err = GetAs[inner](suite.optimizelyJson, "field3")
+	suite.ErrorIs(err, ErrTypeMismatch)
+
+	int64Value, err := GetAs[int64](NewOptimizelyJSONfromMap(map[string]interface{}{"count": 7}), "count")
+	suite.NoError(err)
+	suite.Equal(int64(7), int64Value)
+
+	nullValue, err := GetAs[interface{}](suite.optimizelyJson, "field6")
+	suite.NoError(err)
+	suite.Nil(nullValue)
+
+	_, err = GetAs[string](nil, "field3")
+	suite.ErrorIs(err, ErrValueNotFound)
+}
+
+func (suite *OptimizelyJsonTestSuite) TestGetAsOr() {
+
+	suite.Equal("three", GetAsOr(suite.optimizelyJson, "field3", "default"))
+	suite.Equal("default", GetAsOr(suite.optimizelyJson, "field1", "default"))
+	suite.Equal(10, GetAsOr(suite.optimizelyJson, "missing", 10))
+}
+
 func TestOptimizelyJsonTestSuite(t *testing.T) {
 	suite.Run(t, new(OptimizelyJsonTestSuite))
 }
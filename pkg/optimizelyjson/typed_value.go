@@ -0,0 +1,227 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelyjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrValueNotFound is returned when no value exists at the requested path
+var ErrValueNotFound = errors.New("value not found")
+
+// ErrTypeMismatch is returned when the value at the requested path cannot be converted to the requested type
+var ErrTypeMismatch = errors.New("value has unexpected type")
+
+// ValueError describes a failed typed lookup, it wraps either ErrValueNotFound or ErrTypeMismatch
+type ValueError struct {
+	Path string
+	Want string
+	Got  string
+	Err  error
+}
+
+func (e *ValueError) Error() string {
+	if errors.Is(e.Err, ErrValueNotFound) {
+		return fmt.Sprintf(`value for "%s" not found`, e.Path)
+	}
+	return fmt.Sprintf(`value for "%s" is %s, expected %s`, e.Path, e.Got, e.Want)
+}
+
+// Unwrap returns the underlying sentinel error
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// Get returns the raw value at the dot separated path, numeric segments index into arrays.
+// An empty path returns the whole object.
+func (optlyJson *OptimizelyJSON) Get(path string) (interface{}, error) {
+	if path == "" {
+		return optlyJson.ToMap(), nil
+	}
+
+	var current interface{} = optlyJson.ToMap()
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "" {
+			return nil, errors.New("json key cannot be empty")
+		}
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			item, ok := v[segment]
+			if !ok {
+				return nil, &ValueError{Path: path, Err: ErrValueNotFound}
+			}
+			current = item
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, &ValueError{Path: path, Err: ErrValueNotFound}
+			}
+			current = v[index]
+		default:
+			return nil, &ValueError{Path: path, Err: ErrValueNotFound}
+		}
+	}
+	return current, nil
+}
+
+// GetString returns the string at the given path
+func (optlyJson *OptimizelyJSON) GetString(path string) (string, error) {
+	return GetAs[string](optlyJson, path)
+}
+
+// GetBool returns the bool at the given path
+func (optlyJson *OptimizelyJSON) GetBool(path string) (bool, error) {
+	return GetAs[bool](optlyJson, path)
+}
+
+// GetInt returns the integer at the given path, whole float values are accepted
+func (optlyJson *OptimizelyJSON) GetInt(path string) (int, error) {
+	return GetAs[int](optlyJson, path)
+}
+
+// GetFloat64 returns the number at the given path
+func (optlyJson *OptimizelyJSON) GetFloat64(path string) (float64, error) {
+	return GetAs[float64](optlyJson, path)
+}
+
+// GetMap returns the object at the given path
+func (optlyJson *OptimizelyJSON) GetMap(path string) (map[string]interface{}, error) {
+	return GetAs[map[string]interface{}](optlyJson, path)
+}
+
+// GetAs returns the value at the given path converted to T. Structs, slices and maps are decoded
+// through their json representation.
+func GetAs[T any](optlyJson *OptimizelyJSON, path string) (T, error) {
+	var zero T
+	if optlyJson == nil {
+		return zero, &ValueError{Path: path, Err: ErrValueNotFound}
+	}
+	value, err := optlyJson.Get(path)
+	if err != nil {
+		return zero, err
+	}
+	return Convert[T](path, value)
+}
+
+// GetAsOr returns the value at the given path converted to T, or fallback if it is missing or has a different type
+func GetAsOr[T any](optlyJson *OptimizelyJSON, path string, fallback T) T {
+	if value, err := GetAs[T](optlyJson, path); err == nil {
+		return value
+	}
+	return fallback
+}
+
+// Convert converts a decoded json value to T, path is only used to describe the error
+func Convert[T any](path string, value interface{}) (T, error) {
+	var result T
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+
+	mismatch := func() (T, error) {
+		var zero T
+		return zero, &ValueError{Path: path, Want: TypeName[T](), Got: typeOf(value), Err: ErrTypeMismatch}
+	}
+	target := reflect.ValueOf(&result).Elem()
+	if value == nil {
+		if target.Kind() == reflect.Interface {
+			return result, nil
+		}
+		return mismatch()
+	}
+
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt64(value)
+		if !ok || target.OverflowInt(n) {
+			return mismatch()
+		}
+		target.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat64(value)
+		if !ok || target.OverflowFloat(f) {
+			return mismatch()
+		}
+		target.SetFloat(f)
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Ptr:
+		jsonBytes, err := json.Marshal(value)
+		if err != nil || json.Unmarshal(jsonBytes, &result) != nil {
+			return mismatch()
+		}
+	default:
+		return mismatch()
+	}
+	return result, nil
+}
+
+// TypeName returns a readable name of T for error messages
+func TypeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func typeOf(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	return reflect.TypeOf(value).String()
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
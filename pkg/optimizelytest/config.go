@@ -0,0 +1,141 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/config"
+	"github.com/optimizely/go-sdk/v2/pkg/config/configdiff"
+	"github.com/optimizely/go-sdk/v2/pkg/config/datafileprojectconfig"
+	"github.com/optimizely/go-sdk/v2/pkg/logging"
+	"github.com/optimizely/go-sdk/v2/pkg/notification"
+)
+
+// ConfigManager is an in-memory config.ProjectConfigManager whose project config is set by the test
+type ConfigManager struct {
+	mutex            sync.RWMutex
+	projectConfig    config.ProjectConfig
+	optimizelyConfig *config.OptimizelyConfig
+	err              error
+	callbacks        map[int]func(notification.ProjectConfigUpdateNotification)
+	nextID           int
+}
+
+// NewConfigManager returns a ConfigManager serving the given project config
+func NewConfigManager(projectConfig config.ProjectConfig) *ConfigManager {
+	return &ConfigManager{
+		projectConfig: projectConfig,
+		callbacks:     map[int]func(notification.ProjectConfigUpdateNotification){},
+	}
+}
+
+// NewConfigManagerFromDatafile returns a ConfigManager serving the project config of the given datafile
+func NewConfigManagerFromDatafile(datafile []byte) (*ConfigManager, error) {
+	projectConfig, err := parseDatafile(datafile)
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigManager(projectConfig), nil
+}
+
+// GetConfig returns the project config, or the error set with SetError
+func (m *ConfigManager) GetConfig() (config.ProjectConfig, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.projectConfig == nil {
+		return nil, errors.New("no project config set")
+	}
+	return m.projectConfig, nil
+}
+
+// GetOptimizelyConfig returns the OptimizelyConfig of the project config
+func (m *ConfigManager) GetOptimizelyConfig() *config.OptimizelyConfig {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.optimizelyConfig == nil && m.projectConfig != nil {
+		m.optimizelyConfig = config.NewOptimizelyConfig(m.projectConfig)
+	}
+	return m.optimizelyConfig
+}
+
+// OnProjectConfigUpdate adds a callback called by SetConfig and SetDatafile
+func (m *ConfigManager) OnProjectConfigUpdate(callback func(notification.ProjectConfigUpdateNotification)) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.nextID++
+	m.callbacks[m.nextID] = callback
+	return m.nextID, nil
+}
+
+// RemoveOnProjectConfigUpdate removes the callback of the given id
+func (m *ConfigManager) RemoveOnProjectConfigUpdate(id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.callbacks, id)
+	return nil
+}
+
+// SetConfig replaces the project config, clears the error and calls the update callbacks
+func (m *ConfigManager) SetConfig(projectConfig config.ProjectConfig) {
+	m.mutex.Lock()
+	previousConfig := m.projectConfig
+	m.projectConfig = projectConfig
+	m.optimizelyConfig = nil
+	m.err = nil
+	callbacks := make([]func(notification.ProjectConfigUpdateNotification), 0, len(m.callbacks))
+	for _, callback := range m.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	m.mutex.Unlock()
+
+	updateNotification := notification.ProjectConfigUpdateNotification{
+		Type:     notification.ProjectConfigUpdate,
+		Revision: projectConfig.GetRevision(),
+	}
+	if previousConfig != nil {
+		updateNotification.Diff = configdiff.Compare(previousConfig, projectConfig)
+	}
+	for _, callback := range callbacks {
+		callback(updateNotification)
+	}
+}
+
+// SetDatafile replaces the project config with the one of the given datafile
+func (m *ConfigManager) SetDatafile(datafile []byte) error {
+	projectConfig, err := parseDatafile(datafile)
+	if err != nil {
+		return err
+	}
+	m.SetConfig(projectConfig)
+	return nil
+}
+
+// SetError makes GetConfig return the given error until the next config is set
+func (m *ConfigManager) SetError(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.err = err
+}
+
+func parseDatafile(datafile []byte) (config.ProjectConfig, error) {
+	return datafileprojectconfig.NewDatafileProjectConfig(datafile, logging.GetLogger("", "DatafileProjectConfig"))
+}
@@ -0,0 +1,259 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+)
+
+// EventProcessor is an in-memory event.Processor which records the user events. Every event is handed to the
+// dispatch callbacks and to the dispatcher, if any, as a log event of its own when it is processed.
+type EventProcessor struct {
+	mutex      sync.RWMutex
+	events     []event.UserEvent
+	dispatcher event.Dispatcher
+	callbacks  map[int]func(event.LogEvent)
+	nextID     int
+	discard    bool
+}
+
+// EPOptionFunc is used to provide custom configuration to the EventProcessor.
+type EPOptionFunc func(*EventProcessor)
+
+// WithDispatcher sets the dispatcher the processed events are sent to.
+func WithDispatcher(dispatcher event.Dispatcher) EPOptionFunc {
+	return func(p *EventProcessor) {
+		p.dispatcher = dispatcher
+	}
+}
+
+// NewEventProcessor returns an EventProcessor with the given options
+func NewEventProcessor(options ...EPOptionFunc) *EventProcessor {
+	p := &EventProcessor{callbacks: map[int]func(event.LogEvent){}}
+	for _, opt := range options {
+		opt(p)
+	}
+	return p
+}
+
+// ProcessEvent records the event and dispatches it. It returns false without recording the event while events are
+// discarded.
+func (p *EventProcessor) ProcessEvent(userEvent event.UserEvent) bool {
+	p.mutex.Lock()
+	if p.discard {
+		p.mutex.Unlock()
+		return false
+	}
+	p.events = append(p.events, userEvent)
+	callbacks := make([]func(event.LogEvent), 0, len(p.callbacks))
+	for _, callback := range p.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	p.mutex.Unlock()
+
+	logEvent := event.CreateLogEvent(userEvent, event.DefaultEventEndPoint)
+	for _, callback := range callbacks {
+		callback(logEvent)
+	}
+	if p.dispatcher != nil {
+		_, _ = p.dispatcher.DispatchEvent(logEvent)
+	}
+	return true
+}
+
+// OnEventDispatch adds a callback called with the log event of every processed event
+func (p *EventProcessor) OnEventDispatch(callback func(logEvent event.LogEvent)) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.nextID++
+	p.callbacks[p.nextID] = callback
+	return p.nextID, nil
+}
+
+// RemoveOnEventDispatch removes the callback of the given id
+func (p *EventProcessor) RemoveOnEventDispatch(id int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.callbacks, id)
+	return nil
+}
+
+// SetDiscard makes ProcessEvent discard the events, as a full queue does
+func (p *EventProcessor) SetDiscard(discard bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.discard = discard
+}
+
+// Events returns the recorded events
+func (p *EventProcessor) Events() []event.UserEvent {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return append([]event.UserEvent{}, p.events...)
+}
+
+// Impressions returns the recorded impression events
+func (p *EventProcessor) Impressions() []event.UserEvent {
+	impressions := []event.UserEvent{}
+	for _, userEvent := range p.Events() {
+		if userEvent.Impression != nil {
+			impressions = append(impressions, userEvent)
+		}
+	}
+	return impressions
+}
+
+// Conversions returns the recorded conversion events
+func (p *EventProcessor) Conversions() []event.UserEvent {
+	conversions := []event.UserEvent{}
+	for _, userEvent := range p.Events() {
+		if userEvent.Conversion != nil {
+			conversions = append(conversions, userEvent)
+		}
+	}
+	return conversions
+}
+
+// FindImpression returns the first impression sent for the given flag and variation. An empty variation key matches
+// any variation.
+func (p *EventProcessor) FindImpression(flagKey, variationKey string) (event.UserEvent, bool) {
+	for _, impression := range p.Impressions() {
+		metadata := impression.Impression.Metadata
+		if metadata.FlagKey == flagKey && (variationKey == "" || metadata.VariationKey == variationKey) {
+			return impression, true
+		}
+	}
+	return event.UserEvent{}, false
+}
+
+// FindConversion returns the first conversion sent for the given event key and user. An empty user ID matches any
+// user.
+func (p *EventProcessor) FindConversion(eventKey, userID string) (event.UserEvent, bool) {
+	for _, conversion := range p.Conversions() {
+		if conversion.Conversion.Key == eventKey && (userID == "" || conversion.VisitorID == userID) {
+			return conversion, true
+		}
+	}
+	return event.UserEvent{}, false
+}
+
+// AssertImpression fails the test unless an impression was sent for the given flag and variation
+func (p *EventProcessor) AssertImpression(t TestingT, flagKey, variationKey string) bool {
+	t.Helper()
+	if _, ok := p.FindImpression(flagKey, variationKey); !ok {
+		t.Errorf("no impression sent for flag %q with variation %q, impressions sent: %s", flagKey, variationKey, describeImpressions(p.Impressions()))
+		return false
+	}
+	return true
+}
+
+// AssertNoImpression fails the test if an impression was sent for the given flag
+func (p *EventProcessor) AssertNoImpression(t TestingT, flagKey string) bool {
+	t.Helper()
+	if impression, ok := p.FindImpression(flagKey, ""); ok {
+		t.Errorf("impression sent for flag %q with variation %q", flagKey, impression.Impression.Metadata.VariationKey)
+		return false
+	}
+	return true
+}
+
+// AssertConversion fails the test unless a conversion was sent for the given event key and user
+func (p *EventProcessor) AssertConversion(t TestingT, eventKey, userID string) bool {
+	t.Helper()
+	if _, ok := p.FindConversion(eventKey, userID); !ok {
+		t.Errorf("no conversion sent for event %q and user %q", eventKey, userID)
+		return false
+	}
+	return true
+}
+
+// Reset removes the recorded events
+func (p *EventProcessor) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.events = nil
+}
+
+// EventDispatcher is an in-memory event.Dispatcher which records the log events
+type EventDispatcher struct {
+	mutex  sync.RWMutex
+	events []event.LogEvent
+	err    error
+}
+
+// NewEventDispatcher returns an EventDispatcher
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{}
+}
+
+// DispatchEvent records the log event, or fails with the error set with SetError without recording it
+func (d *EventDispatcher) DispatchEvent(logEvent event.LogEvent) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		return false, d.err
+	}
+	d.events = append(d.events, logEvent)
+	return true, nil
+}
+
+// SetError makes DispatchEvent fail with the given error, or succeed again when it is nil
+func (d *EventDispatcher) SetError(err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.err = err
+}
+
+// Events returns the recorded log events
+func (d *EventDispatcher) Events() []event.LogEvent {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return append([]event.LogEvent{}, d.events...)
+}
+
+// Visitors returns the visitors of all the recorded log events
+func (d *EventDispatcher) Visitors() []event.Visitor {
+	visitors := []event.Visitor{}
+	for _, logEvent := range d.Events() {
+		visitors = append(visitors, logEvent.Event.Visitors...)
+	}
+	return visitors
+}
+
+// Reset removes the recorded log events
+func (d *EventDispatcher) Reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.events = nil
+}
+
+func describeImpressions(impressions []event.UserEvent) string {
+	if len(impressions) == 0 {
+		return "none"
+	}
+	description := ""
+	for i, impression := range impressions {
+		if i > 0 {
+			description += ", "
+		}
+		description += fmt.Sprintf("%s/%s", impression.Impression.Metadata.FlagKey, impression.Impression.Metadata.VariationKey)
+	}
+	return description
+}
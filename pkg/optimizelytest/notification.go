@@ -0,0 +1,144 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/notification"
+)
+
+// SentNotification is a notification recorded by the NotificationCenter
+type SentNotification struct {
+	Type    notification.Type
+	Payload interface{}
+}
+
+// NotificationCenter is an in-memory notification.Center which records the notifications and calls the handlers
+// synchronously
+type NotificationCenter struct {
+	mutex    sync.RWMutex
+	handlers map[notification.Type]map[int]func(interface{})
+	sent     []SentNotification
+	nextID   int
+}
+
+// NewNotificationCenter returns a NotificationCenter
+func NewNotificationCenter() *NotificationCenter {
+	return &NotificationCenter{handlers: map[notification.Type]map[int]func(interface{}){}}
+}
+
+// AddHandler adds a handler for the given notification type
+func (c *NotificationCenter) AddHandler(notificationType notification.Type, handler func(interface{})) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.handlers[notificationType] == nil {
+		c.handlers[notificationType] = map[int]func(interface{}){}
+	}
+	c.nextID++
+	c.handlers[notificationType][c.nextID] = handler
+	return c.nextID, nil
+}
+
+// RemoveHandler removes the handler of the given id and notification type
+func (c *NotificationCenter) RemoveHandler(id int, notificationType notification.Type) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.handlers[notificationType][id]; !ok {
+		return fmt.Errorf("no handler %d found for type %s", id, notificationType)
+	}
+	delete(c.handlers[notificationType], id)
+	return nil
+}
+
+// Send records the notification and calls the handlers of its type
+func (c *NotificationCenter) Send(notificationType notification.Type, payload interface{}) error {
+	c.mutex.Lock()
+	c.sent = append(c.sent, SentNotification{Type: notificationType, Payload: payload})
+	handlers := make([]func(interface{}), 0, len(c.handlers[notificationType]))
+	for _, handler := range c.handlers[notificationType] {
+		handlers = append(handlers, handler)
+	}
+	c.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+// Notifications returns the payloads of the recorded notifications of the given type
+func (c *NotificationCenter) Notifications(notificationType notification.Type) []interface{} {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	payloads := []interface{}{}
+	for _, sent := range c.sent {
+		if sent.Type == notificationType {
+			payloads = append(payloads, sent.Payload)
+		}
+	}
+	return payloads
+}
+
+// Decisions returns the recorded decision notifications
+func (c *NotificationCenter) Decisions() []notification.DecisionNotification {
+	decisions := []notification.DecisionNotification{}
+	for _, payload := range c.Notifications(notification.Decision) {
+		switch decision := payload.(type) {
+		case notification.DecisionNotification:
+			decisions = append(decisions, decision)
+		case *notification.DecisionNotification:
+			decisions = append(decisions, *decision)
+		}
+	}
+	return decisions
+}
+
+// Tracks returns the recorded track notifications
+func (c *NotificationCenter) Tracks() []notification.TrackNotification {
+	tracks := []notification.TrackNotification{}
+	for _, payload := range c.Notifications(notification.Track) {
+		if track, ok := payload.(notification.TrackNotification); ok {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// AssertFlagDecision fails the test unless a decision notification was sent for the given flag and variation. An
+// empty variation key matches any variation.
+func (c *NotificationCenter) AssertFlagDecision(t TestingT, flagKey, variationKey string) bool {
+	t.Helper()
+	for _, decision := range c.Decisions() {
+		if decision.Type != notification.Flag {
+			continue
+		}
+		if decision.DecisionInfo["flagKey"] == flagKey && (variationKey == "" || decision.DecisionInfo["variationKey"] == variationKey) {
+			return true
+		}
+	}
+	t.Errorf("no decision notification sent for flag %q with variation %q", flagKey, variationKey)
+	return false
+}
+
+// Reset removes the recorded notifications
+func (c *NotificationCenter) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sent = nil
+}
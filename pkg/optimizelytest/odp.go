@@ -0,0 +1,128 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/odp/event"
+	"github.com/optimizely/go-sdk/v2/pkg/odp/segment"
+)
+
+// OdpManager is an in-memory odp.Manager which serves the segments set by the test and records the users and events
+type OdpManager struct {
+	mutex           sync.RWMutex
+	segments        map[string][]string
+	fetchErr        error
+	identifiedUsers []string
+	events          []event.Event
+	apiKey          string
+	apiHost         string
+	segmentsToCheck []string
+}
+
+// NewOdpManager returns an OdpManager
+func NewOdpManager() *OdpManager {
+	return &OdpManager{segments: map[string][]string{}}
+}
+
+// FetchQualifiedSegments returns the segments set for the user, or the error set with SetFetchError
+func (m *OdpManager) FetchQualifiedSegments(userID string, options []segment.OptimizelySegmentOption) (segments []string, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.fetchErr != nil {
+		return nil, m.fetchErr
+	}
+	return append([]string{}, m.segments[userID]...), nil
+}
+
+// IdentifyUser records the user
+func (m *OdpManager) IdentifyUser(userID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.identifiedUsers = append(m.identifiedUsers, userID)
+}
+
+// SendOdpEvent records the event
+func (m *OdpManager) SendOdpEvent(eventType, action string, identifiers map[string]string, data map[string]interface{}) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.events = append(m.events, event.Event{Type: eventType, Action: action, Identifiers: identifiers, Data: data})
+	return nil
+}
+
+// Update records the ODP settings of the project config
+func (m *OdpManager) Update(apiKey, apiHost string, segmentsToCheck []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.apiKey, m.apiHost, m.segmentsToCheck = apiKey, apiHost, segmentsToCheck
+}
+
+// SetQualifiedSegments sets the segments returned for the user
+func (m *OdpManager) SetQualifiedSegments(userID string, segments ...string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.segments[userID] = segments
+}
+
+// SetFetchError makes FetchQualifiedSegments fail with the given error, or succeed again when it is nil
+func (m *OdpManager) SetFetchError(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.fetchErr = err
+}
+
+// IdentifiedUsers returns the users passed to IdentifyUser
+func (m *OdpManager) IdentifiedUsers() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]string{}, m.identifiedUsers...)
+}
+
+// Events returns the recorded events
+func (m *OdpManager) Events() []event.Event {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]event.Event{}, m.events...)
+}
+
+// SegmentsToCheck returns the segments of the last update
+func (m *OdpManager) SegmentsToCheck() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]string{}, m.segmentsToCheck...)
+}
+
+// AssertEventSent fails the test unless an event of the given type and action was sent
+func (m *OdpManager) AssertEventSent(t TestingT, eventType, action string) bool {
+	t.Helper()
+	for _, odpEvent := range m.Events() {
+		if odpEvent.Type == eventType && odpEvent.Action == action {
+			return true
+		}
+	}
+	t.Errorf("no ODP event sent with type %q and action %q", eventType, action)
+	return false
+}
+
+// Reset removes the recorded users and events
+func (m *OdpManager) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.identifiedUsers = nil
+	m.events = nil
+}
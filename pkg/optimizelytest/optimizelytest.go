@@ -0,0 +1,71 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+// Package optimizelytest provides in-memory fakes of the SDK interfaces and assertion helpers for testing code which
+// uses the Optimizely client.
+package optimizelytest
+
+import (
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+)
+
+// TestingT is the subset of testing.TB used by the assertion helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Kit bundles a fake of every service of the client
+type Kit struct {
+	ConfigManager      *ConfigManager
+	EventProcessor     *EventProcessor
+	EventDispatcher    *EventDispatcher
+	OdpManager         *OdpManager
+	NotificationCenter *NotificationCenter
+	Tracer             *Tracer
+}
+
+// NewKit returns a Kit with a config manager serving the given datafile
+func NewKit(datafile []byte) (*Kit, error) {
+	configManager, err := NewConfigManagerFromDatafile(datafile)
+	if err != nil {
+		return nil, err
+	}
+
+	eventDispatcher := NewEventDispatcher()
+	return &Kit{
+		ConfigManager:      configManager,
+		EventProcessor:     NewEventProcessor(WithDispatcher(eventDispatcher)),
+		EventDispatcher:    eventDispatcher,
+		OdpManager:         NewOdpManager(),
+		NotificationCenter: NewNotificationCenter(),
+		Tracer:             NewTracer(),
+	}, nil
+}
+
+// Client returns a client using the fakes of the kit. The given options are applied after the fakes, so they can
+// replace any of them.
+func (k *Kit) Client(clientOptions ...client.OptionFunc) (*client.OptimizelyClient, error) {
+	factory := &client.OptimizelyFactory{}
+	options := []client.OptionFunc{
+		client.WithConfigManager(k.ConfigManager),
+		client.WithEventProcessor(k.EventProcessor),
+		client.WithOdpManager(k.OdpManager),
+		client.WithNotificationCenter(k.NotificationCenter),
+		client.WithTracer(k.Tracer),
+	}
+	return factory.Client(append(options, clientOptions...)...)
+}
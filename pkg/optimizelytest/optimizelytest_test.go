@@ -0,0 +1,194 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/optimizely/go-sdk/v2/pkg/client"
+	"github.com/optimizely/go-sdk/v2/pkg/event"
+	"github.com/optimizely/go-sdk/v2/pkg/notification"
+)
+
+const testDatafile = `{
+	"version": "4", "revision": "1", "projectId": "11", "accountId": "12", "sendFlagDecisions": true,
+	"events": [{"id": "21", "key": "purchase", "experimentIds": []}],
+	"featureFlags": [{"id": "31", "key": "flag_1", "rolloutId": "41", "experimentIds": [], "variables": []}],
+	"rollouts": [{"id": "41", "experiments": [{
+		"id": "51", "key": "everyone", "layerId": "41", "status": "Running", "audienceIds": [],
+		"trafficAllocation": [{"entityId": "61", "endOfRange": 10000}],
+		"variations": [{"id": "61", "key": "on", "featureEnabled": true, "variables": []}]
+	}]}],
+	"experiments": [], "audiences": [], "attributes": [], "groups": []
+}`
+
+// recordingT records the failures of the assertion helpers
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+type KitTestSuite struct {
+	suite.Suite
+	kit    *Kit
+	client *client.OptimizelyClient
+}
+
+func (s *KitTestSuite) SetupTest() {
+	var err error
+	s.kit, err = NewKit([]byte(testDatafile))
+	s.Require().NoError(err)
+	s.client, err = s.kit.Client()
+	s.Require().NoError(err)
+}
+
+func (s *KitTestSuite) TearDownTest() {
+	s.client.Close()
+}
+
+func (s *KitTestSuite) TestDecide() {
+	user := s.client.CreateUserContext("user1", nil)
+	decision := user.Decide("flag_1", nil)
+	s.Equal("on", decision.VariationKey)
+
+	s.True(s.kit.EventProcessor.AssertImpression(s.T(), "flag_1", "on"))
+	s.True(s.kit.EventProcessor.AssertImpression(s.T(), "flag_1", ""))
+	s.True(s.kit.NotificationCenter.AssertFlagDecision(s.T(), "flag_1", "on"))
+	s.True(s.kit.Tracer.AssertSpan(s.T(), client.SpanNameDecide))
+	s.Len(s.kit.EventDispatcher.Visitors(), 1)
+	s.Equal("user1", s.kit.EventDispatcher.Visitors()[0].VisitorID)
+}
+
+func (s *KitTestSuite) TestFailedAssertions() {
+	t := &recordingT{}
+	s.False(s.kit.EventProcessor.AssertImpression(t, "flag_1", "on"))
+	s.Equal([]string{`no impression sent for flag "flag_1" with variation "on", impressions sent: none`}, t.errors)
+
+	user := s.client.CreateUserContext("user1", nil)
+	user.Decide("flag_1", nil)
+
+	t = &recordingT{}
+	s.False(s.kit.EventProcessor.AssertImpression(t, "flag_1", "off"))
+	s.False(s.kit.EventProcessor.AssertNoImpression(t, "flag_1"))
+	s.False(s.kit.EventProcessor.AssertConversion(t, "purchase", "user1"))
+	s.False(s.kit.NotificationCenter.AssertFlagDecision(t, "flag_1", "off"))
+	s.False(s.kit.OdpManager.AssertEventSent(t, "fullstack", "identified"))
+	s.False(s.kit.Tracer.AssertSpan(t, "unknown"))
+	s.Len(t.errors, 6)
+	s.Equal(`no impression sent for flag "flag_1" with variation "off", impressions sent: flag_1/on`, t.errors[0])
+}
+
+func (s *KitTestSuite) TestTrackEvent() {
+	user := s.client.CreateUserContext("user1", nil)
+	s.NoError(user.TrackEvent("purchase", map[string]interface{}{"revenue": 100}))
+
+	s.True(s.kit.EventProcessor.AssertConversion(s.T(), "purchase", "user1"))
+	s.True(s.kit.EventProcessor.AssertNoImpression(s.T(), "flag_1"))
+	s.Len(s.kit.NotificationCenter.Tracks(), 1)
+
+	s.kit.EventProcessor.Reset()
+	s.Empty(s.kit.EventProcessor.Events())
+}
+
+func (s *KitTestSuite) TestDiscardEvents() {
+	s.kit.EventProcessor.SetDiscard(true)
+	user := s.client.CreateUserContext("user1", nil)
+	user.Decide("flag_1", nil)
+
+	s.Empty(s.kit.EventProcessor.Events())
+	s.Empty(s.kit.EventDispatcher.Events())
+}
+
+func (s *KitTestSuite) TestOdpManager() {
+	s.kit.OdpManager.SetQualifiedSegments("user1", "segment1", "segment2")
+	user := s.client.CreateUserContext("user1", nil)
+	s.True(user.FetchQualifiedSegments(nil))
+	s.Equal([]string{"segment1", "segment2"}, user.GetQualifiedSegments())
+	s.Contains(s.kit.OdpManager.IdentifiedUsers(), "user1")
+
+	s.kit.OdpManager.SetFetchError(errors.New("unavailable"))
+	s.False(user.FetchQualifiedSegments(nil))
+
+	s.NoError(s.client.SendOdpEvent("fullstack", "purchased", map[string]string{"fs_user_id": "user1"}, nil))
+	s.True(s.kit.OdpManager.AssertEventSent(s.T(), "fullstack", "purchased"))
+}
+
+func (s *KitTestSuite) TestConfigManager() {
+	revisions := []string{}
+	id, err := s.kit.ConfigManager.OnProjectConfigUpdate(func(n notification.ProjectConfigUpdateNotification) {
+		revisions = append(revisions, n.Revision)
+	})
+	s.NoError(err)
+
+	s.NoError(s.kit.ConfigManager.SetDatafile([]byte(`{"version": "4", "revision": "2"}`)))
+	s.Equal("2", s.client.GetOptimizelyConfig().Revision)
+	s.Equal([]string{"2"}, revisions)
+
+	s.kit.ConfigManager.SetError(errors.New("unavailable"))
+	s.False(s.client.IsReady())
+
+	s.NoError(s.kit.ConfigManager.RemoveOnProjectConfigUpdate(id))
+	s.NoError(s.kit.ConfigManager.SetDatafile([]byte(`{"version": "4", "revision": "3"}`)))
+	s.Equal([]string{"2"}, revisions)
+
+	s.Error(s.kit.ConfigManager.SetDatafile([]byte(`{}`)))
+}
+
+func TestKitTestSuite(t *testing.T) {
+	suite.Run(t, new(KitTestSuite))
+}
+
+func TestNotificationCenter(t *testing.T) {
+	center := NewNotificationCenter()
+	payloads := []interface{}{}
+	id, err := center.AddHandler(notification.Track, func(payload interface{}) {
+		payloads = append(payloads, payload)
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, center.Send(notification.Track, "track"))
+	assert.NoError(t, center.Send(notification.LogEvent, "log"))
+	assert.Equal(t, []interface{}{"track"}, payloads)
+	assert.Equal(t, []interface{}{"log"}, center.Notifications(notification.LogEvent))
+
+	assert.NoError(t, center.RemoveHandler(id, notification.Track))
+	assert.Error(t, center.RemoveHandler(id, notification.Track))
+}
+
+func TestEventDispatcherError(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+	dispatcher.SetError(errors.New("unavailable"))
+
+	processor := NewEventProcessor(WithDispatcher(dispatcher))
+	logEvents := 0
+	_, err := processor.OnEventDispatch(func(logEvent event.LogEvent) { logEvents++ })
+	assert.NoError(t, err)
+
+	assert.True(t, processor.ProcessEvent(event.UserEvent{VisitorID: "user1"}))
+	assert.Equal(t, 1, logEvents)
+	assert.Empty(t, dispatcher.Events())
+}
@@ -0,0 +1,121 @@
+/****************************************************************************
+ * Copyright 2026, Optimizely, Inc. and contributors                        *
+ *                                                                          *
+ * Licensed under the Apache License, Version 2.0 (the "License");          *
+ * you may not use this file except in compliance with the License.         *
+ * You may obtain a copy of the License at                                  *
+ *                                                                          *
+ *    https://www.apache.org/licenses/LICENSE-2.0                           *
+ *                                                                          *
+ * Unless required by applicable law or agreed to in writing, software      *
+ * distributed under the License is distributed on an "AS IS" BASIS,        *
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. *
+ * See the License for the specific language governing permissions and      *
+ * limitations under the License.                                           *
+ ***************************************************************************/
+
+package optimizelytest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/optimizely/go-sdk/v2/pkg/tracing"
+)
+
+// Tracer is an in-memory tracing.Tracer which records the spans
+type Tracer struct {
+	mutex sync.RWMutex
+	spans []*Span
+}
+
+// Span is a span recorded by the Tracer
+type Span struct {
+	TracerName string
+	Name       string
+
+	mutex      sync.RWMutex
+	attributes map[string]interface{}
+	ended      bool
+}
+
+// NewTracer returns a Tracer
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// StartSpan records and returns a new span
+func (t *Tracer) StartSpan(ctx context.Context, tracerName, spanName string) (context.Context, tracing.Span) {
+	span := &Span{TracerName: tracerName, Name: spanName, attributes: map[string]interface{}{}}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+// Spans returns the recorded spans
+func (t *Tracer) Spans() []*Span {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return append([]*Span{}, t.spans...)
+}
+
+// SpanNames returns the names of the recorded spans in the order they were started
+func (t *Tracer) SpanNames() []string {
+	names := []string{}
+	for _, span := range t.Spans() {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+// AssertSpan fails the test unless a span of the given name was started and ended
+func (t *Tracer) AssertSpan(tt TestingT, spanName string) bool {
+	tt.Helper()
+	for _, span := range t.Spans() {
+		if span.Name == spanName && span.Ended() {
+			return true
+		}
+	}
+	tt.Errorf("no span %q ended, spans started: %v", spanName, t.SpanNames())
+	return false
+}
+
+// Reset removes the recorded spans
+func (t *Tracer) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = nil
+}
+
+// End ends the span
+func (s *Span) End() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ended = true
+}
+
+// SetAttibutes sets an attribute of the span
+func (s *Span) SetAttibutes(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes[key] = value
+}
+
+// Ended returns true once the span was ended
+func (s *Span) Ended() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.ended
+}
+
+// Attributes returns the attributes of the span
+func (s *Span) Attributes() map[string]interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	attributes := make(map[string]interface{}, len(s.attributes))
+	for key, value := range s.attributes {
+		attributes[key] = value
+	}
+	return attributes
+}
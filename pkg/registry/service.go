@@ -39,3 +39,12 @@ func GetNotificationCenter(sdkKey string) notification.Center {
 
 	return notificationCenter
 }
+
+// RemoveNotificationCenter removes the notification center associated with the given SDK Key, so that the next call to
+// GetNotificationCenter creates a new one
+func RemoveNotificationCenter(sdkKey string) {
+	notificationLock.Lock()
+	defer notificationLock.Unlock()
+
+	delete(notificationCenterCache, sdkKey)
+}
@@ -44,6 +44,17 @@ func (s *ServiceRegistryTestSuite) TestGetNotificationCenter() {
 	s.Equal(notificationCenter, notificationCenter2)
 }
 
+func (s *ServiceRegistryTestSuite) TestRemoveNotificationCenter() {
+	sdkKey := "sdk_key_remove"
+	notificationCenter := GetNotificationCenter(sdkKey)
+
+	RemoveNotificationCenter(sdkKey)
+	s.NotSame(notificationCenter, GetNotificationCenter(sdkKey))
+
+	// removing an unknown key is a no-op
+	RemoveNotificationCenter("unknown_sdk_key")
+}
+
 func TestServiceRegistryTestSuite(t *testing.T) {
 	suite.Run(t, new(ServiceRegistryTestSuite))
 }